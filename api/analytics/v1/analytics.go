@@ -0,0 +1,132 @@
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// 内容缺口报告
+//
+type ContentGapsReq struct {
+	g.Meta    `path:"/analytics/gaps" method:"get" tags:"统计分析" summary:"将未被满足的查询聚类为内容缺口主题"`
+	RepoName  string      `json:"repo_name" in:"query" v:"repo-name#知识库名称不合法"`            // 知识库名称，不填则处理全部知识库
+	StartTime *gtime.Time `json:"start_time" in:"query"`                                  // 时间起（含），格式:YYYY-MM-DD HH:MM:SS
+	EndTime   *gtime.Time `json:"end_time" in:"query"`                                    // 时间止（含），格式:YYYY-MM-DD HH:MM:SS
+	Limit     int         `json:"limit" in:"query" v:"min:0|max:100#主题数量不能小于0|主题数量最多100"` // 返回的主题数量，不填则使用配置
+}
+
+type ContentGapsRes struct {
+	Signals  int          `json:"signals"`  // 参与统计的信号数量（点踩、无相关结果反馈、零结果与低分检索）
+	Queries  int          `json:"queries"`  // 归一化去重后的查询数量
+	Skipped  int          `json:"skipped"`  // 超过数量上限或向量化失败而未参与聚类的查询数量
+	Clusters []GapCluster `json:"clusters"` // 主题列表，按信号数量从多到少排列
+}
+
+// GapCluster 语义相近的一组未被满足的查询
+type GapCluster struct {
+	Label    string     `json:"label"`     // 大模型生成的主题名称，生成失败时为出现次数最多的查询
+	RepoName string     `json:"repo_name"` // 知识库名称，跨知识库或未知时为空
+	Count    int        `json:"count"`     // 组内信号数量之和
+	Signals  GapSignals `json:"signals"`   // 按类型统计的信号数量
+	Queries  []GapQuery `json:"queries"`   // 组内的查询，按信号数量从多到少排列
+}
+
+// GapQuery 未被满足的查询
+type GapQuery struct {
+	Query   string     `json:"query"`   // 出现次数最多的原始写法
+	Count   int        `json:"count"`   // 信号数量
+	Signals GapSignals `json:"signals"` // 按类型统计的信号数量
+}
+
+// GapSignals 查询未被满足的信号
+type GapSignals struct {
+	Dislikes   int `json:"dislikes"`    // 点踩多于点赞的查询上的点踩数
+	NoRelevant int `json:"no_relevant"` // “没有相关结果”的查询反馈数
+	ZeroResult int `json:"zero_result"` // 没有检索结果的次数
+	LowScore   int `json:"low_score"`   // 结果分数低于阈值的次数
+}
+
+// 查询检索记录
+//
+type SearchLogListReq struct {
+	g.Meta      `path:"/analytics/search_logs" method:"get" tags:"统计分析" summary:"分页查询检索记录"`
+	RepoName    string      `json:"repo_name" in:"query" v:"repo-name#知识库名称不合法"`                  // 检索过该知识库的记录
+	SessionID   string      `json:"session_id" in:"query"`                                        // 按会话ID过滤
+	Source      string      `json:"source" in:"query" v:"in:,search,answer#检索来源必须是search或answer"` // 按检索来源过滤：search/answer
+	Query       string      `json:"query" in:"query"`                                             // 原始查询或实际检索的查询包含该内容
+	KnowledgeID string      `json:"knowledge_id" in:"query"`                                      // 结果中包含该知识
+	Failed      bool        `json:"failed" in:"query"`                                            // 只查询失败的检索
+	ZeroResult  bool        `json:"zero_result" in:"query"`                                       // 只查询没有结果的检索
+	StartTime   *gtime.Time `json:"start_time" in:"query"`                                        // 时间起（含），格式:YYYY-MM-DD HH:MM:SS
+	EndTime     *gtime.Time `json:"end_time" in:"query"`                                          // 时间止（含），格式:YYYY-MM-DD HH:MM:SS
+	Page        int         `json:"page" in:"query" d:"1"`                                        // 页码
+	PageSize    int         `json:"page_size" in:"query" d:"10" v:"max:100#每页最多100条"`             // 每页数量
+}
+
+type SearchLogListRes struct {
+	List  []SearchLog `json:"list"`  // 检索记录，按时间倒序
+	Total int         `json:"total"` // 总条数
+	Page  int         `json:"page"`  // 当前页码
+}
+
+// 获取检索记录
+//
+type SearchLogGetReq struct {
+	g.Meta   `path:"/analytics/search_logs/:search_id" method:"get" tags:"统计分析" summary:"按检索ID获取检索记录"`
+	SearchID string `json:"search_id" in:"path" v:"required#检索ID不能为空"` // 检索ID
+}
+
+type SearchLogGetRes struct {
+	SearchLog
+}
+
+// SearchLog 一次检索的记录
+type SearchLog struct {
+	ID          uint64            `json:"id"`                  // 记录ID
+	SearchID    string            `json:"search_id"`           // 检索ID，与检索响应及展示记录中的相同
+	Source      string            `json:"source"`              // 检索来源：search/answer
+	SessionID   string            `json:"session_id"`          // 用户会话ID
+	Query       string            `json:"query"`               // 用户原始查询
+	SearchQuery string            `json:"search_query"`        // 实际用于检索的查询，多轮会话中为改写后的查询
+	RepoNames   []string          `json:"repo_names"`          // 检索的知识库，为空表示全部知识库
+	Mode        string            `json:"mode"`                // 检索模式
+	Fusion      string            `json:"fusion"`              // 实际使用的融合策略
+	Params      SearchLogParams   `json:"params"`              // 检索参数
+	Filter      interface{}       `json:"filter,omitempty"`    // 过滤条件
+	Results     []SearchLogResult `json:"results"`             // 返回的知识ID与分数
+	Total       uint64            `json:"total"`               // 满足过滤条件的候选总数
+	TopScore    *float32          `json:"top_score,omitempty"` // 第一条结果的排序分数，没有结果时为空
+	Cached      bool              `json:"cached"`              // 是否命中检索缓存
+	LatencyMs   float64           `json:"latency_ms"`          // 总耗时（毫秒）
+	Timings     []StageTiming     `json:"timings"`             // 各阶段耗时，命中缓存与关键词检索时为空
+	Error       string            `json:"error,omitempty"`     // 失败原因
+	CreatedAt   string            `json:"created_at"`          // 检索时间
+}
+
+// SearchLogParams 检索参数，未填写的项为空
+type SearchLogParams struct {
+	Limit     uint64   `json:"limit"`               // 返回结果数量
+	Offset    uint64   `json:"offset"`              // 跳过的结果数量
+	MinScore  *float32 `json:"min_score,omitempty"` // 最低分数
+	Fusion    string   `json:"fusion,omitempty"`    // 请求的融合策略
+	Alpha     *float32 `json:"alpha,omitempty"`     // weighted 策略的密集向量权重
+	Beta      *float32 `json:"beta,omitempty"`      // weighted 策略的标签稀疏向量权重
+	Rerank    *bool    `json:"rerank,omitempty"`    // 是否重排序
+	Strategy  string   `json:"strategy,omitempty"`  // 检索策略
+	Highlight bool     `json:"highlight,omitempty"` // 是否返回高亮片段
+	Explain   bool     `json:"explain,omitempty"`   // 是否返回检索过程说明
+}
+
+// SearchLogResult 检索记录中的一条结果
+type SearchLogResult struct {
+	ID          string   `json:"id"`                     // 知识ID
+	RepoName    string   `json:"repo_name"`              // 知识库名称
+	Score       float32  `json:"score"`                  // 检索分数
+	RerankScore *float32 `json:"rerank_score,omitempty"` // 重排序分数
+}
+
+// StageTiming 阶段耗时
+type StageTiming struct {
+	Stage string  `json:"stage"` // 阶段名称
+	Ms    float64 `json:"ms"`    // 耗时（毫秒）
+}
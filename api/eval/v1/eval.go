@@ -0,0 +1,238 @@
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// 创建评测集
+//
+type EvalSetCreateReq struct {
+	g.Meta      `path:"/eval/sets" method:"post" tags:"评测管理" summary:"创建检索评测集"`
+	RepoName    string      `json:"repo_name" v:"required|repo-name#知识库名称不能为空|知识库名称不合法"`       // 评测的知识库
+	Name        string      `json:"name" v:"required|max-length:128#评测集名称不能为空|评测集名称长度不能超过128"` // 评测集名称，同一知识库内唯一
+	Description string      `json:"description" v:"max-length:512#评测集说明长度不能超过512"`             // 评测集说明
+	Queries     []EvalQuery `json:"queries"`                                                   // 带相关性标注的查询
+}
+
+type EvalSetCreateRes struct {
+	ID uint64 `json:"id"` // 评测集ID
+}
+
+// 查询评测集列表
+//
+type EvalSetListReq struct {
+	g.Meta   `path:"/eval/sets" method:"get" tags:"评测管理" summary:"查询检索评测集列表"`
+	RepoName string `json:"repo_name" in:"query" v:"repo-name#知识库名称不合法"` // 按知识库过滤，不填则返回全部
+}
+
+type EvalSetListRes struct {
+	List []EvalSet `json:"list"`
+}
+
+// 获取评测集详情
+//
+type EvalSetGetReq struct {
+	g.Meta `path:"/eval/sets/:id" method:"get" tags:"评测管理" summary:"获取检索评测集及其查询"`
+	ID     uint64 `json:"id" in:"path" v:"required#评测集ID不能为空"`
+}
+
+type EvalSetGetRes struct {
+	EvalSet
+	Queries []EvalQuery `json:"queries"`
+}
+
+// 删除评测集
+//
+type EvalSetDeleteReq struct {
+	g.Meta `path:"/eval/sets/:id" method:"delete" tags:"评测管理" summary:"删除检索评测集"`
+	ID     uint64 `json:"id" in:"path" v:"required#评测集ID不能为空"`
+}
+
+type EvalSetDeleteRes struct{}
+
+// 追加评测查询
+//
+type EvalQueryAddReq struct {
+	g.Meta  `path:"/eval/sets/:id/queries" method:"post" tags:"评测管理" summary:"向检索评测集追加查询"`
+	ID      uint64      `json:"id" in:"path" v:"required#评测集ID不能为空"`
+	Queries []EvalQuery `json:"queries" v:"required#查询不能为空"`
+}
+
+type EvalQueryAddRes struct {
+	Added int `json:"added"` // 追加的查询数量
+}
+
+// 评测检索配置
+//
+type EvalRunReq struct {
+	g.Meta `path:"/eval/run" method:"post" tags:"评测管理" summary:"使用指定检索配置运行评测集"`
+	SetID  uint64     `json:"set_id" v:"required#评测集ID不能为空"`
+	K      int        `json:"k" d:"10" v:"between:1,100#k必须在1到100之间"` // 评测的结果数量
+	Config EvalConfig `json:"config"`                                 // 检索配置，不填的项使用当前配置
+}
+
+type EvalRunRes struct {
+	*EvalReport
+}
+
+// 对比检索配置
+//
+type EvalCompareReq struct {
+	g.Meta    `path:"/eval/compare" method:"post" tags:"评测管理" summary:"在同一评测集上对比两个检索配置"`
+	SetID     uint64     `json:"set_id" v:"required#评测集ID不能为空"`
+	K         int        `json:"k" d:"10" v:"between:1,100#k必须在1到100之间"` // 评测的结果数量
+	Baseline  EvalConfig `json:"baseline"`                               // 基准配置
+	Candidate EvalConfig `json:"candidate"`                              // 候选配置
+}
+
+type EvalCompareRes struct {
+	Baseline  *EvalReport      `json:"baseline"`  // 基准配置的评测结果
+	Candidate *EvalReport      `json:"candidate"` // 候选配置的评测结果
+	Delta     EvalDelta        `json:"delta"`     // 候选配置减去基准配置的指标差值
+	Changed   []EvalQueryDelta `json:"changed"`   // nDCG 有变化的查询，按变化量从差到好排列
+}
+
+// 预览由反馈生成的标注
+//
+type EvalFeedbackPreviewReq struct {
+	g.Meta `path:"/eval/feedback" method:"get" tags:"评测管理" summary:"预览由用户反馈生成的相关性标注"`
+	FeedbackRange
+}
+
+type EvalFeedbackPreviewRes struct {
+	Feedbacks int             `json:"feedbacks"` // 参与统计的反馈数量
+	Skipped   int             `json:"skipped"`   // 没有正向标注而被丢弃的查询数量
+	Queries   []FeedbackQuery `json:"queries"`   // 归一化去重后的查询
+}
+
+// 由反馈生成评测集
+//
+type EvalFeedbackSetsReq struct {
+	g.Meta `path:"/eval/feedback/sets" method:"post" tags:"评测管理" summary:"由用户反馈生成评测集"`
+	FeedbackRange
+	Name string `json:"name" v:"max-length:128#评测集名称长度不能超过128"` // 评测集名称，每个知识库各创建一个，不填则为 feedback-<时间>
+}
+
+type EvalFeedbackSetsRes struct {
+	Feedbacks int       `json:"feedbacks"` // 参与统计的反馈数量
+	Skipped   int       `json:"skipped"`   // 没有正向标注而被丢弃的查询数量
+	Sets      []EvalSet `json:"sets"`      // 创建的评测集
+}
+
+// 导出训练样本
+//
+type EvalFeedbackPairsReq struct {
+	g.Meta `path:"/eval/feedback/pairs" method:"get" tags:"评测管理" summary:"由用户反馈导出 JSONL 训练样本"`
+	FeedbackRange
+}
+
+// EvalFeedbackPairsRes 以 JSONL 文件返回，每行为 {"query": "...", "pos": ["..."], "neg": ["..."]}
+type EvalFeedbackPairsRes struct{}
+
+// FeedbackRange 参与生成标注的反馈范围
+type FeedbackRange struct {
+	RepoName  string      `json:"repo_name" v:"repo-name#知识库名称不合法"` // 知识库名称，不填则处理全部知识库
+	StartTime *gtime.Time `json:"start_time"`                       // 反馈时间起（含），格式:YYYY-MM-DD HH:MM:SS
+	EndTime   *gtime.Time `json:"end_time"`                         // 反馈时间止（含），格式:YYYY-MM-DD HH:MM:SS
+}
+
+// FeedbackQuery 归一化后相同的一组查询及其相关性标注
+type FeedbackQuery struct {
+	RepoName  string         `json:"repo_name"`
+	Query     string         `json:"query"`     // 出现次数最多的原始写法
+	Variants  int            `json:"variants"`  // 合并的不同写法数量
+	Feedbacks int            `json:"feedbacks"` // 去重后的反馈数量
+	Judgments []EvalJudgment `json:"judgments"` // 相关性标注，相关度为0的是以点踩为主的负例
+}
+
+// EvalSet 检索评测集
+type EvalSet struct {
+	ID          uint64      `json:"id"`
+	RepoName    string      `json:"repo_name"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	QueryCount  int         `json:"query_count"` // 查询数量
+	CreatedAt   *gtime.Time `json:"created_at"`
+	UpdatedAt   *gtime.Time `json:"updated_at"`
+}
+
+// EvalQuery 带相关性标注的评测查询
+type EvalQuery struct {
+	ID        uint64         `json:"id"` // 查询ID，创建与追加时忽略
+	Query     string         `json:"query" v:"required#查询内容不能为空"`
+	Judgments []EvalJudgment `json:"judgments" v:"required#相关性标注不能为空"` // 至少包含一条相关度大于0的标注
+}
+
+// EvalJudgment 相关性标注
+type EvalJudgment struct {
+	ID    string `json:"id" v:"required#知识条目ID不能为空"`          // 知识条目ID
+	Grade int    `json:"grade" v:"between:0,3#相关度等级必须在0到3之间"` // 相关度等级：0 不相关，1 部分相关，2 相关，3 高度相关
+}
+
+// EvalConfig 评测使用的检索配置，不填的项使用当前配置文件的值
+type EvalConfig struct {
+	Name               string   `json:"name"`                                                                                               // 配置名称，用于在报告中区分
+	Mode               string   `json:"mode" v:"in:keyword,semantic,hybrid,lexical#检索模式必须是 keyword/semantic/hybrid/lexical 之一"`             // 检索模式，默认 hybrid
+	Fusion             string   `json:"fusion" v:"in:dense,sparse_dense,rrf,dbsf,weighted#融合策略必须是 dense/sparse_dense/rrf/dbsf/weighted 之一"` // 融合策略
+	Alpha              *float32 `json:"alpha,omitempty" v:"min:0#alpha不能为负数"`                                                               // weighted 策略的密集向量权重
+	Beta               *float32 `json:"beta,omitempty" v:"min:0#beta不能为负数"`                                                                 // weighted 策略的标签稀疏向量权重
+	Rerank             *bool    `json:"rerank,omitempty"`                                                                                   // 是否重排序
+	Strategy           string   `json:"strategy" v:"in:standard,multi_query,hyde#检索策略必须是 standard/multi_query/hyde 之一"`                     // 检索策略
+	LabelThreshold     *float32 `json:"label_threshold,omitempty" v:"between:0,5#查询标签阈值必须在0到5之间"`                                           // 查询标签阈值（1-5分），覆盖 llm.label_threshold
+	PrefetchMultiplier *uint64  `json:"prefetch_multiplier,omitempty" v:"min:1#预查询倍数不能小于1"`                                                 // 预查询候选数量倍数，覆盖 search.prefetch_multiplier
+}
+
+// EvalReport 单个检索配置在评测集上的评测结果，指标为检索成功的查询的平均值
+type EvalReport struct {
+	SetID    uint64            `json:"set_id"`
+	SetName  string            `json:"set_name"`
+	RepoName string            `json:"repo_name"`
+	Config   EvalConfig        `json:"config"`
+	K        int               `json:"k"`
+	Queries  int               `json:"queries"` // 查询数量
+	Failed   int               `json:"failed"`  // 检索失败的查询数量，不计入指标
+	Recall   float64           `json:"recall"`  // recall@k
+	MRR      float64           `json:"mrr"`     // 平均倒数排名
+	NDCG     float64           `json:"ndcg"`    // nDCG@k
+	Latency  EvalLatency       `json:"latency"` // 检索耗时（毫秒）
+	Details  []EvalQueryResult `json:"details"` // 各查询的评测结果
+}
+
+// EvalLatency 检索耗时统计（毫秒）
+type EvalLatency struct {
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P95  float64 `json:"p95"`
+	Max  float64 `json:"max"`
+}
+
+// EvalQueryResult 单个查询的评测结果
+type EvalQueryResult struct {
+	QueryID   uint64   `json:"query_id"`
+	Query     string   `json:"query"`
+	Recall    float64  `json:"recall"`
+	RR        float64  `json:"rr"` // 第一个相关结果排名的倒数
+	NDCG      float64  `json:"ndcg"`
+	LatencyMs float64  `json:"latency_ms"`
+	Retrieved []string `json:"retrieved"`       // 检索到的前 k 条知识ID
+	Error     string   `json:"error,omitempty"` // 检索失败的原因
+}
+
+// EvalDelta 指标差值
+type EvalDelta struct {
+	Recall     float64 `json:"recall"`
+	MRR        float64 `json:"mrr"`
+	NDCG       float64 `json:"ndcg"`
+	LatencyP50 float64 `json:"latency_p50"`
+	LatencyP95 float64 `json:"latency_p95"`
+}
+
+// EvalQueryDelta 单个查询在两个配置下的 nDCG
+type EvalQueryDelta struct {
+	QueryID   uint64  `json:"query_id"`
+	Query     string  `json:"query"`
+	Baseline  float64 `json:"baseline"`
+	Candidate float64 `json:"candidate"`
+	Delta     float64 `json:"delta"`
+}
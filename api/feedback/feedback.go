@@ -14,4 +14,14 @@ type IFeedbackV1 interface {
 	FeedbackAdd(ctx context.Context, req *v1.FeedbackAddReq) (res *v1.FeedbackAddRes, err error)
 	FeedbackList(ctx context.Context, req *v1.FeedbackListReq) (res *v1.FeedbackListRes, err error)
 	FeedbackProcess(ctx context.Context, req *v1.FeedbackProcessReq) (res *v1.FeedbackProcessRes, err error)
+	FeedbackRunList(ctx context.Context, req *v1.FeedbackRunListReq) (res *v1.FeedbackRunListRes, err error)
+	FeedbackQualityLog(ctx context.Context, req *v1.FeedbackQualityLogReq) (res *v1.FeedbackQualityLogRes, err error)
+	FeedbackEvent(ctx context.Context, req *v1.FeedbackEventReq) (res *v1.FeedbackEventRes, err error)
+	QueryFeedbackAdd(ctx context.Context, req *v1.QueryFeedbackAddReq) (res *v1.QueryFeedbackAddRes, err error)
+	QueryFeedbackList(ctx context.Context, req *v1.QueryFeedbackListReq) (res *v1.QueryFeedbackListRes, err error)
+	FeedbackEntryStats(ctx context.Context, req *v1.FeedbackEntryStatsReq) (res *v1.FeedbackEntryStatsRes, err error)
+	FeedbackKnowledgeStats(ctx context.Context, req *v1.FeedbackKnowledgeStatsReq) (res *v1.FeedbackKnowledgeStatsRes, err error)
+	FeedbackTopDisliked(ctx context.Context, req *v1.FeedbackTopDislikedReq) (res *v1.FeedbackTopDislikedRes, err error)
+	FeedbackTrend(ctx context.Context, req *v1.FeedbackTrendReq) (res *v1.FeedbackTrendRes, err error)
+	FeedbackSessionDelete(ctx context.Context, req *v1.FeedbackSessionDeleteReq) (res *v1.FeedbackSessionDeleteRes, err error)
 }
@@ -2,13 +2,15 @@ package v1
 
 import (
 	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
 )
 
 // 添加反馈请求
 type FeedbackAddReq struct {
 	g.Meta      `path:"/feedback" method:"post" tags:"反馈管理" summary:"添加用户反馈"`
-	SessionID   string `json:"session_id" dc:"用户会话ID，可选，不传则使用默认测试ID"`
-	UserQuery   string `v:"required#用户查询不能为空" json:"user_query" dc:"用户查询内容"`
+	SearchID    string `json:"search_id" dc:"检索或答案生成响应中的search_id，可选，传入时该次检索必须展示过这条知识"`
+	SessionID   string `json:"session_id" dc:"通过POST /session获取的会话ID，可选，不传则使用检索时的会话ID，均没有时为匿名反馈"`
+	UserQuery   string `json:"user_query" dc:"用户查询内容，传入search_id时可不传"`
 	KnowledgeID string `v:"required#知识ID不能为空" json:"knowledge_id" dc:"被检索到的知识ID"`
 	Action      string `v:"required|in:like,dislike#操作类型必须是like或dislike" json:"action" dc:"反馈操作类型：like或dislike"`
 }
@@ -44,6 +46,9 @@ type FeedbackItem struct {
 	UserQuery   string `json:"user_query" dc:"用户查询"`
 	KnowledgeID string `json:"knowledge_id" dc:"知识ID"`
 	Action      string `json:"action" dc:"操作类型"`
+	TurnID      uint64 `json:"turn_id" dc:"关联的会话轮次ID，未关联时为0"`
+	SearchID    string `json:"search_id" dc:"产生该反馈的检索ID，未关联时为空"`
+	Anonymous   bool   `json:"anonymous" dc:"是否匿名反馈（没有会话ID）"`
 	Timestamp   string `json:"timestamp" dc:"反馈时间"`
 }
 
@@ -54,6 +59,241 @@ type FeedbackProcessReq struct {
 
 // 触发更新响应
 type FeedbackProcessRes struct {
-	Success bool   `json:"success" dc:"是否成功"`
-	Message string `json:"message" dc:"处理消息"`
+	Success bool         `json:"success" dc:"是否成功"`
+	Message string       `json:"message" dc:"处理消息"`
+	Run     *FeedbackRun `json:"run" dc:"本次处理记录"`
+}
+
+// 查询反馈处理记录请求
+type FeedbackRunListReq struct {
+	g.Meta   `path:"/feedback/runs" method:"get" tags:"反馈管理" summary:"查询反馈处理记录"`
+	Page     int `json:"page" in:"query" d:"1" dc:"页码"`
+	PageSize int `json:"page_size" in:"query" d:"10" v:"max:100#每页最多100条" dc:"每页数量"`
+}
+
+// 查询反馈处理记录响应
+type FeedbackRunListRes struct {
+	List  []FeedbackRun `json:"list" dc:"处理记录列表"`
+	Total int           `json:"total" dc:"总条数"`
+	Page  int           `json:"page" dc:"当前页码"`
+}
+
+// 反馈处理记录
+type FeedbackRun struct {
+	ID             uint64 `json:"id" dc:"记录ID"`
+	Trigger        string `json:"trigger" dc:"触发方式：cron/manual"`
+	Status         string `json:"status" dc:"处理结果：succeeded/failed"`
+	FromFeedbackID uint64 `json:"from_feedback_id" dc:"处理前的水位线（不含）"`
+	ToFeedbackID   uint64 `json:"to_feedback_id" dc:"处理后的水位线（含），失败时与处理前相同"`
+	Feedbacks      uint   `json:"feedbacks" dc:"处理的反馈数量"`
+	Adjusted       uint   `json:"adjusted" dc:"调整质量分数的知识数量"`
+	Error          string `json:"error,omitempty" dc:"失败原因"`
+	StartedAt      string `json:"started_at" dc:"开始时间"`
+	FinishedAt     string `json:"finished_at" dc:"结束时间"`
+}
+
+// 查询质量分数调整日志请求
+type FeedbackQualityLogReq struct {
+	g.Meta      `path:"/feedback/quality/logs" method:"get" tags:"反馈管理" summary:"查询知识质量分数调整日志"`
+	KnowledgeID string `json:"knowledge_id" in:"query" dc:"按知识ID过滤"`
+	Page        int    `json:"page" in:"query" d:"1" dc:"页码"`
+	PageSize    int    `json:"page_size" in:"query" d:"10" v:"max:100#每页最多100条" dc:"每页数量"`
+}
+
+// 查询质量分数调整日志响应
+type FeedbackQualityLogRes struct {
+	List  []QualityLog `json:"list" dc:"调整日志列表"`
+	Total int          `json:"total" dc:"总条数"`
+	Page  int          `json:"page" dc:"当前页码"`
+}
+
+// 质量分数调整日志
+type QualityLog struct {
+	ID          uint64  `json:"id" dc:"日志ID"`
+	KnowledgeID string  `json:"knowledge_id" dc:"知识ID"`
+	Source      string  `json:"source" dc:"调整来源"`
+	Likes       uint    `json:"likes" dc:"本次计入的点赞数"`
+	Dislikes    uint    `json:"dislikes" dc:"本次计入的点踩数"`
+	ScoreBefore float64 `json:"score_before" dc:"调整前的质量分数（已衰减到调整时刻）"`
+	Delta       float64 `json:"delta" dc:"调整量"`
+	ScoreAfter  float64 `json:"score_after" dc:"调整后的质量分数"`
+	CreatedAt   string  `json:"created_at" dc:"调整时间"`
+}
+
+// 记录检索行为请求
+type FeedbackEventReq struct {
+	g.Meta      `path:"/feedback/event" method:"post" tags:"反馈管理" summary:"记录对检索结果的点击或复制"`
+	SearchID    string `v:"required#检索ID不能为空" json:"search_id" dc:"检索或答案生成响应中的search_id"`
+	KnowledgeID string `v:"required#知识ID不能为空" json:"knowledge_id" dc:"被点击或复制的知识ID，必须是该次检索展示过的"`
+	Event       string `v:"required|in:click,copy#行为类型必须是click或copy" json:"event" dc:"行为类型：click或copy"`
+	SessionID   string `json:"session_id" dc:"通过POST /session获取的会话ID，可选，不传则使用检索时的会话ID"`
+}
+
+// 记录检索行为响应
+type FeedbackEventRes struct {
+	ID uint64 `json:"id" dc:"行为ID"`
+}
+
+// 添加查询反馈请求
+type QueryFeedbackAddReq struct {
+	g.Meta    `path:"/feedback/query" method:"post" tags:"反馈管理" summary:"添加针对整次检索的反馈"`
+	SearchID  string `json:"search_id" dc:"检索或答案生成响应中的search_id，可选"`
+	Query     string `json:"query" dc:"用户查询，传入search_id且检索有结果时可不传"`
+	RepoName  string `json:"repo_name" v:"repo-name#知识库名称不合法" dc:"知识库名称，可选，不传则按检索展示的条目补全"`
+	SessionID string `json:"session_id" dc:"通过POST /session获取的会话ID，可选，不传则使用检索时的会话ID，均没有时为匿名反馈"`
+	Rating    string `v:"required|in:no_relevant,partial,helpful#评价必须是no_relevant、partial或helpful" json:"rating" dc:"整体评价：no_relevant没有相关结果，partial部分相关，helpful有帮助"`
+	Comment   string `v:"max-length:1000#补充说明不能超过1000字" json:"comment" dc:"补充说明，可选"`
+}
+
+// 添加查询反馈响应
+type QueryFeedbackAddRes struct {
+	ID uint64 `json:"id" dc:"反馈ID"`
+}
+
+// 查询查询反馈请求
+type QueryFeedbackListReq struct {
+	g.Meta    `path:"/feedback/query/list" method:"get" tags:"反馈管理" summary:"查询针对整次检索的反馈"`
+	RepoName  string      `json:"repo_name" in:"query" dc:"按知识库过滤"`
+	Rating    string      `json:"rating" in:"query" v:"in:,no_relevant,partial,helpful#评价必须是no_relevant、partial或helpful" dc:"按评价过滤"`
+	StartTime *gtime.Time `json:"start_time" in:"query" dc:"开始时间，格式:YYYY-MM-DD HH:MM:SS"`
+	EndTime   *gtime.Time `json:"end_time" in:"query" dc:"结束时间，格式:YYYY-MM-DD HH:MM:SS"`
+	Page      int         `json:"page" in:"query" d:"1" dc:"页码"`
+	PageSize  int         `json:"page_size" in:"query" d:"10" v:"max:100#每页最多100条" dc:"每页数量"`
+}
+
+// 查询查询反馈响应
+type QueryFeedbackListRes struct {
+	List  []QueryFeedback `json:"list" dc:"查询反馈列表"`
+	Total int             `json:"total" dc:"总条数"`
+	Page  int             `json:"page" dc:"当前页码"`
+}
+
+// 针对整次检索的反馈
+type QueryFeedback struct {
+	ID        uint64 `json:"id" dc:"反馈ID"`
+	SearchID  string `json:"search_id" dc:"关联的检索ID，未关联时为空"`
+	SessionID string `json:"session_id" dc:"会话ID"`
+	Query     string `json:"query" dc:"用户查询"`
+	RepoName  string `json:"repo_name" dc:"知识库名称，跨知识库或未知时为空"`
+	Rating    string `json:"rating" dc:"整体评价"`
+	Comment   string `json:"comment" dc:"补充说明"`
+	Anonymous bool   `json:"anonymous" dc:"是否匿名反馈（没有会话ID）"`
+	CreatedAt string `json:"created_at" dc:"反馈时间"`
+}
+
+// 知识条目点击率统计请求
+type FeedbackEntryStatsReq struct {
+	g.Meta         `path:"/feedback/stats/entries" method:"get" tags:"反馈管理" summary:"统计知识条目的展示次数与点击率"`
+	RepoName       string      `json:"repo_name" in:"query" dc:"按知识库过滤"`
+	KnowledgeID    string      `json:"knowledge_id" in:"query" dc:"按知识ID过滤"`
+	StartTime      *gtime.Time `json:"start_time" in:"query" dc:"展示时间起，格式:YYYY-MM-DD HH:MM:SS"`
+	EndTime        *gtime.Time `json:"end_time" in:"query" dc:"展示时间止，格式:YYYY-MM-DD HH:MM:SS"`
+	MinImpressions int         `json:"min_impressions" in:"query" d:"1" v:"min:1#最少展示次数不能小于1" dc:"最少展示次数"`
+	OrderBy        string      `json:"order_by" in:"query" d:"impressions" v:"in:impressions,ctr#排序方式必须是impressions或ctr" dc:"排序方式：impressions按展示次数，ctr按点击率，均为降序"`
+	Page           int         `json:"page" in:"query" d:"1" dc:"页码"`
+	PageSize       int         `json:"page_size" in:"query" d:"10" v:"max:100#每页最多100条" dc:"每页数量"`
+}
+
+// 知识条目点击率统计响应
+type FeedbackEntryStatsRes struct {
+	List  []EntryStats `json:"list" dc:"统计列表"`
+	Total int          `json:"total" dc:"总条数"`
+	Page  int          `json:"page" dc:"当前页码"`
+}
+
+// 知识条目的展示与点击统计
+type EntryStats struct {
+	KnowledgeID string  `json:"knowledge_id" dc:"知识ID"`
+	RepoName    string  `json:"repo_name" dc:"知识库名称"`
+	Impressions uint    `json:"impressions" dc:"展示次数"`
+	Clicks      uint    `json:"clicks" dc:"被点击的展示次数，同一次展示多次点击只计一次"`
+	Copies      uint    `json:"copies" dc:"被复制的展示次数，同一次展示多次复制只计一次"`
+	CTR         float64 `json:"ctr" dc:"点击率"`
+	CopyRate    float64 `json:"copy_rate" dc:"复制率"`
+	AvgPosition float64 `json:"avg_position" dc:"平均展示位置"`
+}
+
+// 知识条目点赞点踩统计请求
+type FeedbackKnowledgeStatsReq struct {
+	g.Meta      `path:"/feedback/stats/knowledge" method:"get" tags:"反馈管理" summary:"统计知识条目的点赞与点踩数量"`
+	RepoName    string      `json:"repo_name" in:"query" dc:"按知识库过滤"`
+	KnowledgeID string      `json:"knowledge_id" in:"query" dc:"按知识ID过滤"`
+	StartTime   *gtime.Time `json:"start_time" in:"query" dc:"反馈时间起，格式:YYYY-MM-DD HH:MM:SS"`
+	EndTime     *gtime.Time `json:"end_time" in:"query" dc:"反馈时间止，格式:YYYY-MM-DD HH:MM:SS"`
+	OrderBy     string      `json:"order_by" in:"query" d:"total" v:"in:total,likes,dislikes#排序方式必须是total、likes或dislikes" dc:"排序方式：total按反馈总数，likes按点赞数，dislikes按点踩数，均为降序"`
+	Page        int         `json:"page" in:"query" d:"1" dc:"页码"`
+	PageSize    int         `json:"page_size" in:"query" d:"10" v:"max:100#每页最多100条" dc:"每页数量"`
+}
+
+// 知识条目点赞点踩统计响应
+type FeedbackKnowledgeStatsRes struct {
+	List  []KnowledgeFeedbackStats `json:"list" dc:"统计列表"`
+	Total int                      `json:"total" dc:"总条数"`
+	Page  int                      `json:"page" dc:"当前页码"`
+}
+
+// 知识条目的点赞与点踩统计
+type KnowledgeFeedbackStats struct {
+	KnowledgeID string `json:"knowledge_id" dc:"知识ID"`
+	RepoName    string `json:"repo_name" dc:"知识库名称"`
+	Summary     string `json:"summary" dc:"内容摘要"`
+	Likes       uint   `json:"likes" dc:"点赞数"`
+	Dislikes    uint   `json:"dislikes" dc:"点踩数"`
+}
+
+// 点踩最多的知识条目请求
+type FeedbackTopDislikedReq struct {
+	g.Meta    `path:"/feedback/stats/disliked" method:"get" tags:"反馈管理" summary:"按知识库查询点踩最多的知识条目"`
+	RepoName  string      `json:"repo_name" in:"query" dc:"按知识库过滤，不填则返回全部知识库"`
+	StartTime *gtime.Time `json:"start_time" in:"query" dc:"反馈时间起，格式:YYYY-MM-DD HH:MM:SS"`
+	EndTime   *gtime.Time `json:"end_time" in:"query" dc:"反馈时间止，格式:YYYY-MM-DD HH:MM:SS"`
+	Limit     int         `json:"limit" in:"query" d:"10" v:"min:1|max:100#每个知识库至少返回1条|每个知识库最多返回100条" dc:"每个知识库返回的条目数量"`
+}
+
+// 点踩最多的知识条目响应
+type FeedbackTopDislikedRes struct {
+	List []RepoDislikedEntries `json:"list" dc:"按知识库名称排列"`
+}
+
+// 知识库中点踩最多的知识条目
+type RepoDislikedEntries struct {
+	RepoName string                   `json:"repo_name" dc:"知识库名称"`
+	Entries  []KnowledgeFeedbackStats `json:"entries" dc:"按点踩数从多到少排列，点踩数相同时点赞少的在前"`
+}
+
+// 反馈每日趋势请求
+type FeedbackTrendReq struct {
+	g.Meta      `path:"/feedback/stats/trend" method:"get" tags:"反馈管理" summary:"按天统计点赞与点踩数量"`
+	RepoName    string      `json:"repo_name" in:"query" dc:"按知识库过滤"`
+	KnowledgeID string      `json:"knowledge_id" in:"query" dc:"按知识ID过滤"`
+	StartTime   *gtime.Time `json:"start_time" in:"query" dc:"反馈时间起，不填则为结束时间之前的30天，时间跨度最多366天"`
+	EndTime     *gtime.Time `json:"end_time" in:"query" dc:"反馈时间止，不填则为当前时间"`
+}
+
+// 反馈每日趋势响应
+type FeedbackTrendRes struct {
+	List []FeedbackTrendPoint `json:"list" dc:"按日期排列，没有反馈的日期计为0"`
+}
+
+// 一天的反馈数量
+type FeedbackTrendPoint struct {
+	Date     string `json:"date" dc:"日期，格式:YYYY-MM-DD"`
+	Likes    uint   `json:"likes" dc:"点赞数"`
+	Dislikes uint   `json:"dislikes" dc:"点踩数"`
+}
+
+// 删除会话反馈请求
+type FeedbackSessionDeleteReq struct {
+	g.Meta    `path:"/feedback/session/:session_id" method:"delete" tags:"反馈管理" summary:"删除会话的全部反馈、检索行为与检索记录"`
+	SessionID string `json:"session_id" in:"path" v:"required#会话ID不能为空" dc:"用户会话ID"`
+}
+
+// 删除会话反馈响应
+type FeedbackSessionDeleteRes struct {
+	Feedbacks      int64 `json:"feedbacks" dc:"删除的点赞点踩反馈数量"`
+	QueryFeedbacks int64 `json:"query_feedbacks" dc:"删除的查询反馈数量"`
+	Events         int64 `json:"events" dc:"删除的点击、复制等检索行为数量"`
+	Turns          int64 `json:"turns" dc:"删除的会话轮次数量"`
+	Impressions    int64 `json:"impressions" dc:"删除的检索展示记录数量"`
+	SearchLogs     int64 `json:"search_logs" dc:"删除的检索记录数量"`
 }
@@ -17,4 +17,10 @@ type IKnowledgeV1 interface {
 	Classify(ctx context.Context, req *v1.ClassifyReq) (res *v1.ClassifyRes, err error)
 	Search(ctx context.Context, req *v1.SearchReq) (res *v1.SearchRes, err error)
 	GetRepos(ctx context.Context, req *v1.GetReposReq) (res *v1.GetReposRes, err error)
+	RepoDescribe(ctx context.Context, req *v1.RepoDescribeReq) (res *v1.RepoDescribeRes, err error)
+	RepoRename(ctx context.Context, req *v1.RepoRenameReq) (res *v1.RepoRenameRes, err error)
+	RepoMetadataSchema(ctx context.Context, req *v1.RepoMetadataSchemaReq) (res *v1.RepoMetadataSchemaRes, err error)
+	SearchCacheStats(ctx context.Context, req *v1.SearchCacheStatsReq) (res *v1.SearchCacheStatsRes, err error)
+	Answer(ctx context.Context, req *v1.AnswerReq) (res *v1.AnswerRes, err error)
+	SessionCreate(ctx context.Context, req *v1.SessionCreateReq) (res *v1.SessionCreateRes, err error)
 }
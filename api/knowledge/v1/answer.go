@@ -0,0 +1,49 @@
+package v1
+
+import "github.com/gogf/gf/v2/frame/g"
+
+// 检索增强答案生成
+//
+type AnswerReq struct {
+	g.Meta        `path:"/answer" method:"post" tags:"Knowledge" summary:"检索知识并生成答案"`
+	Query         string        `json:"query" v:"required#问题不能为空"`
+	RepoName      string        `json:"repo_name" v:"repo-name#知识库名称不合法"`                                                                  // 知识库名称，不填则检索 repo_names 或所有知识库
+	RepoNames     []string      `json:"repo_names" v:"foreach|repo-name#|知识库名称不合法"`                                                        // 参与检索的知识库列表，repo_name 为空时生效
+	Mode          string        `json:"mode" d:"hybrid" v:"in:keyword,semantic,hybrid,lexical#检索模式必须是 keyword/semantic/hybrid/lexical 之一"` // 检索模式
+	TopK          int           `json:"top_k" v:"min:0#检索数量不能为负数"`                                                                         // 检索的候选数量，不填则使用配置 answer.top_k
+	Rerank        *bool         `json:"rerank"`                                                                                            // 是否重排序，不填则使用配置 rerank.enabled
+	Filter        *SearchFilter `json:"filter"`                                                                                            // 过滤条件
+	ContextTokens int           `json:"context_tokens" v:"min:0#上下文token预算不能为负数"`                                                          // 上下文的 token 预算，不填则使用配置 answer.context_tokens
+	Stream        bool          `json:"stream"`                                                                                            // 是否以 SSE 流式返回
+	SessionID     string        `json:"session_id" v:"max-length:64#会话ID长度不能超过64"`                                                         // 多轮会话ID（通过 POST /session 获取），填写时结合会话历史将追问改写为独立问题
+}
+
+// AnswerRes 答案生成结果；stream 为 true 时以 SSE 返回：
+// 生成过程中发送 token 事件（{"token": "..."}），结束时发送 done 事件（内容为本结构），出错时发送 error 事件（{"message": "..."}）
+type AnswerRes struct {
+	Answer    string          `json:"answer"`    // 生成的答案，引用以 [n] 形式内嵌
+	Citations []Citation      `json:"citations"` // 答案中实际出现的引用，按首次出现的顺序
+	Contexts  []AnswerContext `json:"contexts"`  // 放入上下文的知识条目
+	Fusion    string          `json:"fusion"`    // 检索使用的融合策略
+	SearchID  string          `json:"search_id"` // 检索ID，提交点击、复制与查询反馈时传入
+	// 以下仅在指定 session_id 时返回
+	SessionID      string `json:"session_id,omitempty"`      // 会话ID
+	RewrittenQuery string `json:"rewritten_query,omitempty"` // 改写后实际用于检索与生成的问题，未改写时不返回
+}
+
+// Citation 答案中的引用
+type Citation struct {
+	Index    int    `json:"index"` // 答案中的引用编号，即 [n] 中的 n
+	ID       string `json:"id"`    // 知识条目ID
+	RepoName string `json:"repo_name"`
+}
+
+// AnswerContext 参与答案生成的知识条目
+type AnswerContext struct {
+	Index    int     `json:"index"` // 引用编号，从1开始
+	ID       string  `json:"id"`
+	RepoName string  `json:"repo_name"`
+	Summary  string  `json:"summary"`
+	Score    float32 `json:"score"`  // 检索分数
+	Tokens   int     `json:"tokens"` // 在上下文中占用的估算 token 数
+}
@@ -1,11 +1,16 @@
 package v1
 
-import "github.com/gogf/gf/v2/frame/g"
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
 
 // KnowledgeItem 知识条目
 type KnowledgeItem struct {
 	ID      string `json:"id,omitempty" v:""` // ID 字段设为可选，系统会自动生成
 	Content string `json:"content" v:"required#内容不能为空"`
+	// 元数据，例如 {"region": "北京市", "effective_date": "2024-01-01", "policy_no": "京医保发〔2024〕1号"}
+	Metadata map[string]interface{} `json:"metadata"`
 }
 
 // LabelScore 标签分数
@@ -22,13 +27,27 @@ type KnowledgeResult struct {
 	Labels   []LabelScore `json:"labels"`
 	Summary  string       `json:"summary"`
 	Score    float32      `json:"score"` // 检索分数
+	// 元数据，未设置时不返回
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	// 重排序分数，仅在开启重排序时返回
+	RerankScore *float32 `json:"rerank_score,omitempty"`
+	// 衰减后的质量分数，仅在有质量调整记录时返回；排序分数已按其提升
+	Quality *float32 `json:"quality,omitempty"`
+	// 高亮片段，仅在请求 highlight 且有命中词时返回
+	Highlights *Highlights `json:"highlights,omitempty"`
+}
+
+// Highlights 检索结果的高亮片段，命中词以 search.highlight 配置的标签包裹
+type Highlights struct {
+	Content string `json:"content,omitempty"`
+	Summary string `json:"summary,omitempty"`
 }
 
 // 批量导入
 //
 type BatchImportReq struct {
 	g.Meta   `path:"/batch_import" method:"post" tags:"Knowledge" summary:"批量导入知识条目"`
-	RepoName string          `json:"repo_name" v:"required#知识库名称不能为空"`
+	RepoName string          `json:"repo_name" v:"required|repo-name#知识库名称不能为空|知识库名称只允许中文、字母、数字、_、-、.，且长度不超过64"`
 	Items    []KnowledgeItem `json:"items" v:"required|array#导入条目不能为空|导入条目必须为数组"`
 }
 
@@ -41,7 +60,7 @@ type BatchImportRes struct {
 //
 type BatchImportAsyncReq struct {
 	g.Meta   `path:"/batch_import_async" method:"post" tags:"Knowledge" summary:"批量异步导入知识条目"`
-	RepoName string          `json:"repo_name" v:"required#知识库名称不能为空"`
+	RepoName string          `json:"repo_name" v:"required|repo-name#知识库名称不能为空|知识库名称只允许中文、字母、数字、_、-、.，且长度不超过64"`
 	Items    []KnowledgeItem `json:"items" v:"required|array#导入条目不能为空|导入条目必须为数组"`
 }
 
@@ -82,15 +101,83 @@ type ClassifyRes struct {
 // 知识检索
 //
 type SearchReq struct {
-	g.Meta   `path:"/search" method:"post" tags:"Knowledge" summary:"知识检索"`
-	Query    string `json:"query" v:"required#检索关键词不能为空"`
-	RepoName string `json:"repo_name"` // 知识库名称，不填则搜索所有知识库
-	Mode     string `json:"mode" v:"required|in:keyword,semantic,hybrid#检索模式必须是 keyword/semantic/hybrid 之一"`
-	TopK     int    `json:"top_k" v:"min:1#返回结果数量必须大于0"`
+	g.Meta    `path:"/search" method:"post" tags:"Knowledge" summary:"知识检索"`
+	Query     string   `json:"query" v:"required#检索关键词不能为空"`
+	RepoName  string   `json:"repo_name" v:"repo-name#知识库名称不合法"`           // 知识库名称，不填则搜索 repo_names 或所有知识库
+	RepoNames []string `json:"repo_names" v:"foreach|repo-name#|知识库名称不合法"` // 参与检索的知识库列表，repo_name 为空时生效
+	Mode      string   `json:"mode" v:"required|in:keyword,semantic,hybrid,lexical#检索模式必须是 keyword/semantic/hybrid/lexical 之一"`
+	TopK      int      `json:"top_k" v:"min:1#返回结果数量必须大于0"`
+	// 以下为混合检索的可选参数，不填则使用配置 search.fusion / search.hybrid
+	Fusion string        `json:"fusion" v:"in:dense,sparse_dense,rrf,dbsf,weighted#融合策略必须是 dense/sparse_dense/rrf/dbsf/weighted 之一"` // 融合策略
+	Alpha  *float32      `json:"alpha" v:"min:0#alpha不能为负数"`                                                                         // weighted 策略的密集向量权重
+	Beta   *float32      `json:"beta" v:"min:0#beta不能为负数"`                                                                           // weighted 策略的标签稀疏向量权重
+	Rerank *bool         `json:"rerank"`                                                                                             // 是否重排序，不填则使用配置 rerank.enabled
+	Filter *SearchFilter `json:"filter"`                                                                                             // 过滤条件，各条件之间为“且”的关系
+	// 检索策略：standard 直接使用查询；multi_query 由大模型生成多个改写查询分别检索后融合；hyde 以大模型生成的假设性答案的向量检索
+	Strategy string `json:"strategy" d:"standard" v:"in:standard,multi_query,hyde#检索策略必须是 standard/multi_query/hyde 之一"`
+	// 以下为翻页与结果处理参数
+	Offset    uint64   `json:"offset"`    // 跳过的结果数量
+	Cursor    string   `json:"cursor"`    // 上一页返回的 next_cursor，填写时忽略 offset
+	MinScore  *float32 `json:"min_score"` // 最低分数，开启重排序时作用于重排序分数
+	Highlight bool     `json:"highlight"` // 是否返回高亮片段
+	Explain   bool     `json:"explain"`   // 是否返回检索过程说明（查询标签、稀疏向量、各路分数与各阶段耗时），不使用检索缓存
+	// 多轮会话ID，填写时结合该会话最近几轮的查询将追问改写为独立查询后再检索
+	SessionID string `json:"session_id" v:"max-length:64#会话ID长度不能超过64"`
+}
+
+// SearchFilter 检索过滤条件
+type SearchFilter struct {
+	Labels      []LabelFilter    `json:"labels"`       // 标签条件，每个标签都必须存在且不低于最低分数
+	CreatedFrom *gtime.Time      `json:"created_from"` // 创建时间起（含）
+	CreatedTo   *gtime.Time      `json:"created_to"`   // 创建时间止（含）
+	UpdatedFrom *gtime.Time      `json:"updated_from"` // 更新时间起（含）
+	UpdatedTo   *gtime.Time      `json:"updated_to"`   // 更新时间止（含）
+	DocumentIDs []string         `json:"document_ids"` // 限定的知识条目ID
+	Metadata    []MetadataFilter `json:"metadata"`     // 元数据条件
+}
+
+// MetadataFilter 元数据过滤条件，match 与范围条件同时存在时都需满足
+type MetadataFilter struct {
+	Key   string        `json:"key" v:"required#元数据字段名不能为空"`
+	Match []interface{} `json:"match"` // 等于其中任意一个值
+	Gte   interface{}   `json:"gte"`   // 大于等于，适用于数字与日期时间
+	Lte   interface{}   `json:"lte"`   // 小于等于，适用于数字与日期时间
+}
+
+// LabelFilter 标签过滤条件
+type LabelFilter struct {
+	Name     string  `json:"name" v:"required#标签不能为空"`
+	MinScore float32 `json:"min_score" v:"min:0#最低分数不能为负数"`
 }
 
 type SearchRes struct {
-	Items []KnowledgeResult `json:"items"`
+	Items      []KnowledgeResult `json:"items"`
+	Fusion     string            `json:"fusion"`                // 实际使用的融合策略，跨知识库检索时为各知识库使用过的策略，以逗号分隔
+	Errors     []RepoError       `json:"errors,omitempty"`      // 跨知识库检索时失败的知识库，其余知识库的结果照常返回
+	Total      uint64            `json:"total"`                 // 满足过滤条件的候选总数的估计值（未应用最低分数）
+	Offset     uint64            `json:"offset"`                // 本页的起始位置
+	HasMore    bool              `json:"has_more"`              // 是否还有下一页
+	NextCursor string            `json:"next_cursor,omitempty"` // 下一页的翻页游标，没有下一页时不返回
+	Debug      *SearchDebug      `json:"debug,omitempty"`       // 检索策略的中间结果，strategy 为 standard 时不返回
+	Explain    *SearchExplain    `json:"explain,omitempty"`     // 检索过程说明，仅在请求 explain 时返回
+	SearchID   string            `json:"search_id"`             // 检索ID，提交点击、复制与查询反馈时传入
+	// 以下仅在指定 session_id 时返回
+	SessionID      string `json:"session_id,omitempty"`      // 会话ID
+	RewrittenQuery string `json:"rewritten_query,omitempty"` // 改写后实际用于检索的查询，未改写时不返回
+}
+
+// SearchDebug 检索策略的中间结果
+type SearchDebug struct {
+	Strategy           string   `json:"strategy"`                      // 实际使用的检索策略，生成失败回退时为 standard
+	Queries            []string `json:"queries,omitempty"`             // multi_query 策略参与检索的查询，第一条为原始查询
+	HypotheticalAnswer string   `json:"hypothetical_answer,omitempty"` // hyde 策略生成的假设性答案
+	Error              string   `json:"error,omitempty"`               // 生成失败的原因
+}
+
+// RepoError 单个知识库的检索错误
+type RepoError struct {
+	RepoName string `json:"repo_name"`
+	Message  string `json:"message"`
 }
 
 // 获取所有知识库
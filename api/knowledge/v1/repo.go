@@ -0,0 +1,52 @@
+package v1
+
+import "github.com/gogf/gf/v2/frame/g"
+
+// 知识库详情
+//
+type RepoDescribeReq struct {
+	g.Meta   `path:"/repo/:repo_name" method:"get" tags:"Knowledge" summary:"获取知识库详情"`
+	RepoName string `json:"repo_name" in:"path" v:"required|repo-name#知识库名称不能为空|知识库名称不合法"`
+}
+
+type RepoDescribeRes struct {
+	RepoName       string          `json:"repo_name"`       // 知识库名称
+	RepoID         string          `json:"repo_id"`         // 知识库内部ID
+	Alias          string          `json:"alias"`           // Qdrant集合别名
+	Collection     string          `json:"collection"`      // 别名当前指向的Qdrant物理集合
+	PointsCount    uint64          `json:"points_count"`    // Qdrant中的向量数量
+	KnowledgeCount int             `json:"knowledge_count"` // 知识条目数量
+	MetadataSchema []MetadataField `json:"metadata_schema"` // 元数据字段声明
+	CreatedAt      string          `json:"created_at"`      // 创建时间
+	UpdatedAt      string          `json:"updated_at"`      // 更新时间
+}
+
+// 知识库重命名
+//
+type RepoRenameReq struct {
+	g.Meta   `path:"/repo/rename" method:"post" tags:"Knowledge" summary:"重命名知识库"`
+	RepoName string `json:"repo_name" v:"required|repo-name#知识库名称不能为空|知识库名称不合法"`
+	NewName  string `json:"new_name" v:"required|repo-name#新名称不能为空|新名称只允许中文、字母、数字、_、-、.，且长度不超过64"`
+}
+
+type RepoRenameRes struct {
+	Success bool `json:"success"`
+}
+
+// 声明元数据字段
+//
+type RepoMetadataSchemaReq struct {
+	g.Meta   `path:"/repo/metadata_schema" method:"post" tags:"Knowledge" summary:"声明知识库元数据字段"`
+	RepoName string          `json:"repo_name" v:"required|repo-name#知识库名称不能为空|知识库名称不合法"`
+	Fields   []MetadataField `json:"fields" v:"required#元数据字段不能为空"`
+}
+
+type RepoMetadataSchemaRes struct {
+	MetadataSchema []MetadataField `json:"metadata_schema"` // 声明后的全部元数据字段
+}
+
+// MetadataField 元数据字段声明，声明过的字段会建立索引并在导入时按类型转换
+type MetadataField struct {
+	Key  string `json:"key" v:"required#元数据字段名不能为空"`
+	Type string `json:"type" v:"required|in:keyword,integer,float,bool,datetime#元数据字段类型不能为空|元数据字段类型必须是 keyword/integer/float/bool/datetime 之一"`
+}
@@ -0,0 +1,18 @@
+package v1
+
+import "github.com/gogf/gf/v2/frame/g"
+
+// 检索缓存统计
+//
+type SearchCacheStatsReq struct {
+	g.Meta `path:"/search/cache/stats" method:"get" tags:"Knowledge" summary:"获取检索结果缓存统计"`
+}
+
+type SearchCacheStatsRes struct {
+	Enabled bool    `json:"enabled"`  // 是否启用缓存
+	Backend string  `json:"backend"`  // 缓存后端：memory/redis
+	Hits    uint64  `json:"hits"`     // 命中次数
+	Misses  uint64  `json:"misses"`   // 未命中次数
+	HitRate float64 `json:"hit_rate"` // 命中率
+	Size    int     `json:"size"`     // 当前缓存的结果数量（仅 memory 后端）
+}
@@ -0,0 +1,14 @@
+package v1
+
+import "github.com/gogf/gf/v2/frame/g"
+
+// 签发会话ID
+//
+type SessionCreateReq struct {
+	g.Meta `path:"/session" method:"post" tags:"Knowledge" summary:"签发用于多轮检索与反馈的会话ID"`
+}
+
+type SessionCreateRes struct {
+	SessionID string `json:"session_id"` // 会话ID，检索、答案生成与反馈请求中的 session_id
+	ExpiresAt string `json:"expires_at"` // 过期时间，为空表示不过期
+}
@@ -3,6 +3,8 @@ package cmd
 import (
 	"context"
 
+	"knowledge-system-api/internal/controller/analytics"
+	"knowledge-system-api/internal/controller/eval"
 	"knowledge-system-api/internal/controller/feedback"
 	"knowledge-system-api/internal/controller/knowledge"
 	"knowledge-system-api/internal/service"
@@ -32,12 +34,19 @@ var (
 					group.Bind(
 						knowledge.NewV1(),
 						feedback.NewV1(),
+						eval.NewV1(),
+						analytics.NewV1(),
 					)
 				})
 
 				// 这里可以添加其他模块的API路由
 			})
 
+			// 检索结果缓存配置为 redis 但无法连接时拒绝启动，避免各实例的缓存失效互不可见
+			if err := service.InitSearchCache(ctx); err != nil {
+				return err
+			}
+
 			// 设置Swagger UI
 			s.SetSwaggerUITemplate(ScalarUITemplate)
 
@@ -46,8 +55,17 @@ var (
 			// 异步执行任务恢复，避免阻塞主线程
 			go service.RecoverUnfinishedTasks(ctx)
 
-			// 启动服务
+			// 注册反馈定时处理任务
+			if err := service.Feedback().StartSchedule(ctx); err != nil {
+				g.Log().Errorf(ctx, "%v", err)
+			}
+			if err := service.StartSearchLogCleanup(ctx); err != nil {
+				g.Log().Errorf(ctx, "%v", err)
+			}
+
+			// 启动服务，服务关闭后取消后台进行中的质心训练
 			s.Run()
+			service.StopLabelCentroids()
 			return nil
 		},
 	}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gcmd"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/service"
+)
+
+var (
+	// Eval 离线检索评测命令，配置文件为 EvalConfig 的 JSON，不指定时使用当前配置
+	Eval = gcmd.Command{
+		Name:  "eval",
+		Usage: "eval -set ID [-k 10] [-config baseline.json] [-compare candidate.json] [-json]",
+		Brief: "在评测集上评测检索配置，输出 recall@k、MRR、nDCG@k 与检索耗时",
+		Arguments: []gcmd.Argument{
+			{Name: "set", Short: "s", Brief: "评测集ID"},
+			{Name: "k", Short: "k", Default: "10", Brief: "评测的结果数量"},
+			{Name: "config", Short: "c", Brief: "检索配置文件（JSON），不指定时使用当前配置"},
+			{Name: "compare", Short: "p", Brief: "对比的候选检索配置文件（JSON），指定时与 -config 的配置对比"},
+			{Name: "json", Short: "j", Orphan: true, Brief: "以 JSON 输出完整结果"},
+		},
+		Func: func(ctx context.Context, parser *gcmd.Parser) (err error) {
+			setID := parser.GetOpt("set").Uint64()
+			if setID == 0 {
+				return gerror.New("请通过 -set 指定评测集ID")
+			}
+			k := parser.GetOpt("k", 10).Int()
+			asJSON := parser.GetOpt("json") != nil
+
+			baseline, err := loadEvalConfig(parser.GetOpt("config").String())
+			if err != nil {
+				return err
+			}
+
+			if candidatePath := parser.GetOpt("compare").String(); candidatePath != "" {
+				candidate, err := loadEvalConfig(candidatePath)
+				if err != nil {
+					return err
+				}
+				comparison, err := service.Eval().Compare(ctx, setID, baseline, candidate, k)
+				if err != nil {
+					return err
+				}
+				if asJSON {
+					return printJSON(comparison)
+				}
+				printEvalReport(comparison.Baseline)
+				printEvalReport(comparison.Candidate)
+				printEvalDelta(comparison)
+				return nil
+			}
+
+			report, err := service.Eval().Run(ctx, setID, baseline, k)
+			if err != nil {
+				return err
+			}
+			if asJSON {
+				return printJSON(report)
+			}
+			printEvalReport(report)
+			return nil
+		},
+	}
+
+	// EvalFeedback 由用户反馈生成评测集或 JSONL 训练样本，都不指定时只输出统计
+	EvalFeedback = gcmd.Command{
+		Name:  "eval-feedback",
+		Usage: "eval-feedback [-repo NAME] [-from TIME] [-to TIME] [-sets [-name NAME]] [-out pairs.jsonl]",
+		Brief: "将用户反馈汇总为分级的相关性标注，保存为评测集或导出训练样本",
+		Arguments: []gcmd.Argument{
+			{Name: "repo", Short: "r", Brief: "知识库名称，不指定时处理全部知识库"},
+			{Name: "from", Short: "f", Brief: "反馈时间起（含），格式:YYYY-MM-DD HH:MM:SS"},
+			{Name: "to", Short: "t", Brief: "反馈时间止（含），格式:YYYY-MM-DD HH:MM:SS"},
+			{Name: "sets", Short: "s", Orphan: true, Brief: "按知识库保存为评测集"},
+			{Name: "name", Short: "n", Brief: "评测集名称，不指定时为 feedback-<时间>"},
+			{Name: "out", Short: "o", Brief: "JSONL 训练样本的输出文件"},
+		},
+		Func: func(ctx context.Context, parser *gcmd.Parser) (err error) {
+			opts := model.FeedbackDatasetOptions{RepoName: parser.GetOpt("repo").String()}
+			if from := parser.GetOpt("from").String(); from != "" {
+				if opts.StartTime, err = gtime.StrToTime(from); err != nil {
+					return gerror.Wrapf(err, "反馈时间起不合法: %s", from)
+				}
+			}
+			if to := parser.GetOpt("to").String(); to != "" {
+				if opts.EndTime, err = gtime.StrToTime(to); err != nil {
+					return gerror.Wrapf(err, "反馈时间止不合法: %s", to)
+				}
+			}
+
+			dataset, err := service.Eval().BuildFeedbackDataset(ctx, opts)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%d 条反馈，生成 %d 条查询，丢弃 %d 条没有正向标注的查询\n",
+				dataset.Feedbacks, len(dataset.Queries), dataset.Skipped)
+
+			if parser.GetOpt("sets") != nil {
+				sets, err := service.Eval().CreateSetsFromFeedback(ctx, dataset, parser.GetOpt("name").String())
+				if err != nil {
+					return err
+				}
+				for _, set := range sets {
+					fmt.Printf("已创建评测集 %d (%s/%s)，%d 条查询\n", set.ID, set.RepoName, set.Name, set.QueryCount)
+				}
+			}
+
+			if out := parser.GetOpt("out").String(); out != "" {
+				file, err := gfile.Create(out)
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				written, err := service.Eval().ExportTrainingPairs(ctx, dataset, file)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("已导出 %d 条训练样本到 %s\n", written, out)
+			}
+			return nil
+		},
+	}
+)
+
+func init() {
+	if err := Main.AddCommand(&Eval, &EvalFeedback); err != nil {
+		panic(err)
+	}
+}
+
+// loadEvalConfig 读取检索配置文件，path 为空时返回空配置
+func loadEvalConfig(path string) (model.EvalConfig, error) {
+	var cfg model.EvalConfig
+	if path == "" {
+		return cfg, nil
+	}
+	if !gfile.Exists(path) {
+		return cfg, gerror.Newf("检索配置文件不存在: %s", path)
+	}
+	if err := json.Unmarshal(gfile.GetBytes(path), &cfg); err != nil {
+		return cfg, gerror.Wrapf(err, "解析检索配置文件失败: %s", path)
+	}
+	if cfg.Name == "" {
+		cfg.Name = gfile.Name(path)
+	}
+	return cfg, nil
+}
+
+// printJSON 以缩进的 JSON 输出
+func printJSON(v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// printEvalReport 输出评测结果摘要
+func printEvalReport(report *model.EvalReport) {
+	fmt.Printf("评测集 %d (%s/%s)，配置 %q，模式 %s，%d 条查询，失败 %d 条\n",
+		report.SetID, report.RepoName, report.SetName, report.Config.Name, report.Config.Mode, report.Queries, report.Failed)
+	fmt.Printf("  recall@%d  %.4f\n", report.K, report.Recall)
+	fmt.Printf("  MRR        %.4f\n", report.MRR)
+	fmt.Printf("  nDCG@%d    %.4f\n", report.K, report.NDCG)
+	fmt.Printf("  耗时(ms)   mean %.1f / p50 %.1f / p95 %.1f / max %.1f\n",
+		report.Latency.Mean, report.Latency.P50, report.Latency.P95, report.Latency.Max)
+	for _, d := range report.Details {
+		if d.Error != "" {
+			fmt.Printf("  [失败] #%d %s: %s\n", d.QueryID, d.Query, d.Error)
+		}
+	}
+}
+
+// printEvalDelta 输出两个配置的指标差值与 nDCG 有变化的查询
+func printEvalDelta(comparison *model.EvalComparison) {
+	delta := comparison.Delta
+	fmt.Printf("对比 %q -> %q\n", comparison.Baseline.Config.Name, comparison.Candidate.Config.Name)
+	fmt.Printf("  recall %+.4f, MRR %+.4f, nDCG %+.4f, p50 %+.1fms, p95 %+.1fms\n",
+		delta.Recall, delta.MRR, delta.NDCG, delta.LatencyP50, delta.LatencyP95)
+	for _, c := range comparison.Changed {
+		fmt.Printf("  %+.4f  #%d %s (%.4f -> %.4f)\n", c.Delta, c.QueryID, c.Query, c.Baseline, c.Candidate)
+	}
+}
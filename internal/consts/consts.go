@@ -1 +1,141 @@
 package consts
+
+// Qdrant 集合中的命名向量
+const (
+	// VectorContentDense 正文密集向量
+	VectorContentDense = "content_dense"
+	// VectorSummaryDense 摘要密集向量（可选，由 qdrant.summary_vector 开启）
+	VectorSummaryDense = "summary_dense"
+	// VectorLabelsSparse 标签稀疏向量
+	VectorLabelsSparse = "labels_sparse"
+	// VectorTextSparse 正文分词后的 BM25 稀疏向量
+	VectorTextSparse = "text_sparse"
+)
+
+// 混合检索的融合策略
+const (
+	// FusionDense 仅使用正文密集向量
+	FusionDense = "dense"
+	// FusionSparseDense 先用标签稀疏向量预查询候选，再用正文密集向量重新打分
+	FusionSparseDense = "sparse_dense"
+	// FusionRRF 多路预查询后倒数排名融合（Reciprocal Rank Fusion）
+	FusionRRF = "rrf"
+	// FusionDBSF 多路预查询后基于分布的分数融合（Distribution-Based Score Fusion）
+	FusionDBSF = "dbsf"
+	// FusionWeighted 密集向量与标签稀疏向量分数按 alpha/beta 加权求和
+	FusionWeighted = "weighted"
+	// FusionLexical 仅使用正文 BM25 稀疏向量（词法检索）
+	FusionLexical = "lexical"
+	// FusionKeyword MySQL 全文索引检索，不经过向量库
+	FusionKeyword = "keyword"
+)
+
+// 跨知识库检索合并结果前的分数归一化方式
+const (
+	// NormalizeMinMax 按知识库内的最小值与最大值缩放到 [0,1]
+	NormalizeMinMax = "minmax"
+	// NormalizeMax 按知识库内的最大值缩放，保留分数之间的比例
+	NormalizeMax = "max"
+	// NormalizeNone 不归一化，直接按原始分数合并
+	NormalizeNone = "none"
+)
+
+// 元数据字段类型，决定导入时的类型转换与 Qdrant payload 索引类型
+const (
+	// MetadataTypeKeyword 关键字，精确匹配
+	MetadataTypeKeyword = "keyword"
+	// MetadataTypeInteger 整数，支持范围条件
+	MetadataTypeInteger = "integer"
+	// MetadataTypeFloat 浮点数，支持范围条件
+	MetadataTypeFloat = "float"
+	// MetadataTypeBool 布尔值
+	MetadataTypeBool = "bool"
+	// MetadataTypeDatetime 日期时间，统一存储为 RFC3339 格式的 UTC 时间，支持范围条件
+	MetadataTypeDatetime = "datetime"
+)
+
+// 查询标签分析方式
+const (
+	// QueryLabelerAuto 最近质心分类优先，置信度不足时在延迟预算内回退大模型
+	QueryLabelerAuto = "auto"
+	// QueryLabelerCentroid 仅使用最近质心分类，不调用大模型
+	QueryLabelerCentroid = "centroid"
+	// QueryLabelerLLM 每次都调用大模型分析
+	QueryLabelerLLM = "llm"
+)
+
+// 查询标签的来源
+const (
+	// LabelSourceCentroid 最近质心分类
+	LabelSourceCentroid = "centroid"
+	// LabelSourceLLM 大模型分析
+	LabelSourceLLM = "llm"
+	// LabelSourceNone 没有得到可用标签
+	LabelSourceNone = "none"
+)
+
+// 检索模式
+const (
+	// SearchModeHybrid 混合检索，融合策略由 fusion 参数或配置决定
+	SearchModeHybrid = "hybrid"
+	// SearchModeSemantic 语义检索，仅使用正文密集向量
+	SearchModeSemantic = "semantic"
+	// SearchModeKeyword 关键词检索，使用 MySQL 全文索引
+	SearchModeKeyword = "keyword"
+	// SearchModeLexical 词法检索，仅使用正文 BM25 稀疏向量
+	SearchModeLexical = "lexical"
+)
+
+// 检索策略，决定用什么文本计算查询向量
+const (
+	// SearchStrategyStandard 直接使用查询文本
+	SearchStrategyStandard = "standard"
+	// SearchStrategyMultiQuery 由大模型生成多个改写查询，分别检索后按倒数排名融合
+	SearchStrategyMultiQuery = "multi_query"
+	// SearchStrategyHyDE 由大模型生成假设性答案，以答案的向量代替查询向量检索（Hypothetical Document Embeddings）
+	SearchStrategyHyDE = "hyde"
+)
+
+// 知识质量分数的调整来源
+const (
+	// QualitySourceFeedback 用户点赞/点踩反馈
+	QualitySourceFeedback = "feedback"
+)
+
+// 反馈处理的触发方式与结果
+const (
+	// FeedbackRunTriggerCron 定时任务触发
+	FeedbackRunTriggerCron = "cron"
+	// FeedbackRunTriggerManual 通过接口手动触发
+	FeedbackRunTriggerManual = "manual"
+	// FeedbackRunSucceeded 处理成功，水位线已推进
+	FeedbackRunSucceeded = "succeeded"
+	// FeedbackRunFailed 处理失败，本批次的调整全部回滚，水位线不变
+	FeedbackRunFailed = "failed"
+)
+
+// 检索结果的展示来源
+const (
+	// ImpressionSourceSearch 知识检索
+	ImpressionSourceSearch = "search"
+	// ImpressionSourceAnswer 答案生成放入上下文的条目
+	ImpressionSourceAnswer = "answer"
+)
+
+// 检索结果上的隐式反馈行为
+const (
+	// SearchEventClick 点击查看
+	SearchEventClick = "click"
+	// SearchEventCopy 复制内容
+	SearchEventCopy = "copy"
+)
+
+// 查询反馈的整体评价
+const (
+	// QueryRatingNoRelevant 没有相关结果
+	QueryRatingNoRelevant = "no_relevant"
+	// QueryRatingPartial 部分相关
+	QueryRatingPartial = "partial"
+	// QueryRatingHelpful 结果有帮助
+	QueryRatingHelpful = "helpful"
+)
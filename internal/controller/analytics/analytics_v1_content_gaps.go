@@ -0,0 +1,41 @@
+package analytics
+
+import (
+	"context"
+
+	"knowledge-system-api/api/analytics/v1"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/service"
+)
+
+func (c *ControllerV1) ContentGaps(ctx context.Context, req *v1.ContentGapsReq) (res *v1.ContentGapsRes, err error) {
+	report, err := service.Analytics().ContentGaps(ctx, model.ContentGapOptions{
+		RepoName:  req.RepoName,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		Limit:     req.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.ContentGapsRes{
+		Signals:  report.Signals,
+		Queries:  report.Queries,
+		Skipped:  report.Skipped,
+		Clusters: make([]v1.GapCluster, len(report.Clusters)),
+	}
+	for i, cluster := range report.Clusters {
+		res.Clusters[i] = v1.GapCluster{
+			Label:    cluster.Label,
+			RepoName: cluster.RepoName,
+			Count:    cluster.Count,
+			Signals:  v1.GapSignals(cluster.Signals),
+			Queries:  make([]v1.GapQuery, len(cluster.Queries)),
+		}
+		for j, q := range cluster.Queries {
+			res.Clusters[i].Queries[j] = v1.GapQuery{Query: q.Query, Count: q.Count, Signals: v1.GapSignals(q.Signals)}
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,22 @@
+package analytics
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"knowledge-system-api/api/analytics/v1"
+	"knowledge-system-api/internal/service"
+)
+
+func (c *ControllerV1) SearchLogGet(ctx context.Context, req *v1.SearchLogGetReq) (res *v1.SearchLogGetRes, err error) {
+	log, err := service.GetSearchLog(ctx, req.SearchID)
+	if err != nil {
+		return nil, err
+	}
+	if log == nil {
+		return nil, gerror.NewCodef(gcode.CodeNotFound, "检索记录不存在（未被抽样或已清理）: %s", req.SearchID)
+	}
+	return &v1.SearchLogGetRes{SearchLog: toSearchLog(*log)}, nil
+}
@@ -0,0 +1,73 @@
+package analytics
+
+import (
+	"context"
+
+	"knowledge-system-api/api/analytics/v1"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/service"
+)
+
+func (c *ControllerV1) SearchLogList(ctx context.Context, req *v1.SearchLogListReq) (res *v1.SearchLogListRes, err error) {
+	logs, total, err := service.ListSearchLogs(ctx, model.SearchLogListOptions{
+		RepoName:    req.RepoName,
+		SessionID:   req.SessionID,
+		Source:      req.Source,
+		Query:       req.Query,
+		KnowledgeID: req.KnowledgeID,
+		Failed:      req.Failed,
+		ZeroResult:  req.ZeroResult,
+		StartTime:   req.StartTime,
+		EndTime:     req.EndTime,
+	}, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.SearchLogListRes{
+		List:  make([]v1.SearchLog, len(logs)),
+		Total: total,
+		Page:  req.Page,
+	}
+	for i, log := range logs {
+		res.List[i] = toSearchLog(log)
+	}
+	return res, nil
+}
+
+// toSearchLog 将检索记录转换为API响应格式
+func toSearchLog(log model.SearchLog) v1.SearchLog {
+	out := v1.SearchLog{
+		ID:          log.ID,
+		SearchID:    log.SearchID,
+		Source:      log.Source,
+		SessionID:   log.SessionID,
+		Query:       log.Query,
+		SearchQuery: log.SearchQuery,
+		RepoNames:   log.RepoNames,
+		Mode:        log.Mode,
+		Fusion:      log.Fusion,
+		Params:      v1.SearchLogParams(log.Params),
+		Results:     make([]v1.SearchLogResult, len(log.Results)),
+		Total:       log.Total,
+		TopScore:    log.TopScore,
+		Cached:      log.Cached,
+		LatencyMs:   log.LatencyMs,
+		Timings:     make([]v1.StageTiming, len(log.Timings)),
+		Error:       log.Error,
+		CreatedAt:   log.CreatedAt.String(),
+	}
+	if out.RepoNames == nil {
+		out.RepoNames = []string{}
+	}
+	if log.Filter != nil {
+		out.Filter = log.Filter
+	}
+	for i, r := range log.Results {
+		out.Results[i] = v1.SearchLogResult(r)
+	}
+	for i, t := range log.Timings {
+		out.Timings[i] = v1.StageTiming(t)
+	}
+	return out
+}
@@ -0,0 +1,15 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package eval
+
+import (
+	"knowledge-system-api/api/eval"
+)
+
+type ControllerV1 struct{}
+
+func NewV1() eval.IEvalV1 {
+	return &ControllerV1{}
+}
@@ -0,0 +1,27 @@
+package eval
+
+import (
+	"context"
+
+	"knowledge-system-api/api/eval/v1"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/service"
+)
+
+func (c *ControllerV1) EvalCompare(ctx context.Context, req *v1.EvalCompareReq) (res *v1.EvalCompareRes, err error) {
+	comparison, err := service.Eval().Compare(ctx, req.SetID, model.EvalConfig(req.Baseline), model.EvalConfig(req.Candidate), req.K)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.EvalCompareRes{
+		Baseline:  toEvalReport(comparison.Baseline),
+		Candidate: toEvalReport(comparison.Candidate),
+		Delta:     v1.EvalDelta(comparison.Delta),
+		Changed:   make([]v1.EvalQueryDelta, len(comparison.Changed)),
+	}
+	for i := range comparison.Changed {
+		res.Changed[i] = v1.EvalQueryDelta(comparison.Changed[i])
+	}
+	return res, nil
+}
@@ -0,0 +1,39 @@
+package eval
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"knowledge-system-api/api/eval/v1"
+	"knowledge-system-api/internal/service"
+)
+
+// EvalFeedbackPairs 以 JSONL 文件返回训练样本，已写出内容后统一响应中间件不再包装结果
+func (c *ControllerV1) EvalFeedbackPairs(ctx context.Context, req *v1.EvalFeedbackPairsReq) (res *v1.EvalFeedbackPairsRes, err error) {
+	dataset, err := service.Eval().BuildFeedbackDataset(ctx, toFeedbackDatasetOptions(req.FeedbackRange))
+	if err != nil {
+		return nil, err
+	}
+
+	// 先写入缓冲区，导出失败时仍能以统一格式返回错误
+	var buf bytes.Buffer
+	written, err := service.Eval().ExportTrainingPairs(ctx, dataset, &buf)
+	if err != nil {
+		return nil, err
+	}
+	if written == 0 {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "没有可导出的训练样本")
+	}
+
+	r := g.RequestFromCtx(ctx)
+	r.Response.Header().Set("Content-Type", "application/x-ndjson; charset=utf-8")
+	r.Response.Header().Set("Content-Disposition", `attachment; filename="feedback-pairs-`+gtime.Now().Format("YmdHis")+`.jsonl"`)
+	r.Response.Write(buf.Bytes())
+	g.Log().Infof(ctx, "已导出 %d 条训练样本", written)
+	return nil, nil
+}
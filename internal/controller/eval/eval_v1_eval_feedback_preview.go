@@ -0,0 +1,40 @@
+package eval
+
+import (
+	"context"
+
+	"knowledge-system-api/api/eval/v1"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/service"
+)
+
+func (c *ControllerV1) EvalFeedbackPreview(ctx context.Context, req *v1.EvalFeedbackPreviewReq) (res *v1.EvalFeedbackPreviewRes, err error) {
+	dataset, err := service.Eval().BuildFeedbackDataset(ctx, toFeedbackDatasetOptions(req.FeedbackRange))
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.EvalFeedbackPreviewRes{
+		Feedbacks: dataset.Feedbacks,
+		Skipped:   dataset.Skipped,
+		Queries:   make([]v1.FeedbackQuery, len(dataset.Queries)),
+	}
+	for i, q := range dataset.Queries {
+		res.Queries[i] = v1.FeedbackQuery{
+			RepoName:  q.RepoName,
+			Query:     q.Query,
+			Variants:  q.Variants,
+			Feedbacks: q.Feedbacks,
+			Judgments: make([]v1.EvalJudgment, len(q.Judgments)),
+		}
+		for j, judgment := range q.Judgments {
+			res.Queries[i].Judgments[j] = v1.EvalJudgment(judgment)
+		}
+	}
+	return res, nil
+}
+
+// toFeedbackDatasetOptions 转换反馈范围
+func toFeedbackDatasetOptions(r v1.FeedbackRange) model.FeedbackDatasetOptions {
+	return model.FeedbackDatasetOptions{RepoName: r.RepoName, StartTime: r.StartTime, EndTime: r.EndTime}
+}
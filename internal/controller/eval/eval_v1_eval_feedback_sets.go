@@ -0,0 +1,29 @@
+package eval
+
+import (
+	"context"
+
+	"knowledge-system-api/api/eval/v1"
+	"knowledge-system-api/internal/service"
+)
+
+func (c *ControllerV1) EvalFeedbackSets(ctx context.Context, req *v1.EvalFeedbackSetsReq) (res *v1.EvalFeedbackSetsRes, err error) {
+	dataset, err := service.Eval().BuildFeedbackDataset(ctx, toFeedbackDatasetOptions(req.FeedbackRange))
+	if err != nil {
+		return nil, err
+	}
+	sets, err := service.Eval().CreateSetsFromFeedback(ctx, dataset, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.EvalFeedbackSetsRes{
+		Feedbacks: dataset.Feedbacks,
+		Skipped:   dataset.Skipped,
+		Sets:      make([]v1.EvalSet, len(sets)),
+	}
+	for i := range sets {
+		res.Sets[i] = v1.EvalSet(sets[i])
+	}
+	return res, nil
+}
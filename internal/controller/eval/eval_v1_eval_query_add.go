@@ -0,0 +1,29 @@
+package eval
+
+import (
+	"context"
+
+	"knowledge-system-api/api/eval/v1"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/service"
+)
+
+func (c *ControllerV1) EvalQueryAdd(ctx context.Context, req *v1.EvalQueryAddReq) (res *v1.EvalQueryAddRes, err error) {
+	added, err := service.Eval().AddQueries(ctx, req.ID, toModelQueries(req.Queries))
+	if err != nil {
+		return nil, err
+	}
+	return &v1.EvalQueryAddRes{Added: added}, nil
+}
+
+// toModelQueries 转换评测查询，忽略请求中的查询ID
+func toModelQueries(queries []v1.EvalQuery) []model.EvalQuery {
+	result := make([]model.EvalQuery, len(queries))
+	for i, q := range queries {
+		result[i] = model.EvalQuery{Query: q.Query, Judgments: make([]model.EvalJudgment, len(q.Judgments))}
+		for j, judgment := range q.Judgments {
+			result[i].Judgments[j] = model.EvalJudgment(judgment)
+		}
+	}
+	return result
+}
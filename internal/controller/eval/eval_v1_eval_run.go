@@ -0,0 +1,39 @@
+package eval
+
+import (
+	"context"
+
+	"knowledge-system-api/api/eval/v1"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/service"
+)
+
+func (c *ControllerV1) EvalRun(ctx context.Context, req *v1.EvalRunReq) (res *v1.EvalRunRes, err error) {
+	report, err := service.Eval().Run(ctx, req.SetID, model.EvalConfig(req.Config), req.K)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.EvalRunRes{EvalReport: toEvalReport(report)}, nil
+}
+
+// toEvalReport 转换评测结果
+func toEvalReport(report *model.EvalReport) *v1.EvalReport {
+	res := &v1.EvalReport{
+		SetID:    report.SetID,
+		SetName:  report.SetName,
+		RepoName: report.RepoName,
+		Config:   v1.EvalConfig(report.Config),
+		K:        report.K,
+		Queries:  report.Queries,
+		Failed:   report.Failed,
+		Recall:   report.Recall,
+		MRR:      report.MRR,
+		NDCG:     report.NDCG,
+		Latency:  v1.EvalLatency(report.Latency),
+		Details:  make([]v1.EvalQueryResult, len(report.Details)),
+	}
+	for i := range report.Details {
+		res.Details[i] = v1.EvalQueryResult(report.Details[i])
+	}
+	return res
+}
@@ -0,0 +1,16 @@
+package eval
+
+import (
+	"context"
+
+	"knowledge-system-api/api/eval/v1"
+	"knowledge-system-api/internal/service"
+)
+
+func (c *ControllerV1) EvalSetCreate(ctx context.Context, req *v1.EvalSetCreateReq) (res *v1.EvalSetCreateRes, err error) {
+	id, err := service.Eval().CreateSet(ctx, req.RepoName, req.Name, req.Description, toModelQueries(req.Queries))
+	if err != nil {
+		return nil, err
+	}
+	return &v1.EvalSetCreateRes{ID: id}, nil
+}
@@ -0,0 +1,15 @@
+package eval
+
+import (
+	"context"
+
+	"knowledge-system-api/api/eval/v1"
+	"knowledge-system-api/internal/service"
+)
+
+func (c *ControllerV1) EvalSetDelete(ctx context.Context, req *v1.EvalSetDeleteReq) (res *v1.EvalSetDeleteRes, err error) {
+	if err = service.Eval().DeleteSet(ctx, req.ID); err != nil {
+		return nil, err
+	}
+	return &v1.EvalSetDeleteRes{}, nil
+}
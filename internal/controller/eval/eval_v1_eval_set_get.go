@@ -0,0 +1,33 @@
+package eval
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"knowledge-system-api/api/eval/v1"
+	"knowledge-system-api/internal/service"
+)
+
+func (c *ControllerV1) EvalSetGet(ctx context.Context, req *v1.EvalSetGetReq) (res *v1.EvalSetGetRes, err error) {
+	set, queries, err := service.Eval().GetSet(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if set == nil {
+		return nil, gerror.NewCodef(gcode.CodeNotFound, "评测集不存在: %d", req.ID)
+	}
+
+	res = &v1.EvalSetGetRes{
+		EvalSet: v1.EvalSet(*set),
+		Queries: make([]v1.EvalQuery, len(queries)),
+	}
+	for i, q := range queries {
+		res.Queries[i] = v1.EvalQuery{ID: q.ID, Query: q.Query, Judgments: make([]v1.EvalJudgment, len(q.Judgments))}
+		for j, judgment := range q.Judgments {
+			res.Queries[i].Judgments[j] = v1.EvalJudgment(judgment)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,20 @@
+package eval
+
+import (
+	"context"
+
+	"knowledge-system-api/api/eval/v1"
+	"knowledge-system-api/internal/service"
+)
+
+func (c *ControllerV1) EvalSetList(ctx context.Context, req *v1.EvalSetListReq) (res *v1.EvalSetListRes, err error) {
+	sets, err := service.Eval().ListSets(ctx, req.RepoName)
+	if err != nil {
+		return nil, err
+	}
+	res = &v1.EvalSetListRes{List: make([]v1.EvalSet, len(sets))}
+	for i := range sets {
+		res.List[i] = v1.EvalSet(sets[i])
+	}
+	return res, nil
+}
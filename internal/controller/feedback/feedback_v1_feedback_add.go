@@ -10,7 +10,7 @@ import (
 
 func (c *ControllerV1) FeedbackAdd(ctx context.Context, req *v1.FeedbackAddReq) (res *v1.FeedbackAddRes, err error) {
 	// 调用服务
-	feedbackID, err := service.Feedback().Add(ctx, req.SessionID, req.UserQuery, req.KnowledgeID, req.Action)
+	feedbackID, err := service.Feedback().Add(ctx, req.SearchID, req.SessionID, req.UserQuery, req.KnowledgeID, req.Action)
 	if err != nil {
 		return nil, err
 	}
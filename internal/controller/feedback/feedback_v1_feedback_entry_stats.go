@@ -0,0 +1,34 @@
+package feedback
+
+import (
+	"context"
+
+	"knowledge-system-api/api/feedback/v1"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/service"
+)
+
+func (c *ControllerV1) FeedbackEntryStats(ctx context.Context, req *v1.FeedbackEntryStatsReq) (res *v1.FeedbackEntryStatsRes, err error) {
+	opts := model.EntryStatsOptions{
+		RepoName:       req.RepoName,
+		KnowledgeID:    req.KnowledgeID,
+		StartTime:      req.StartTime,
+		EndTime:        req.EndTime,
+		MinImpressions: req.MinImpressions,
+		OrderBy:        req.OrderBy,
+	}
+	stats, total, err := service.Feedback().EntryStats(ctx, opts, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.FeedbackEntryStatsRes{
+		List:  make([]v1.EntryStats, len(stats)),
+		Total: total,
+		Page:  req.Page,
+	}
+	for i, s := range stats {
+		res.List[i] = v1.EntryStats(s)
+	}
+	return res, nil
+}
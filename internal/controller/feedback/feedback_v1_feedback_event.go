@@ -0,0 +1,16 @@
+package feedback
+
+import (
+	"context"
+
+	"knowledge-system-api/api/feedback/v1"
+	"knowledge-system-api/internal/service"
+)
+
+func (c *ControllerV1) FeedbackEvent(ctx context.Context, req *v1.FeedbackEventReq) (res *v1.FeedbackEventRes, err error) {
+	id, err := service.Feedback().AddEvent(ctx, req.SearchID, req.SessionID, req.KnowledgeID, req.Event)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.FeedbackEventRes{ID: id}, nil
+}
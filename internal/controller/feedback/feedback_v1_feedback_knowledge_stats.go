@@ -0,0 +1,33 @@
+package feedback
+
+import (
+	"context"
+
+	"knowledge-system-api/api/feedback/v1"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/service"
+)
+
+func (c *ControllerV1) FeedbackKnowledgeStats(ctx context.Context, req *v1.FeedbackKnowledgeStatsReq) (res *v1.FeedbackKnowledgeStatsRes, err error) {
+	opts := model.FeedbackStatsOptions{
+		RepoName:    req.RepoName,
+		KnowledgeID: req.KnowledgeID,
+		StartTime:   req.StartTime,
+		EndTime:     req.EndTime,
+		OrderBy:     req.OrderBy,
+	}
+	stats, total, err := service.Feedback().KnowledgeStats(ctx, opts, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.FeedbackKnowledgeStatsRes{
+		List:  make([]v1.KnowledgeFeedbackStats, len(stats)),
+		Total: total,
+		Page:  req.Page,
+	}
+	for i, s := range stats {
+		res.List[i] = v1.KnowledgeFeedbackStats(s)
+	}
+	return res, nil
+}
@@ -3,12 +3,35 @@ package feedback
 import (
 	"context"
 
-	"github.com/gogf/gf/v2/errors/gcode"
-	"github.com/gogf/gf/v2/errors/gerror"
-
 	"knowledge-system-api/api/feedback/v1"
+	"knowledge-system-api/internal/service"
 )
 
 func (c *ControllerV1) FeedbackList(ctx context.Context, req *v1.FeedbackListReq) (res *v1.FeedbackListRes, err error) {
-	return nil, gerror.NewCode(gcode.CodeNotImplemented)
+	// 调用服务
+	items, total, err := service.Feedback().List(ctx, req.SessionID, req.KnowledgeID, req.Action, req.StartTime, req.EndTime, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// 返回结果
+	res = &v1.FeedbackListRes{
+		List:  make([]v1.FeedbackItem, len(items)),
+		Total: total,
+		Page:  req.Page,
+	}
+	for i, item := range items {
+		res.List[i] = v1.FeedbackItem{
+			ID:          item.ID,
+			SessionID:   item.SessionID,
+			UserQuery:   item.UserQuery,
+			KnowledgeID: item.KnowledgeID,
+			Action:      item.Action,
+			TurnID:      item.TurnID,
+			SearchID:    item.SearchID,
+			Anonymous:   item.Anonymous,
+			Timestamp:   item.Timestamp.Format("2006-01-02 15:04:05"),
+		}
+	}
+	return
 }
@@ -3,12 +3,35 @@ package feedback
 import (
 	"context"
 
-	"github.com/gogf/gf/v2/errors/gcode"
-	"github.com/gogf/gf/v2/errors/gerror"
-
 	"knowledge-system-api/api/feedback/v1"
+	"knowledge-system-api/internal/consts"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/service"
 )
 
 func (c *ControllerV1) FeedbackProcess(ctx context.Context, req *v1.FeedbackProcessReq) (res *v1.FeedbackProcessRes, err error) {
-	return nil, gerror.NewCode(gcode.CodeNotImplemented)
+	run, err := service.Feedback().ProcessFeedbacks(ctx, consts.FeedbackRunTriggerManual)
+	if err != nil {
+		return &v1.FeedbackProcessRes{Success: false, Message: err.Error(), Run: toFeedbackRun(run)}, nil
+	}
+	return &v1.FeedbackProcessRes{Success: true, Message: "反馈处理完成", Run: toFeedbackRun(run)}, nil
+}
+
+// toFeedbackRun 转换反馈处理记录
+func toFeedbackRun(run *model.FeedbackRun) *v1.FeedbackRun {
+	if run == nil {
+		return nil
+	}
+	return &v1.FeedbackRun{
+		ID:             run.ID,
+		Trigger:        run.Trigger,
+		Status:         run.Status,
+		FromFeedbackID: run.FromFeedbackID,
+		ToFeedbackID:   run.ToFeedbackID,
+		Feedbacks:      run.Feedbacks,
+		Adjusted:       run.Adjusted,
+		Error:          run.Error,
+		StartedAt:      run.StartedAt.Format("Y-m-d H:i:s"),
+		FinishedAt:     run.FinishedAt.Format("Y-m-d H:i:s"),
+	}
 }
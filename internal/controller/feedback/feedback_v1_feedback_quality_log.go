@@ -0,0 +1,35 @@
+package feedback
+
+import (
+	"context"
+
+	"knowledge-system-api/api/feedback/v1"
+	"knowledge-system-api/internal/service"
+)
+
+func (c *ControllerV1) FeedbackQualityLog(ctx context.Context, req *v1.FeedbackQualityLogReq) (res *v1.FeedbackQualityLogRes, err error) {
+	logs, total, err := service.ListQualityLogs(ctx, req.KnowledgeID, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.FeedbackQualityLogRes{
+		List:  make([]v1.QualityLog, len(logs)),
+		Total: total,
+		Page:  req.Page,
+	}
+	for i, log := range logs {
+		res.List[i] = v1.QualityLog{
+			ID:          log.ID,
+			KnowledgeID: log.KnowledgeID,
+			Source:      log.Source,
+			Likes:       log.Likes,
+			Dislikes:    log.Dislikes,
+			ScoreBefore: log.ScoreBefore,
+			Delta:       log.Delta,
+			ScoreAfter:  log.ScoreAfter,
+			CreatedAt:   log.CreatedAt.Format("Y-m-d H:i:s"),
+		}
+	}
+	return res, nil
+}
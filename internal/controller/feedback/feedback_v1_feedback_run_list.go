@@ -0,0 +1,25 @@
+package feedback
+
+import (
+	"context"
+
+	"knowledge-system-api/api/feedback/v1"
+	"knowledge-system-api/internal/service"
+)
+
+func (c *ControllerV1) FeedbackRunList(ctx context.Context, req *v1.FeedbackRunListReq) (res *v1.FeedbackRunListRes, err error) {
+	runs, total, err := service.Feedback().ListRuns(ctx, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.FeedbackRunListRes{
+		List:  make([]v1.FeedbackRun, len(runs)),
+		Total: total,
+		Page:  req.Page,
+	}
+	for i := range runs {
+		res.List[i] = *toFeedbackRun(&runs[i])
+	}
+	return res, nil
+}
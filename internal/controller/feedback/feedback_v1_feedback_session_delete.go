@@ -0,0 +1,28 @@
+package feedback
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/frame/g"
+
+	"knowledge-system-api/api/feedback/v1"
+	"knowledge-system-api/internal/service"
+)
+
+func (c *ControllerV1) FeedbackSessionDelete(ctx context.Context, req *v1.FeedbackSessionDeleteReq) (res *v1.FeedbackSessionDeleteRes, err error) {
+	deletion, err := service.Feedback().DeleteBySession(ctx, req.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	g.Log().Infof(ctx, "已删除会话 %s 的反馈 %d 条、查询反馈 %d 条、检索行为 %d 条、会话轮次 %d 条、展示记录 %d 条、检索记录 %d 条",
+		req.SessionID, deletion.Feedbacks, deletion.QueryFeedbacks, deletion.Events,
+		deletion.Turns, deletion.Impressions, deletion.SearchLogs)
+	return &v1.FeedbackSessionDeleteRes{
+		Feedbacks:      deletion.Feedbacks,
+		QueryFeedbacks: deletion.QueryFeedbacks,
+		Events:         deletion.Events,
+		Turns:          deletion.Turns,
+		Impressions:    deletion.Impressions,
+		SearchLogs:     deletion.SearchLogs,
+	}, nil
+}
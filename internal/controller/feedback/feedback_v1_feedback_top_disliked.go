@@ -0,0 +1,33 @@
+package feedback
+
+import (
+	"context"
+
+	"knowledge-system-api/api/feedback/v1"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/service"
+)
+
+func (c *ControllerV1) FeedbackTopDisliked(ctx context.Context, req *v1.FeedbackTopDislikedReq) (res *v1.FeedbackTopDislikedRes, err error) {
+	opts := model.FeedbackStatsOptions{
+		RepoName:  req.RepoName,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+	}
+	repos, err := service.Feedback().TopDisliked(ctx, opts, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.FeedbackTopDislikedRes{List: make([]v1.RepoDislikedEntries, len(repos))}
+	for i, repo := range repos {
+		res.List[i] = v1.RepoDislikedEntries{
+			RepoName: repo.RepoName,
+			Entries:  make([]v1.KnowledgeFeedbackStats, len(repo.Entries)),
+		}
+		for j, entry := range repo.Entries {
+			res.List[i].Entries[j] = v1.KnowledgeFeedbackStats(entry)
+		}
+	}
+	return res, nil
+}
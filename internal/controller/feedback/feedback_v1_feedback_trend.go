@@ -0,0 +1,27 @@
+package feedback
+
+import (
+	"context"
+
+	"knowledge-system-api/api/feedback/v1"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/service"
+)
+
+func (c *ControllerV1) FeedbackTrend(ctx context.Context, req *v1.FeedbackTrendReq) (res *v1.FeedbackTrendRes, err error) {
+	points, err := service.Feedback().Trend(ctx, model.FeedbackStatsOptions{
+		RepoName:    req.RepoName,
+		KnowledgeID: req.KnowledgeID,
+		StartTime:   req.StartTime,
+		EndTime:     req.EndTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.FeedbackTrendRes{List: make([]v1.FeedbackTrendPoint, len(points))}
+	for i, p := range points {
+		res.List[i] = v1.FeedbackTrendPoint(p)
+	}
+	return res, nil
+}
@@ -0,0 +1,24 @@
+package feedback
+
+import (
+	"context"
+
+	"knowledge-system-api/api/feedback/v1"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/service"
+)
+
+func (c *ControllerV1) QueryFeedbackAdd(ctx context.Context, req *v1.QueryFeedbackAddReq) (res *v1.QueryFeedbackAddRes, err error) {
+	id, err := service.Feedback().AddQueryFeedback(ctx, &model.QueryFeedback{
+		SearchID:  req.SearchID,
+		SessionID: req.SessionID,
+		Query:     req.Query,
+		RepoName:  req.RepoName,
+		Rating:    req.Rating,
+		Comment:   req.Comment,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &v1.QueryFeedbackAddRes{ID: id}, nil
+}
@@ -0,0 +1,42 @@
+package feedback
+
+import (
+	"context"
+
+	"knowledge-system-api/api/feedback/v1"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/service"
+)
+
+func (c *ControllerV1) QueryFeedbackList(ctx context.Context, req *v1.QueryFeedbackListReq) (res *v1.QueryFeedbackListRes, err error) {
+	opts := model.QueryFeedbackListOptions{
+		RepoName:  req.RepoName,
+		Rating:    req.Rating,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+	}
+	feedbacks, total, err := service.Feedback().ListQueryFeedbacks(ctx, opts, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.QueryFeedbackListRes{
+		List:  make([]v1.QueryFeedback, len(feedbacks)),
+		Total: total,
+		Page:  req.Page,
+	}
+	for i, f := range feedbacks {
+		res.List[i] = v1.QueryFeedback{
+			ID:        f.ID,
+			SearchID:  f.SearchID,
+			SessionID: f.SessionID,
+			Query:     f.Query,
+			RepoName:  f.RepoName,
+			Rating:    f.Rating,
+			Comment:   f.Comment,
+			Anonymous: f.Anonymous,
+			CreatedAt: f.CreatedAt.Format("Y-m-d H:i:s"),
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,142 @@
+package knowledge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+
+	v1 "knowledge-system-api/api/knowledge/v1"
+	"knowledge-system-api/internal/consts"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/service"
+)
+
+// Answer 检索知识并生成带引用的答案，stream 为 true 时通过 SSE 逐段返回
+func (c *ControllerV1) Answer(ctx context.Context, req *v1.AnswerReq) (res *v1.AnswerRes, err error) {
+	start := time.Now()
+	opts := &model.AnswerOptions{
+		Search: model.SearchOptions{
+			Query:     req.Query,
+			RepoName:  req.RepoName,
+			RepoNames: req.RepoNames,
+			Limit:     uint64(req.TopK),
+			Rerank:    req.Rerank,
+			Filter:    toSearchFilter(req.Filter),
+		},
+		Mode:          req.Mode,
+		ContextTokens: req.ContextTokens,
+	}
+
+	// 流式返回时错误通过 SSE 写出，需单独记录到 logErr
+	var logErr error
+	searchLog := newSearchLog(consts.ImpressionSourceAnswer, req.SessionID, req.Mode, &opts.Search)
+	defer func() {
+		if logErr == nil {
+			logErr = err
+		}
+		finishSearchLog(ctx, searchLog, start, logErr)
+	}()
+
+	// 多轮会话中先结合历史将追问改写为独立问题
+	rewrite, err := rewriteSessionQuery(ctx, req.SessionID, req.Query)
+	if err != nil {
+		return nil, err
+	}
+	if rewrite != nil {
+		opts.Search.Query = rewrite.RewrittenQuery
+	}
+	searchLog.SearchQuery = opts.Search.Query
+
+	if !req.Stream {
+		output, err := service.Answer().Answer(ctx, opts, nil)
+		if err != nil {
+			if gerror.Code(err) == gcode.CodeInvalidParameter {
+				return nil, err
+			}
+			g.Log().Errorf(ctx, "生成答案失败: %v", err)
+			return nil, gerror.NewCodef(gcode.CodeInternalError, "生成答案失败: %s", err.Error())
+		}
+		recordAnswerTurn(ctx, searchLog, rewrite, output)
+		return toAnswerRes(req, rewrite, searchLog.SearchID, output), nil
+	}
+
+	// SSE 流式返回：已写出内容后统一响应中间件不再包装结果
+	r := g.RequestFromCtx(ctx)
+	r.Response.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+	r.Response.Header().Set("Cache-Control", "no-cache")
+	r.Response.Header().Set("Connection", "keep-alive")
+	r.Response.Header().Set("X-Accel-Buffering", "no")
+
+	output, err := service.Answer().Answer(ctx, opts, func(token string) error {
+		writeSSE(r, "token", g.Map{"token": token})
+		return ctx.Err()
+	})
+	if err != nil {
+		g.Log().Errorf(ctx, "生成答案失败: %v", err)
+		writeSSE(r, "error", g.Map{"message": err.Error()})
+		logErr = err
+		return nil, nil
+	}
+	recordAnswerTurn(ctx, searchLog, rewrite, output)
+	writeSSE(r, "done", toAnswerRes(req, rewrite, searchLog.SearchID, output))
+	return nil, nil
+}
+
+// writeSSE 写出一个 SSE 事件并立即刷新到客户端
+func writeSSE(r *ghttp.Request, event string, data interface{}) {
+	payload, _ := json.Marshal(data)
+	r.Response.Write(fmt.Sprintf("event: %s\ndata: %s\n\n", event, payload))
+	r.Response.Flush()
+}
+
+// recordAnswerTurn 补全检索记录，并以放入上下文的知识条目记录会话轮次与展示记录
+func recordAnswerTurn(ctx context.Context, searchLog *model.SearchLog, rewrite *model.QueryRewrite, output *model.AnswerOutput) {
+	searchLog.Fusion, searchLog.Total, searchLog.Timings = output.Fusion, output.Total, output.Timings
+	searchLog.Results, searchLog.TopScore = service.NewSearchLogResults(output.Results)
+
+	ids := make([]string, len(output.Contexts))
+	impressions := make([]model.ImpressionItem, len(output.Contexts))
+	for i, c := range output.Contexts {
+		ids[i] = c.ID
+		impressions[i] = model.ImpressionItem{KnowledgeID: c.ID, RepoName: c.RepoName, Position: uint(c.Index)}
+	}
+	recordSessionTurn(ctx, searchLog.SessionID, rewrite, ids)
+	recordImpressions(ctx, searchLog, impressions)
+}
+
+// toAnswerRes 将答案生成结果转换为API响应格式
+func toAnswerRes(req *v1.AnswerReq, rewrite *model.QueryRewrite, searchID string, output *model.AnswerOutput) *v1.AnswerRes {
+	res := &v1.AnswerRes{
+		Answer:    output.Answer,
+		Citations: []v1.Citation{},
+		Contexts:  []v1.AnswerContext{},
+		Fusion:    output.Fusion,
+		SearchID:  searchID,
+	}
+	if rewrite != nil {
+		res.SessionID = req.SessionID
+		if rewrite.RewrittenQuery != req.Query {
+			res.RewrittenQuery = rewrite.RewrittenQuery
+		}
+	}
+	for _, c := range output.Citations {
+		res.Citations = append(res.Citations, v1.Citation{Index: c.Index, ID: c.ID, RepoName: c.RepoName})
+	}
+	for _, c := range output.Contexts {
+		res.Contexts = append(res.Contexts, v1.AnswerContext{
+			Index:    c.Index,
+			ID:       c.ID,
+			RepoName: c.RepoName,
+			Summary:  c.Summary,
+			Score:    c.Score,
+			Tokens:   c.Tokens,
+		})
+	}
+	return res
+}
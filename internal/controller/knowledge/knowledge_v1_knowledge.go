@@ -3,8 +3,10 @@ package knowledge
 import (
 	"context"
 	v1 "knowledge-system-api/api/knowledge/v1"
+	"knowledge-system-api/internal/consts"
 	"knowledge-system-api/internal/model"
 	"knowledge-system-api/internal/service"
+	"time"
 
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
@@ -24,7 +26,19 @@ func NewV1() *ControllerV1 {
 // BatchImport 批量导入知识条目
 func (c *ControllerV1) BatchImport(ctx context.Context, req *v1.BatchImportReq) (res *v1.BatchImportRes, err error) {
 	// 参数校验由框架自动完成，这里只需处理业务逻辑
+	repo, err := service.Repo().Ensure(ctx, req.RepoName)
+	if err != nil {
+		g.Log().Errorf(ctx, "获取知识库失败: %v", err)
+		return nil, gerror.NewCodef(gcode.CodeInternalError, "获取知识库失败: %s", err.Error())
+	}
+
 	for _, item := range req.Items {
+		// 按知识库的字段声明转换元数据
+		metadata, err := service.NormalizeMetadata(item.Metadata, repo.MetadataSchema)
+		if err != nil {
+			return nil, err
+		}
+
 		// 调用LLM进行标签分类和摘要生成
 		labels, summary, err := service.LLMClassifyByConfig(ctx, item.Content)
 		if err != nil {
@@ -39,13 +53,13 @@ func (c *ControllerV1) BatchImport(ctx context.Context, req *v1.BatchImportReq)
 		id := uuid.New().String()
 
 		// 存入向量数据库
-		if err := service.QdrantUpsert(ctx, req.RepoName, id, item.Content, summary, filtered); err != nil {
+		if err := service.QdrantUpsert(ctx, req.RepoName, id, item.Content, summary, filtered, metadata); err != nil {
 			g.Log().Errorf(ctx, "Qdrant入库失败: %v", err)
 			return nil, gerror.NewCodef(gcode.CodeInternalError, "Qdrant入库失败: %s", err.Error())
 		}
 
 		// 存入MySQL
-		if err := service.KnowledgeService().CreateKnowledge(ctx, id, req.RepoName, item.Content, filtered, summary); err != nil {
+		if err := service.KnowledgeService().CreateKnowledge(ctx, id, req.RepoName, item.Content, filtered, summary, metadata); err != nil {
 			g.Log().Errorf(ctx, "MySQL入库失败: %v", err)
 			return nil, gerror.NewCodef(gcode.CodeInternalError, "MySQL入库失败: %s", err.Error())
 		}
@@ -65,6 +79,7 @@ func (c *ControllerV1) BatchImportAsync(ctx context.Context, req *v1.BatchImport
 			// 不使用用户提供的 ID，任务项 ID 由系统在处理时生成
 			RepoName: req.RepoName,
 			Content:  item.Content,
+			Metadata: item.Metadata,
 			Status:   "pending",
 		})
 	}
@@ -139,29 +154,188 @@ func (c *ControllerV1) Classify(ctx context.Context, req *v1.ClassifyReq) (res *
 // Search 知识检索
 func (c *ControllerV1) Search(ctx context.Context, req *v1.SearchReq) (res *v1.SearchRes, err error) {
 	// 参数校验由框架自动完成
+	start := time.Now()
 
 	// 设置默认值
 	if req.TopK <= 0 {
 		req.TopK = 5
 	}
 
-	// 根据模式选择不同的搜索方式
-	var items []model.SearchResult
-	switch req.Mode {
-	case "hybrid", "":
-		items, err = service.KnowledgeService().SearchKnowledgeByHybrid(ctx, req.Query, req.RepoName, uint64(req.TopK))
-	default:
-		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "不支持的搜索模式")
+	opts := &model.SearchOptions{
+		Query:     req.Query,
+		RepoName:  req.RepoName,
+		RepoNames: req.RepoNames,
+		Limit:     uint64(req.TopK),
+		Fusion:    req.Fusion,
+		Alpha:     req.Alpha,
+		Beta:      req.Beta,
+		Rerank:    req.Rerank,
+		Filter:    toSearchFilter(req.Filter),
+		Offset:    req.Offset,
+		MinScore:  req.MinScore,
+		Highlight: req.Highlight,
+		Strategy:  req.Strategy,
+	}
+	if req.Explain {
+		opts.Explain = &model.SearchExplain{}
+	}
+
+	// 无论成功与否都记录本次检索，检索ID同时用于展示记录与反馈
+	searchLog := newSearchLog(consts.ImpressionSourceSearch, req.SessionID, req.Mode, opts)
+	defer func() {
+		finishSearchLog(ctx, searchLog, start, err)
+	}()
+
+	if req.Cursor != "" {
+		if opts.Offset, err = service.DecodeSearchCursor(req.Query, req.Cursor); err != nil {
+			return nil, gerror.NewCode(gcode.CodeInvalidParameter, err.Error())
+		}
 	}
 
+	// 多轮会话中先结合历史将追问改写为独立查询
+	rewrite, err := rewriteSessionQuery(ctx, req.SessionID, req.Query)
 	if err != nil {
-		g.Log().Errorf(ctx, "知识检索失败: %v", err)
-		return nil, gerror.NewCodef(gcode.CodeInternalError, "知识检索失败: %s", err.Error())
+		return nil, err
 	}
+	if rewrite != nil {
+		opts.Query = rewrite.RewrittenQuery
+	}
+	searchLog.SearchQuery = opts.Query
+	searchLog.Params.Offset = opts.Offset
+
+	// 相同参数的检索优先使用缓存结果，explain 需要实际执行检索，不读写缓存
+	var output *model.SearchOutput
+	var cacheKey string
+	if opts.Explain == nil {
+		output, cacheKey = service.GetSearchCache(ctx, req.Mode, opts)
+	}
+	if output == nil {
+		// 根据模式选择不同的搜索方式
+		output, err = service.KnowledgeService().SearchKnowledge(ctx, req.Mode, opts)
+		if err != nil {
+			if gerror.Code(err) == gcode.CodeInvalidParameter {
+				return nil, err
+			}
+			g.Log().Errorf(ctx, "知识检索失败: %v", err)
+			return nil, gerror.NewCodef(gcode.CodeInternalError, "知识检索失败: %s", err.Error())
+		}
+		service.SetSearchCache(ctx, cacheKey, output)
+		searchLog.Timings = output.Timings
+	} else {
+		searchLog.Cached = true
+	}
+	searchLog.Fusion, searchLog.Total = output.Fusion, output.Total
+	searchLog.Results, searchLog.TopScore = service.NewSearchLogResults(output.Items)
 
-	// 转换为API响应格式
+	ids := make([]string, len(output.Items))
+	for i, item := range output.Items {
+		ids[i] = item.ID
+	}
+	recordSessionTurn(ctx, req.SessionID, rewrite, ids)
+
+	impressions := make([]model.ImpressionItem, len(output.Items))
+	for i, item := range output.Items {
+		impressions[i] = model.ImpressionItem{KnowledgeID: item.ID, RepoName: item.RepoName, Position: uint(opts.Offset) + uint(i) + 1}
+	}
+
+	res = toSearchRes(req, opts, output)
+	res.SearchID = searchLog.SearchID
+	recordImpressions(ctx, searchLog, impressions)
+	if rewrite != nil {
+		res.SessionID = req.SessionID
+		if rewrite.RewrittenQuery != req.Query {
+			res.RewrittenQuery = rewrite.RewrittenQuery
+		}
+	}
+	return res, nil
+}
+
+// rewriteSessionQuery 校验会话ID并改写查询，未指定会话时返回 nil
+func rewriteSessionQuery(ctx context.Context, sessionID, query string) (*model.QueryRewrite, error) {
+	if sessionID == "" {
+		return nil, nil
+	}
+	if err := service.Session().Validate(ctx, sessionID); err != nil {
+		return nil, err
+	}
+	rewrite, err := service.Session().Rewrite(ctx, sessionID, query)
+	if err != nil {
+		g.Log().Errorf(ctx, "读取会话 %s 失败: %v", sessionID, err)
+		return nil, gerror.NewCodef(gcode.CodeInternalError, "读取会话失败: %s", err.Error())
+	}
+	return rewrite, nil
+}
+
+// recordSessionTurn 记录会话的一轮检索，重复的查询（翻页、重试）不记录，失败只记录日志
+func recordSessionTurn(ctx context.Context, sessionID string, rewrite *model.QueryRewrite, knowledgeIDs []string) {
+	if rewrite == nil || rewrite.Repeated {
+		return
+	}
+	if _, err := service.Session().AppendTurn(ctx, sessionID, rewrite.Query, rewrite.RewrittenQuery, knowledgeIDs); err != nil {
+		g.Log().Warningf(ctx, "记录会话 %s 的检索轮次失败: %v", sessionID, err)
+	}
+}
+
+// newSearchLog 生成检索ID并以请求参数创建检索记录，查询与知识库取自 opts
+func newSearchLog(source, sessionID, mode string, opts *model.SearchOptions) *model.SearchLog {
+	repoNames := opts.RepoNames
+	if opts.RepoName != "" {
+		repoNames = []string{opts.RepoName}
+	}
+	return &model.SearchLog{
+		SearchID:    uuid.New().String(),
+		Source:      source,
+		SessionID:   sessionID,
+		Query:       opts.Query,
+		SearchQuery: opts.Query,
+		RepoNames:   repoNames,
+		Mode:        mode,
+		Params: model.SearchLogParams{
+			Limit:     opts.Limit,
+			Offset:    opts.Offset,
+			MinScore:  opts.MinScore,
+			Fusion:    opts.Fusion,
+			Alpha:     opts.Alpha,
+			Beta:      opts.Beta,
+			Rerank:    opts.Rerank,
+			Strategy:  opts.Strategy,
+			Highlight: opts.Highlight,
+			Explain:   opts.Explain != nil,
+		},
+		Filter:  opts.Filter,
+		Results: []model.SearchLogResult{},
+	}
+}
+
+// finishSearchLog 记录总耗时与失败原因后保存检索记录，失败只记录日志
+func finishSearchLog(ctx context.Context, log *model.SearchLog, start time.Time, err error) {
+	log.LatencyMs = model.NewStageTiming("total", start).Ms
+	if err != nil {
+		log.Error = err.Error()
+	}
+	if err := service.RecordSearchLog(ctx, log); err != nil {
+		g.Log().Warningf(ctx, "保存检索记录 %s 失败: %v", log.SearchID, err)
+	}
+}
+
+// recordImpressions 以检索记录的检索ID记录本次展示的知识条目，失败只记录日志
+func recordImpressions(ctx context.Context, log *model.SearchLog, items []model.ImpressionItem) {
+	err := service.Feedback().RecordImpressions(ctx, &model.Impression{
+		SearchID:  log.SearchID,
+		SessionID: log.SessionID,
+		Source:    log.Source,
+		Query:     log.SearchQuery,
+		Items:     items,
+	})
+	if err != nil {
+		g.Log().Warningf(ctx, "记录检索 %s 的展示条目失败: %v", log.SearchID, err)
+	}
+}
+
+// toSearchRes 将检索结果转换为API响应格式
+func toSearchRes(req *v1.SearchReq, opts *model.SearchOptions, output *model.SearchOutput) *v1.SearchRes {
 	var outItems []v1.KnowledgeResult
-	for _, item := range items {
+	for _, item := range output.Items {
 		var outLabels []v1.LabelScore
 		for _, l := range item.Labels {
 			outLabels = append(outLabels, v1.LabelScore{
@@ -171,16 +345,146 @@ func (c *ControllerV1) Search(ctx context.Context, req *v1.SearchReq) (res *v1.S
 		}
 
 		outItems = append(outItems, v1.KnowledgeResult{
-			ID:       item.ID,
-			RepoName: item.RepoName,
-			Content:  item.Content,
-			Labels:   outLabels,
-			Summary:  item.Summary,
-			Score:    item.Score,
+			ID:          item.ID,
+			RepoName:    item.RepoName,
+			Content:     item.Content,
+			Labels:      outLabels,
+			Summary:     item.Summary,
+			Score:       item.Score,
+			RerankScore: item.RerankScore,
+			Quality:     item.Quality,
+			Metadata:    item.Metadata,
+			Highlights:  toHighlights(item.Highlights),
+		})
+	}
+
+	var outErrors []v1.RepoError
+	for _, e := range output.Errors {
+		outErrors = append(outErrors, v1.RepoError{RepoName: e.RepoName, Message: e.Message})
+	}
+
+	res := &v1.SearchRes{
+		Items:   outItems,
+		Fusion:  output.Fusion,
+		Errors:  outErrors,
+		Total:   output.Total,
+		Offset:  opts.Offset,
+		HasMore: output.HasMore,
+	}
+	if output.Debug != nil {
+		res.Debug = &v1.SearchDebug{
+			Strategy:           output.Debug.Strategy,
+			Queries:            output.Debug.Queries,
+			HypotheticalAnswer: output.Debug.HypotheticalAnswer,
+			Error:              output.Debug.Error,
+		}
+	}
+	if output.Explain != nil {
+		res.Explain = toSearchExplain(output.Explain)
+	}
+	if output.HasMore {
+		res.NextCursor = service.EncodeSearchCursor(req.Query, opts.Offset+uint64(len(output.Items)))
+	}
+	return res
+}
+
+// toSearchExplain 将检索过程说明转换为API响应格式
+func toSearchExplain(e *model.SearchExplain) *v1.SearchExplain {
+	res := &v1.SearchExplain{
+		LabelSource:     e.LabelSource,
+		LabelConfidence: e.LabelConfidence,
+		QueryLabels:     toLabelScores(e.QueryLabels),
+		MappedLabels:    []v1.MappedLabel{},
+		DroppedLabels:   toLabelScores(e.DroppedLabels),
+		SparseVector:    v1.SparseVector{Indices: e.SparseVector.Indices, Values: e.SparseVector.Values},
+		LexicalVector:   v1.SparseVector{Indices: e.LexicalVector.Indices, Values: e.LexicalVector.Values},
+		Repos:           []v1.RepoExplain{},
+		Items:           []v1.ItemExplain{},
+		Timings:         toStageTimings(e.Timings),
+	}
+	for _, l := range e.MappedLabels {
+		res.MappedLabels = append(res.MappedLabels, v1.MappedLabel{Name: l.Name, ID: l.ID, Score: l.Score})
+	}
+	for _, r := range e.Repos {
+		repo := v1.RepoExplain{
+			RepoName:   r.RepoName,
+			Fusion:     r.Fusion,
+			Candidates: []v1.CandidateExplain{},
+			Timings:    toStageTimings(r.Timings),
+		}
+		for _, c := range r.Candidates {
+			repo.Candidates = append(repo.Candidates, v1.CandidateExplain{
+				ID:           c.ID,
+				Prefetched:   c.Prefetched,
+				Returned:     c.Returned,
+				Score:        c.Score,
+				DenseScore:   c.DenseScore,
+				SparseScore:  c.SparseScore,
+				LexicalScore: c.LexicalScore,
+			})
+		}
+		res.Repos = append(res.Repos, repo)
+	}
+	for _, item := range e.Items {
+		res.Items = append(res.Items, v1.ItemExplain{
+			ID:           item.ID,
+			RepoName:     item.RepoName,
+			Score:        item.Score,
+			DenseScore:   item.DenseScore,
+			SparseScore:  item.SparseScore,
+			LexicalScore: item.LexicalScore,
+			RerankScore:  item.RerankScore,
+			Quality:      item.Quality,
 		})
 	}
+	return res
+}
+
+// toLabelScores 转换标签分数，空列表返回空数组
+func toLabelScores(labels []model.LabelScore) []v1.LabelScore {
+	out := []v1.LabelScore{}
+	for _, l := range labels {
+		out = append(out, v1.LabelScore{Name: l.Name, Score: l.Score})
+	}
+	return out
+}
+
+// toStageTimings 转换阶段耗时
+func toStageTimings(timings []model.StageTiming) []v1.StageTiming {
+	out := []v1.StageTiming{}
+	for _, t := range timings {
+		out = append(out, v1.StageTiming{Stage: t.Stage, Ms: t.Ms})
+	}
+	return out
+}
+
+// toHighlights 将高亮片段转换为API响应格式
+func toHighlights(h *model.Highlights) *v1.Highlights {
+	if h == nil {
+		return nil
+	}
+	return &v1.Highlights{Content: h.Content, Summary: h.Summary}
+}
 
-	return &v1.SearchRes{Items: outItems}, nil
+// toSearchFilter 转换检索过滤条件
+func toSearchFilter(f *v1.SearchFilter) *model.SearchFilter {
+	if f == nil {
+		return nil
+	}
+	filter := &model.SearchFilter{
+		CreatedFrom: f.CreatedFrom,
+		CreatedTo:   f.CreatedTo,
+		UpdatedFrom: f.UpdatedFrom,
+		UpdatedTo:   f.UpdatedTo,
+		DocumentIDs: f.DocumentIDs,
+	}
+	for _, l := range f.Labels {
+		filter.Labels = append(filter.Labels, model.LabelFilter{Name: l.Name, MinScore: l.MinScore})
+	}
+	for _, m := range f.Metadata {
+		filter.Metadata = append(filter.Metadata, model.MetadataFilter{Key: m.Key, Match: m.Match, Gte: m.Gte, Lte: m.Lte})
+	}
+	return filter
 }
 
 // GetRepos 获取所有知识库
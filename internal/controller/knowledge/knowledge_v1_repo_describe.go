@@ -0,0 +1,36 @@
+package knowledge
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	v1 "knowledge-system-api/api/knowledge/v1"
+	"knowledge-system-api/internal/service"
+)
+
+// RepoDescribe 获取知识库详情，包括与 Qdrant 集合的映射关系
+func (c *ControllerV1) RepoDescribe(ctx context.Context, req *v1.RepoDescribeReq) (res *v1.RepoDescribeRes, err error) {
+	repo, err := service.Repo().Describe(ctx, req.RepoName)
+	if err != nil {
+		g.Log().Errorf(ctx, "获取知识库详情失败: %v", err)
+		return nil, gerror.NewCodef(gcode.CodeInternalError, "获取知识库详情失败: %s", err.Error())
+	}
+	if repo == nil {
+		return nil, gerror.NewCodef(gcode.CodeNotFound, "知识库不存在: %s", req.RepoName)
+	}
+
+	return &v1.RepoDescribeRes{
+		RepoName:       repo.Name,
+		RepoID:         repo.ID,
+		Alias:          repo.Alias,
+		Collection:     repo.Collection,
+		PointsCount:    repo.PointsCount,
+		KnowledgeCount: repo.KnowledgeCount,
+		MetadataSchema: toMetadataFields(repo.MetadataSchema),
+		CreatedAt:      repo.CreatedAt.String(),
+		UpdatedAt:      repo.UpdatedAt.String(),
+	}, nil
+}
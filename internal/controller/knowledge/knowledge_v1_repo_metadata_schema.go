@@ -0,0 +1,35 @@
+package knowledge
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/frame/g"
+
+	v1 "knowledge-system-api/api/knowledge/v1"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/service"
+)
+
+// RepoMetadataSchema 声明知识库元数据字段，知识库不存在时自动创建
+func (c *ControllerV1) RepoMetadataSchema(ctx context.Context, req *v1.RepoMetadataSchemaReq) (res *v1.RepoMetadataSchemaRes, err error) {
+	fields := make([]model.MetadataField, 0, len(req.Fields))
+	for _, f := range req.Fields {
+		fields = append(fields, model.MetadataField{Key: f.Key, Type: f.Type})
+	}
+
+	repo, err := service.Repo().AddMetadataFields(ctx, req.RepoName, fields)
+	if err != nil {
+		g.Log().Errorf(ctx, "声明元数据字段失败: %v", err)
+		return nil, err
+	}
+	return &v1.RepoMetadataSchemaRes{MetadataSchema: toMetadataFields(repo.MetadataSchema)}, nil
+}
+
+// toMetadataFields 转换元数据字段声明
+func toMetadataFields(schema []model.MetadataField) []v1.MetadataField {
+	fields := make([]v1.MetadataField, 0, len(schema))
+	for _, f := range schema {
+		fields = append(fields, v1.MetadataField{Key: f.Key, Type: f.Type})
+	}
+	return fields
+}
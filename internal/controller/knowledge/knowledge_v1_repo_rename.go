@@ -0,0 +1,19 @@
+package knowledge
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/frame/g"
+
+	v1 "knowledge-system-api/api/knowledge/v1"
+	"knowledge-system-api/internal/service"
+)
+
+// RepoRename 重命名知识库，向量数据无需迁移
+func (c *ControllerV1) RepoRename(ctx context.Context, req *v1.RepoRenameReq) (res *v1.RepoRenameRes, err error) {
+	if err = service.Repo().Rename(ctx, req.RepoName, req.NewName); err != nil {
+		g.Log().Errorf(ctx, "重命名知识库失败: %v", err)
+		return nil, err
+	}
+	return &v1.RepoRenameRes{Success: true}, nil
+}
@@ -0,0 +1,21 @@
+package knowledge
+
+import (
+	"context"
+
+	v1 "knowledge-system-api/api/knowledge/v1"
+	"knowledge-system-api/internal/service"
+)
+
+// SearchCacheStats 获取检索结果缓存的命中统计
+func (c *ControllerV1) SearchCacheStats(ctx context.Context, req *v1.SearchCacheStatsReq) (res *v1.SearchCacheStatsRes, err error) {
+	stats := service.SearchCacheStats(ctx)
+	return &v1.SearchCacheStatsRes{
+		Enabled: stats.Enabled,
+		Backend: stats.Backend,
+		Hits:    stats.Hits,
+		Misses:  stats.Misses,
+		HitRate: stats.HitRate,
+		Size:    stats.Size,
+	}, nil
+}
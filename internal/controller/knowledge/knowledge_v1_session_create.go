@@ -0,0 +1,21 @@
+package knowledge
+
+import (
+	"context"
+
+	v1 "knowledge-system-api/api/knowledge/v1"
+	"knowledge-system-api/internal/service"
+)
+
+// SessionCreate 签发新的会话ID
+func (c *ControllerV1) SessionCreate(ctx context.Context, req *v1.SessionCreateReq) (res *v1.SessionCreateRes, err error) {
+	session, err := service.Session().Create(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res = &v1.SessionCreateRes{SessionID: session.ID}
+	if session.ExpiresAt != nil {
+		res.ExpiresAt = session.ExpiresAt.String()
+	}
+	return res, nil
+}
@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"knowledge-system-api/internal/dao/internal"
+)
+
+// evalQueryDao is the data access object for the table eval_query.
+// You can define custom methods on it to extend its functionality as needed.
+type evalQueryDao struct {
+	*internal.EvalQueryDao
+}
+
+var (
+	// EvalQuery is a globally accessible object for table eval_query operations.
+	EvalQuery = evalQueryDao{internal.NewEvalQueryDao()}
+)
+
+// Add your custom methods and functionality below.
@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"knowledge-system-api/internal/dao/internal"
+)
+
+// evalSetDao is the data access object for the table eval_set.
+// You can define custom methods on it to extend its functionality as needed.
+type evalSetDao struct {
+	*internal.EvalSetDao
+}
+
+var (
+	// EvalSet is a globally accessible object for table eval_set operations.
+	EvalSet = evalSetDao{internal.NewEvalSetDao()}
+)
+
+// Add your custom methods and functionality below.
@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"knowledge-system-api/internal/dao/internal"
+)
+
+// feedbackRunDao is the data access object for the table feedback_run.
+// You can define custom methods on it to extend its functionality as needed.
+type feedbackRunDao struct {
+	*internal.FeedbackRunDao
+}
+
+var (
+	// FeedbackRun is a globally accessible object for table feedback_run operations.
+	FeedbackRun = feedbackRunDao{internal.NewFeedbackRunDao()}
+)
+
+// Add your custom methods and functionality below.
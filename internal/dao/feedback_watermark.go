@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"knowledge-system-api/internal/dao/internal"
+)
+
+// feedbackWatermarkDao is the data access object for the table feedback_watermark.
+// You can define custom methods on it to extend its functionality as needed.
+type feedbackWatermarkDao struct {
+	*internal.FeedbackWatermarkDao
+}
+
+var (
+	// FeedbackWatermark is a globally accessible object for table feedback_watermark operations.
+	FeedbackWatermark = feedbackWatermarkDao{internal.NewFeedbackWatermarkDao()}
+)
+
+// Add your custom methods and functionality below.
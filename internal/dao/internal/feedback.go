@@ -22,10 +22,13 @@ type FeedbackDao struct {
 // FeedbackColumns defines and stores column names for the table feedback.
 type FeedbackColumns struct {
 	Id                   string // 主键ID
-	SessionId            string // 用户会话ID
+	SessionId            string // 用户会话ID，匿名反馈时为空
 	UserQuery            string // 用户查询内容
 	RetrievedKnowledgeId string // 被检索到的知识ID
 	Action               string // 反馈操作类型
+	TurnId               string // 关联的会话轮次ID
+	SearchId             string // 产生该反馈的检索ID
+	Anonymous            string // 是否匿名反馈（没有会话ID）
 	Timestamp            string // 反馈时间
 }
 
@@ -36,6 +39,9 @@ var feedbackColumns = FeedbackColumns{
 	UserQuery:            "user_query",
 	RetrievedKnowledgeId: "retrieved_knowledge_id",
 	Action:               "action",
+	TurnId:               "turn_id",
+	SearchId:             "search_id",
+	Anonymous:            "anonymous",
 	Timestamp:            "timestamp",
 }
 
@@ -26,6 +26,7 @@ type KnowledgeColumns struct {
 	Content   string // 知识内容
 	Labels    string // 标签分数数组
 	Summary   string // 内容摘要
+	Metadata  string // 用户自定义元数据
 	CreatedAt string // 创建时间
 	UpdatedAt string // 更新时间
 }
@@ -37,6 +38,7 @@ var knowledgeColumns = KnowledgeColumns{
 	Content:   "content",
 	Labels:    "labels",
 	Summary:   "summary",
+	Metadata:  "metadata",
 	CreatedAt: "created_at",
 	UpdatedAt: "updated_at",
 }
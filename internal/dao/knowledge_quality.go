@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"knowledge-system-api/internal/dao/internal"
+)
+
+// knowledgeQualityDao is the data access object for the table knowledge_quality.
+// You can define custom methods on it to extend its functionality as needed.
+type knowledgeQualityDao struct {
+	*internal.KnowledgeQualityDao
+}
+
+var (
+	// KnowledgeQuality is a globally accessible object for table knowledge_quality operations.
+	KnowledgeQuality = knowledgeQualityDao{internal.NewKnowledgeQualityDao()}
+)
+
+// Add your custom methods and functionality below.
@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"knowledge-system-api/internal/dao/internal"
+)
+
+// knowledgeQualityLogDao is the data access object for the table knowledge_quality_log.
+// You can define custom methods on it to extend its functionality as needed.
+type knowledgeQualityLogDao struct {
+	*internal.KnowledgeQualityLogDao
+}
+
+var (
+	// KnowledgeQualityLog is a globally accessible object for table knowledge_quality_log operations.
+	KnowledgeQualityLog = knowledgeQualityLogDao{internal.NewKnowledgeQualityLogDao()}
+)
+
+// Add your custom methods and functionality below.
@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"knowledge-system-api/internal/dao/internal"
+)
+
+// knowledgeRepoDao is the data access object for the table knowledge_repo.
+// You can define custom methods on it to extend its functionality as needed.
+type knowledgeRepoDao struct {
+	*internal.KnowledgeRepoDao
+}
+
+var (
+	// KnowledgeRepo is a globally accessible object for table knowledge_repo operations.
+	KnowledgeRepo = knowledgeRepoDao{internal.NewKnowledgeRepoDao()}
+)
+
+// Add your custom methods and functionality below.
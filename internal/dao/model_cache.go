@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"knowledge-system-api/internal/dao/internal"
+)
+
+// modelCacheDao is the data access object for the table model_cache.
+// You can define custom methods on it to extend its functionality as needed.
+type modelCacheDao struct {
+	*internal.ModelCacheDao
+}
+
+var (
+	// ModelCache is a globally accessible object for table model_cache operations.
+	ModelCache = modelCacheDao{internal.NewModelCacheDao()}
+)
+
+// Add your custom methods and functionality below.
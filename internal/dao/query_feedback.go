@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"knowledge-system-api/internal/dao/internal"
+)
+
+// queryFeedbackDao is the data access object for the table query_feedback.
+// You can define custom methods on it to extend its functionality as needed.
+type queryFeedbackDao struct {
+	*internal.QueryFeedbackDao
+}
+
+var (
+	// QueryFeedback is a globally accessible object for table query_feedback operations.
+	QueryFeedback = queryFeedbackDao{internal.NewQueryFeedbackDao()}
+)
+
+// Add your custom methods and functionality below.
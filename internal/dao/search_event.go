@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"knowledge-system-api/internal/dao/internal"
+)
+
+// searchEventDao is the data access object for the table search_event.
+// You can define custom methods on it to extend its functionality as needed.
+type searchEventDao struct {
+	*internal.SearchEventDao
+}
+
+var (
+	// SearchEvent is a globally accessible object for table search_event operations.
+	SearchEvent = searchEventDao{internal.NewSearchEventDao()}
+)
+
+// Add your custom methods and functionality below.
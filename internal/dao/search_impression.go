@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"knowledge-system-api/internal/dao/internal"
+)
+
+// searchImpressionDao is the data access object for the table search_impression.
+// You can define custom methods on it to extend its functionality as needed.
+type searchImpressionDao struct {
+	*internal.SearchImpressionDao
+}
+
+var (
+	// SearchImpression is a globally accessible object for table search_impression operations.
+	SearchImpression = searchImpressionDao{internal.NewSearchImpressionDao()}
+)
+
+// Add your custom methods and functionality below.
@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"knowledge-system-api/internal/dao/internal"
+)
+
+// searchLogDao is the data access object for the table search_log.
+// You can define custom methods on it to extend its functionality as needed.
+type searchLogDao struct {
+	*internal.SearchLogDao
+}
+
+var (
+	// SearchLog is a globally accessible object for table search_log operations.
+	SearchLog = searchLogDao{internal.NewSearchLogDao()}
+)
+
+// Add your custom methods and functionality below.
@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"knowledge-system-api/internal/dao/internal"
+)
+
+// sessionTurnDao is the data access object for the table session_turn.
+// You can define custom methods on it to extend its functionality as needed.
+type sessionTurnDao struct {
+	*internal.SessionTurnDao
+}
+
+var (
+	// SessionTurn is a globally accessible object for table session_turn operations.
+	SessionTurn = sessionTurnDao{internal.NewSessionTurnDao()}
+)
+
+// Add your custom methods and functionality below.
@@ -50,14 +50,6 @@ type sDictionary struct {
 	labelToID map[string]uint32 // 内存中的只读映射表
 }
 
-// init 函数在包被导入时自动执行。
-// 这是 Go 语言的特性，非常适合用来做服务注册。
-func init() {
-	// 在这里，我们将 New() 函数创建的实例注册为服务单例。
-	// RegisterDictionary 函数现在已经被定义，所以这个调用不会再报错。
-	RegisterDictionary(New())
-}
-
 // New 函数创建并返回一个具体的服务实现，它是 IDictionary 类型。
 func New() IDictionary {
 	// 从配置文件获取映射文件路径
@@ -30,6 +30,10 @@ func SetInitFunctions(fns InitFunctions) {
 
 // InitAll 执行所有初始化
 func InitAll() {
+	// 加载标签字典并注册为服务单例，映射文件缺失时直接退出
+	// 不放在包的 init 中，使导入本包的单元测试不依赖配置文件
+	RegisterDictionary(New())
+
 	if globalInitFunctions.InitServices != nil {
 		globalInitFunctions.InitServices()
 	}
@@ -12,7 +12,7 @@ type LLMClassifyFunc func(ctx context.Context, content string) ([]model.LabelSco
 type FilterLabelsFunc func(labels []model.LabelScore, threshold float32) []model.LabelScore
 
 // QdrantUpsertFunc Qdrant 向量库插入函数类型
-type QdrantUpsertFunc func(ctx context.Context, repoName string, id string, content string, summary string, labels []model.LabelScore) error
+type QdrantUpsertFunc func(ctx context.Context, repoName string, id string, content string, summary string, labels []model.LabelScore, metadata map[string]interface{}) error
 
 // KnowledgeServiceFunc 获取知识库服务接口实例函数类型
 type KnowledgeServiceFunc func() interface{}
@@ -9,7 +9,7 @@ import (
 type VectorizeFunc func(ctx context.Context, text string) ([]float32, error)
 
 // VectorSearchFunc 向量搜索函数类型
-type VectorSearchFunc func(repoName string, content string, labels []model.LabelScore, limit uint64) ([]model.VectorSearchResult, error)
+type VectorSearchFunc func(ctx context.Context, opts *model.SearchOptions) (*model.VectorSearchOutput, error)
 
 // 全局函数变量
 var (
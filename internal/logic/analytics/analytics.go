@@ -0,0 +1,9 @@
+package analytics
+
+// Analytics 检索统计分析服务实现，基于反馈与检索记录生成报告
+type Analytics struct{}
+
+// New 创建检索统计分析服务
+func New() *Analytics {
+	return &Analytics{}
+}
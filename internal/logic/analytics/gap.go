@@ -0,0 +1,412 @@
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+
+	"knowledge-system-api/internal/consts"
+	"knowledge-system-api/internal/dao"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/service"
+)
+
+// gapCandidate 归一化后相同的一组未被满足的查询
+type gapCandidate struct {
+	repoName string
+	forms    map[string]int // 原始写法 -> 出现次数
+	first    []string       // 原始写法首次出现的顺序，出现次数相同时取先出现的
+	likes    int            // 点赞数，只用于判断是否以点踩为主
+	signals  model.GapSignals
+}
+
+// query 出现次数最多的原始写法
+func (c *gapCandidate) query() string {
+	query := ""
+	for _, form := range c.first {
+		if c.forms[form] > c.forms[query] {
+			query = form
+		}
+	}
+	return query
+}
+
+// gapCollector 按知识库与归一化查询汇总信号
+type gapCollector struct {
+	candidates map[string]*gapCandidate
+	keys       []string
+	signals    int
+}
+
+// add 将一条查询计入对应的候选，查询归一化后为空时返回 nil
+func (c *gapCollector) add(repoName, query string) *gapCandidate {
+	query = strings.TrimSpace(query)
+	normalized := service.NormalizeQuery(query)
+	if normalized == "" {
+		return nil
+	}
+	key := repoName + "\x00" + normalized
+	candidate, ok := c.candidates[key]
+	if !ok {
+		candidate = &gapCandidate{repoName: repoName, forms: make(map[string]int)}
+		c.candidates[key] = candidate
+		c.keys = append(c.keys, key)
+	}
+	if candidate.forms[query] == 0 {
+		candidate.first = append(candidate.first, query)
+	}
+	candidate.forms[query]++
+	return candidate
+}
+
+// gapCluster 聚类中的主题，centroid 为成员单位向量之和
+type gapCluster struct {
+	repoName string
+	centroid []float64
+	members  []*gapCandidate
+	signals  model.GapSignals
+}
+
+// ContentGaps 将未被满足的查询按语义聚类，生成按出现次数排列的内容缺口报告
+// 未被满足的信号包括：点踩多于点赞的查询（关联会话轮次时使用改写后的查询）、“没有相关结果”的查询反馈，
+// 以及检索记录中没有结果或最高检索分数低于 analytics.gaps.low_score 中对应融合策略阈值的检索。
+// 查询按知识库分别聚类：依出现次数从多到少，与已有主题中心的余弦相似度不低于阈值时归入最相近的主题，否则新建主题。
+func (s *Analytics) ContentGaps(ctx context.Context, opts model.ContentGapOptions) (*model.ContentGapReport, error) {
+	cfg := service.LoadContentGapConfig(ctx)
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = cfg.MaxClusters
+	}
+
+	collector := &gapCollector{candidates: make(map[string]*gapCandidate)}
+	if err := collectDislikes(ctx, opts, collector); err != nil {
+		return nil, err
+	}
+	if err := collectNoRelevant(ctx, opts, collector); err != nil {
+		return nil, err
+	}
+	if err := collectSearchLogs(ctx, opts, cfg, collector); err != nil {
+		return nil, err
+	}
+
+	var candidates []*gapCandidate
+	for _, key := range collector.keys {
+		candidate := collector.candidates[key]
+		if candidate.signals.Total() > 0 {
+			candidates = append(candidates, candidate)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].signals.Total() > candidates[j].signals.Total()
+	})
+	report := &model.ContentGapReport{
+		Signals:  collector.signals,
+		Queries:  len(candidates),
+		Clusters: []model.GapCluster{},
+	}
+	if len(candidates) > cfg.MaxQueries {
+		report.Skipped = len(candidates) - cfg.MaxQueries
+		candidates = candidates[:cfg.MaxQueries]
+	}
+
+	var clusters []*gapCluster
+	for _, candidate := range candidates {
+		vector, err := service.Vectorize(ctx, candidate.query())
+		if err != nil {
+			g.Log().Warningf(ctx, "内容缺口查询向量化失败: %v, 查询: %s", err, candidate.query())
+			report.Skipped++
+			continue
+		}
+		unit := unitVector(vector)
+		if unit == nil {
+			report.Skipped++
+			continue
+		}
+		cluster := nearestCluster(clusters, candidate.repoName, unit, cfg.Similarity)
+		if cluster == nil {
+			cluster = &gapCluster{repoName: candidate.repoName, centroid: make([]float64, len(unit))}
+			clusters = append(clusters, cluster)
+		}
+		for i, x := range unit {
+			cluster.centroid[i] += x
+		}
+		cluster.members = append(cluster.members, candidate)
+		cluster.signals.Add(candidate.signals)
+	}
+
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return clusters[i].signals.Total() > clusters[j].signals.Total()
+	})
+	if len(clusters) > limit {
+		clusters = clusters[:limit]
+	}
+	for _, cluster := range clusters {
+		out := model.GapCluster{
+			RepoName: cluster.repoName,
+			Count:    cluster.signals.Total(),
+			Signals:  cluster.signals,
+			Queries:  make([]model.GapQuery, len(cluster.members)),
+		}
+		for i, member := range cluster.members {
+			out.Queries[i] = model.GapQuery{
+				Query:   member.query(),
+				Count:   member.signals.Total(),
+				Signals: member.signals,
+			}
+		}
+		out.Label = labelCluster(ctx, cfg, out.Queries)
+		report.Clusters = append(report.Clusters, out)
+	}
+	g.Log().Infof(ctx, "内容缺口报告: %d 个信号，%d 条查询，跳过 %d 条，返回 %d 个主题",
+		report.Signals, report.Queries, report.Skipped, len(report.Clusters))
+	return report, nil
+}
+
+// collectDislikes 汇总点踩多于点赞的查询，计入其点踩数
+func collectDislikes(ctx context.Context, opts model.ContentGapOptions, collector *gapCollector) error {
+	m := dao.Feedback.Ctx(ctx).As("f").
+		InnerJoin("knowledge k", "k.id = f.retrieved_knowledge_id").
+		LeftJoin("session_turn t", "t.id = f.turn_id").
+		Fields("f.user_query, t.rewritten_query, f.action, k.repo_name")
+	if opts.RepoName != "" {
+		m = m.Where("k.repo_name", opts.RepoName)
+	}
+	if opts.StartTime != nil {
+		m = m.WhereGTE("f.timestamp", opts.StartTime)
+	}
+	if opts.EndTime != nil {
+		m = m.WhereLTE("f.timestamp", opts.EndTime)
+	}
+	var rows []struct {
+		UserQuery      string
+		RewrittenQuery string
+		Action         string
+		RepoName       string
+	}
+	if err := m.OrderAsc("f.id").Scan(&rows); err != nil {
+		return err
+	}
+
+	dislikes := make(map[*gapCandidate]int)
+	for _, row := range rows {
+		query := row.UserQuery
+		if strings.TrimSpace(row.RewrittenQuery) != "" {
+			query = row.RewrittenQuery
+		}
+		candidate := collector.add(row.RepoName, query)
+		if candidate == nil {
+			continue
+		}
+		if row.Action == "like" {
+			candidate.likes++
+		} else {
+			dislikes[candidate]++
+		}
+	}
+	for candidate, n := range dislikes {
+		if n > candidate.likes {
+			candidate.signals.Dislikes += n
+			collector.signals += n
+		}
+	}
+	return nil
+}
+
+// collectNoRelevant 汇总“没有相关结果”的查询反馈
+func collectNoRelevant(ctx context.Context, opts model.ContentGapOptions, collector *gapCollector) error {
+	cols := dao.QueryFeedback.Columns()
+	m := dao.QueryFeedback.Ctx(ctx).
+		Fields(cols.Query, cols.RepoName).
+		Where(cols.Rating, consts.QueryRatingNoRelevant)
+	if opts.RepoName != "" {
+		m = m.Where(cols.RepoName, opts.RepoName)
+	}
+	if opts.StartTime != nil {
+		m = m.WhereGTE(cols.CreatedAt, opts.StartTime)
+	}
+	if opts.EndTime != nil {
+		m = m.WhereLTE(cols.CreatedAt, opts.EndTime)
+	}
+	var rows []struct {
+		Query    string
+		RepoName string
+	}
+	if err := m.OrderAsc(cols.Id).Scan(&rows); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if candidate := collector.add(row.RepoName, row.Query); candidate != nil {
+			candidate.signals.NoRelevant++
+			collector.signals++
+		}
+	}
+	return nil
+}
+
+// collectSearchLogs 汇总检索记录中只检索单个知识库且没有结果或结果分数过低的检索，使用实际检索的查询，失败的检索不计入
+// 未指定开始时间时只读取结束时间之前 search_log_days 天的记录，最多读取最近的 max_search_logs 条；
+// 低分按实际使用的融合策略分别判断，比较结果中最高的检索分数
+func collectSearchLogs(ctx context.Context, opts model.ContentGapOptions, cfg *service.ContentGapConfig, collector *gapCollector) error {
+	end := time.Now()
+	if opts.EndTime != nil {
+		end = opts.EndTime.Time
+	}
+	start := end.AddDate(0, 0, -cfg.SearchLogDays)
+	if opts.StartTime != nil {
+		start = opts.StartTime.Time
+	}
+
+	cols := dao.SearchLog.Columns()
+	m := dao.SearchLog.Ctx(ctx).
+		Fields(cols.SearchQuery, cols.RepoNames, cols.Fusion, cols.ResultCount, cols.Results).
+		WhereNull(cols.Error).
+		Where("JSON_LENGTH(repo_names) = 1").
+		WhereBetween(cols.CreatedAt, start, end)
+	fusions := make([]string, 0, len(cfg.LowScore))
+	for fusion := range cfg.LowScore {
+		fusions = append(fusions, fusion)
+	}
+	if len(fusions) > 0 {
+		m = m.Where(fmt.Sprintf("(%s = 0 OR %s IN (?))", cols.ResultCount, cols.Fusion), fusions)
+	} else {
+		m = m.Where(cols.ResultCount, 0)
+	}
+	if opts.RepoName != "" {
+		m = m.Where("JSON_CONTAINS(repo_names, JSON_QUOTE(?))", opts.RepoName)
+	}
+	var rows []struct {
+		SearchQuery string
+		RepoNames   string
+		Fusion      string
+		ResultCount int
+		Results     string
+	}
+	if err := m.OrderDesc(cols.Id).Limit(cfg.MaxSearchLogs).Scan(&rows); err != nil {
+		return err
+	}
+	if len(rows) == cfg.MaxSearchLogs {
+		g.Log().Infof(ctx, "内容缺口报告读取的检索记录达到上限 %d 条，更早的记录未统计", cfg.MaxSearchLogs)
+	}
+	for _, row := range rows {
+		var repoNames []string
+		if err := json.Unmarshal([]byte(row.RepoNames), &repoNames); err != nil || len(repoNames) != 1 {
+			continue
+		}
+		zero := row.ResultCount == 0
+		if !zero {
+			threshold, ok := cfg.LowScore[row.Fusion]
+			if !ok || !lowScoreResults(row.Results, threshold) {
+				continue
+			}
+		}
+		candidate := collector.add(repoNames[0], row.SearchQuery)
+		if candidate == nil {
+			continue
+		}
+		if zero {
+			candidate.signals.ZeroResult++
+		} else {
+			candidate.signals.LowScore++
+		}
+		collector.signals++
+	}
+	return nil
+}
+
+// lowScoreResults 检索记录的结果中最高的检索分数是否低于阈值，不使用重排序分数
+func lowScoreResults(results string, threshold float64) bool {
+	var items []model.SearchLogResult
+	if err := json.Unmarshal([]byte(results), &items); err != nil || len(items) == 0 {
+		return false
+	}
+	best := float64(items[0].Score)
+	for _, item := range items[1:] {
+		best = max(best, float64(item.Score))
+	}
+	return best < threshold
+}
+
+// nearestCluster 同一知识库中与查询向量最相近且相似度不低于阈值的主题，没有时返回 nil
+func nearestCluster(clusters []*gapCluster, repoName string, unit []float64, threshold float64) *gapCluster {
+	var best *gapCluster
+	bestSim := threshold
+	for _, cluster := range clusters {
+		if cluster.repoName != repoName || len(cluster.centroid) != len(unit) {
+			continue
+		}
+		var dot, norm float64
+		for i, x := range cluster.centroid {
+			dot += x * unit[i]
+			norm += x * x
+		}
+		if norm == 0 {
+			continue
+		}
+		if sim := dot / math.Sqrt(norm); sim >= bestSim {
+			best, bestSim = cluster, sim
+		}
+	}
+	return best
+}
+
+// labelCluster 调用大模型为主题生成名称，失败时使用出现次数最多的查询
+func labelCluster(ctx context.Context, cfg *service.ContentGapConfig, queries []model.GapQuery) string {
+	fallback := queries[0].Query
+	promptTmpl, err := service.LoadPromptTemplate(cfg.LabelPromptPath)
+	if err != nil {
+		g.Log().Warningf(ctx, "加载主题名称Prompt模板失败: %v", err)
+		return fallback
+	}
+	var lines []string
+	for i, q := range queries {
+		if i == cfg.SampleQueries {
+			break
+		}
+		lines = append(lines, fmt.Sprintf("- %s", q.Query))
+	}
+	prompt := strings.ReplaceAll(promptTmpl, "{queries}", strings.Join(lines, "\n"))
+
+	budgetCtx, cancel := context.WithTimeout(ctx, time.Duration(cfg.LabelBudgetMs)*time.Millisecond)
+	defer cancel()
+	resp, err := service.GetLLMClient().Generate(budgetCtx, prompt)
+	if err != nil {
+		g.Log().Warningf(ctx, "生成主题名称失败: %v", err)
+		return fallback
+	}
+	jsonStr, err := service.ExtractJSONFromLLMResponse(resp)
+	if err != nil {
+		g.Log().Warningf(ctx, "提取主题名称JSON失败: %v", err)
+		return fallback
+	}
+	var parsed struct {
+		Label string `json:"label"`
+	}
+	if err := json.Unmarshal([]byte(jsonStr), &parsed); err != nil || strings.TrimSpace(parsed.Label) == "" {
+		g.Log().Warningf(ctx, "解析主题名称失败: %v, 响应: %s", err, resp)
+		return fallback
+	}
+	return strings.TrimSpace(parsed.Label)
+}
+
+// unitVector 归一化为单位向量，零向量返回 nil
+func unitVector(v []float32) []float64 {
+	var norm float64
+	for _, x := range v {
+		norm += float64(x) * float64(x)
+	}
+	if norm == 0 {
+		return nil
+	}
+	norm = math.Sqrt(norm)
+	out := make([]float64, len(v))
+	for i, x := range v {
+		out[i] = float64(x) / norm
+	}
+	return out
+}
@@ -0,0 +1,165 @@
+package answer
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/service"
+)
+
+// citationPattern 答案中的引用标注，支持 [1]、[1,2]、[1、2] 等写法
+var citationPattern = regexp.MustCompile(`\[(\d+(?:\s*[,，、]\s*\d+)*)\]`)
+
+// minChunkTokens 预算剩余不足该值时不再截断放入新的知识条目
+const minChunkTokens = 100
+
+// Answer 答案生成服务实现
+type Answer struct{}
+
+// New 创建答案生成服务
+func New() *Answer {
+	return &Answer{}
+}
+
+// Answer 检索知识，在 token 预算内组装上下文后调用大模型生成带引用的答案
+func (s *Answer) Answer(ctx context.Context, opts *model.AnswerOptions, onToken func(token string) error) (*model.AnswerOutput, error) {
+	cfg := service.LoadAnswerConfig(ctx)
+	budget := opts.ContextTokens
+	if budget <= 0 {
+		budget = cfg.ContextTokens
+	}
+	searchOpts := opts.Search
+	if searchOpts.Limit == 0 {
+		searchOpts.Limit = uint64(cfg.TopK)
+	}
+
+	// 步骤1：检索
+	start := time.Now()
+	output, err := service.KnowledgeService().SearchKnowledge(ctx, opts.Mode, &searchOpts)
+	if err != nil {
+		return nil, fmt.Errorf("检索知识失败: %w", err)
+	}
+
+	// 步骤2：按检索顺序在预算内组装上下文
+	contextText, contexts := buildContext(output.Items, budget)
+	result := &model.AnswerOutput{Contexts: contexts, Fusion: output.Fusion, Results: output.Items, Total: output.Total, Timings: output.Timings}
+	if len(result.Timings) == 0 {
+		result.Timings = []model.StageTiming{model.NewStageTiming("search", start)}
+	}
+	if len(contexts) == 0 {
+		result.Answer = cfg.NoContextAnswer
+		if onToken != nil {
+			if err := onToken(result.Answer); err != nil {
+				return nil, err
+			}
+		}
+		return result, nil
+	}
+
+	// 步骤3：生成答案
+	promptTmpl, err := service.LoadPromptTemplate(cfg.PromptPath)
+	if err != nil {
+		return nil, fmt.Errorf("加载答案Prompt模板失败: %w", err)
+	}
+	prompt := strings.NewReplacer("{context}", contextText, "{question}", opts.Search.Query).Replace(promptTmpl)
+	g.Log().Debugf(ctx, "生成答案: 上下文 %d 条，约 %d tokens", len(contexts), service.EstimateTokens(contextText))
+
+	llm := service.GetLLMClient()
+	generateStart := time.Now()
+	if onToken != nil {
+		result.Answer, err = llm.GenerateStream(ctx, prompt, onToken)
+	} else {
+		result.Answer, err = llm.Generate(ctx, prompt)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("生成答案失败: %w", err)
+	}
+	result.Answer = strings.TrimSpace(result.Answer)
+	result.Timings = append(result.Timings, model.NewStageTiming("generate", generateStart))
+
+	// 步骤4：解析答案中的引用，忽略不存在的编号
+	result.Citations = parseCitations(result.Answer, contexts)
+	return result, nil
+}
+
+// buildContext 将检索结果编号后拼接为上下文，总长度不超过预算
+// 第一条超出预算时截断放入，之后的条目放不下时停止
+func buildContext(items []model.SearchResult, budget int) (string, []model.AnswerContext) {
+	var b strings.Builder
+	var contexts []model.AnswerContext
+	remaining := budget
+	for _, item := range items {
+		index := len(contexts) + 1
+		header := fmt.Sprintf("[%d] 知识库: %s\n", index, item.RepoName)
+		if item.Summary != "" {
+			header += "摘要: " + item.Summary + "\n"
+		}
+		body := "内容: " + item.Content + "\n\n"
+
+		tokens := service.EstimateTokens(header) + service.EstimateTokens(body)
+		if tokens > remaining {
+			bodyBudget := remaining - service.EstimateTokens(header)
+			if len(contexts) > 0 || bodyBudget < minChunkTokens {
+				break
+			}
+			suffix := "…\n\n"
+			body = truncateTokens(body, bodyBudget-service.EstimateTokens(suffix)) + suffix
+			tokens = service.EstimateTokens(header) + service.EstimateTokens(body)
+		}
+
+		b.WriteString(header)
+		b.WriteString(body)
+		remaining -= tokens
+		contexts = append(contexts, model.AnswerContext{
+			Index:    index,
+			ID:       item.ID,
+			RepoName: item.RepoName,
+			Summary:  item.Summary,
+			Score:    item.Score,
+			Tokens:   tokens,
+		})
+	}
+	return b.String(), contexts
+}
+
+// truncateTokens 截取文本开头不超过 maxTokens 的部分
+func truncateTokens(text string, maxTokens int) string {
+	runes := []rune(text)
+	lo, hi := 0, len(runes)
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if service.EstimateTokens(string(runes[:mid])) <= maxTokens {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return string(runes[:lo])
+}
+
+// parseCitations 按首次出现的顺序提取答案中的引用编号，映射为知识条目
+func parseCitations(answer string, contexts []model.AnswerContext) []model.Citation {
+	seen := make(map[int]bool)
+	var citations []model.Citation
+	for _, m := range citationPattern.FindAllStringSubmatch(answer, -1) {
+		for _, part := range strings.FieldsFunc(m[1], func(r rune) bool {
+			return r == ',' || r == '，' || r == '、' || r == ' '
+		}) {
+			index, err := strconv.Atoi(part)
+			if err != nil || index < 1 || index > len(contexts) || seen[index] {
+				continue
+			}
+			seen[index] = true
+			c := contexts[index-1]
+			citations = append(citations, model.Citation{Index: index, ID: c.ID, RepoName: c.RepoName})
+		}
+	}
+	return citations
+}
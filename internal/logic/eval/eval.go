@@ -0,0 +1,388 @@
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"knowledge-system-api/internal/consts"
+	"knowledge-system-api/internal/dao"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/model/do"
+	"knowledge-system-api/internal/model/entity"
+	"knowledge-system-api/internal/service"
+)
+
+// defaultK 未指定时评测前10条结果
+const defaultK = 10
+
+// Eval 离线检索评测服务实现，评测集保存在 eval_set/eval_query 表
+type Eval struct{}
+
+// New 创建离线检索评测服务
+func New() *Eval {
+	return &Eval{}
+}
+
+// CreateSet 创建评测集，返回评测集ID
+func (s *Eval) CreateSet(ctx context.Context, repoName, name, description string, queries []model.EvalQuery) (uint64, error) {
+	repo, err := service.Repo().Get(ctx, repoName)
+	if err != nil {
+		return 0, err
+	}
+	if repo == nil {
+		return 0, gerror.NewCodef(gcode.CodeNotFound, "知识库不存在: %s", repoName)
+	}
+	if err := validateQueries(queries); err != nil {
+		return 0, err
+	}
+
+	count, err := dao.EvalSet.Ctx(ctx).Where(do.EvalSet{RepoName: repoName, Name: name}).Count()
+	if err != nil {
+		return 0, err
+	}
+	if count > 0 {
+		return 0, gerror.NewCodef(gcode.CodeInvalidParameter, "评测集已存在: %s/%s", repoName, name)
+	}
+
+	var setID uint64
+	err = dao.EvalSet.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		id, err := dao.EvalSet.Ctx(ctx).Data(do.EvalSet{
+			RepoName:    repoName,
+			Name:        name,
+			Description: description,
+		}).InsertAndGetId()
+		if err != nil {
+			return err
+		}
+		setID = uint64(id)
+		return insertQueries(ctx, setID, queries)
+	})
+	if err != nil {
+		return 0, gerror.Wrapf(err, "创建评测集失败: %s/%s", repoName, name)
+	}
+	g.Log().Infof(ctx, "评测集已创建: %s/%s (id=%d, %d 条查询)", repoName, name, setID, len(queries))
+	return setID, nil
+}
+
+// ListSets 查询评测集列表，repoName 为空时返回全部知识库的评测集
+func (s *Eval) ListSets(ctx context.Context, repoName string) ([]model.EvalSet, error) {
+	m := dao.EvalSet.Ctx(ctx)
+	if repoName != "" {
+		m = m.Where(do.EvalSet{RepoName: repoName})
+	}
+	var sets []entity.EvalSet
+	if err := m.OrderAsc(dao.EvalSet.Columns().Id).Scan(&sets); err != nil {
+		return nil, err
+	}
+	if len(sets) == 0 {
+		return []model.EvalSet{}, nil
+	}
+
+	ids := make([]uint64, len(sets))
+	for i, set := range sets {
+		ids[i] = set.Id
+	}
+	var counts []struct {
+		SetId uint64
+		Count int
+	}
+	err := dao.EvalQuery.Ctx(ctx).
+		Fields("set_id, COUNT(*) AS count").
+		WhereIn(dao.EvalQuery.Columns().SetId, ids).
+		Group(dao.EvalQuery.Columns().SetId).
+		Scan(&counts)
+	if err != nil {
+		return nil, err
+	}
+	countMap := make(map[uint64]int, len(counts))
+	for _, c := range counts {
+		countMap[c.SetId] = c.Count
+	}
+
+	result := make([]model.EvalSet, len(sets))
+	for i := range sets {
+		result[i] = toEvalSet(&sets[i], countMap[sets[i].Id])
+	}
+	return result, nil
+}
+
+// GetSet 获取评测集及其全部查询，不存在时返回 nil
+func (s *Eval) GetSet(ctx context.Context, id uint64) (*model.EvalSet, []model.EvalQuery, error) {
+	var set entity.EvalSet
+	if err := dao.EvalSet.Ctx(ctx).Where(do.EvalSet{Id: id}).Scan(&set); err != nil {
+		return nil, nil, err
+	}
+	if set.Id == 0 {
+		return nil, nil, nil
+	}
+
+	var rows []entity.EvalQuery
+	err := dao.EvalQuery.Ctx(ctx).
+		Where(do.EvalQuery{SetId: id}).
+		OrderAsc(dao.EvalQuery.Columns().Id).
+		Scan(&rows)
+	if err != nil {
+		return nil, nil, err
+	}
+	queries := make([]model.EvalQuery, len(rows))
+	for i, row := range rows {
+		queries[i] = model.EvalQuery{ID: row.Id, Query: row.Query}
+		if err := json.Unmarshal([]byte(row.Judgments), &queries[i].Judgments); err != nil {
+			return nil, nil, gerror.Wrapf(err, "解析评测查询 %d 的相关性标注失败", row.Id)
+		}
+	}
+
+	info := toEvalSet(&set, len(queries))
+	return &info, queries, nil
+}
+
+// DeleteSet 删除评测集及其全部查询（外键级联删除）
+func (s *Eval) DeleteSet(ctx context.Context, id uint64) error {
+	result, err := dao.EvalSet.Ctx(ctx).Where(do.EvalSet{Id: id}).Delete()
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return gerror.NewCodef(gcode.CodeNotFound, "评测集不存在: %d", id)
+	}
+	return nil
+}
+
+// AddQueries 向评测集追加查询，返回追加的数量
+func (s *Eval) AddQueries(ctx context.Context, setID uint64, queries []model.EvalQuery) (int, error) {
+	count, err := dao.EvalSet.Ctx(ctx).Where(do.EvalSet{Id: setID}).Count()
+	if err != nil {
+		return 0, err
+	}
+	if count == 0 {
+		return 0, gerror.NewCodef(gcode.CodeNotFound, "评测集不存在: %d", setID)
+	}
+	if err := validateQueries(queries); err != nil {
+		return 0, err
+	}
+
+	err = dao.EvalSet.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		if err := insertQueries(ctx, setID, queries); err != nil {
+			return err
+		}
+		_, err := dao.EvalSet.Ctx(ctx).
+			Data(do.EvalSet{UpdatedAt: gtime.Now()}).
+			Where(do.EvalSet{Id: setID}).
+			Update()
+		return err
+	})
+	if err != nil {
+		return 0, gerror.Wrapf(err, "追加评测查询失败: %d", setID)
+	}
+	return len(queries), nil
+}
+
+// Run 使用指定检索配置逐条执行评测集中的查询，计算 recall@k、MRR、nDCG@k 与检索耗时
+// 查询串行执行，避免并发检索相互影响耗时；单条查询失败时记录原因，不计入指标
+func (s *Eval) Run(ctx context.Context, setID uint64, cfg model.EvalConfig, k int) (*model.EvalReport, error) {
+	set, queries, err := s.loadSet(ctx, setID)
+	if err != nil {
+		return nil, err
+	}
+	return s.run(ctx, set, queries, cfg, k), nil
+}
+
+// Compare 在同一评测集上依次评测两个检索配置并对比
+func (s *Eval) Compare(ctx context.Context, setID uint64, baseline, candidate model.EvalConfig, k int) (*model.EvalComparison, error) {
+	set, queries, err := s.loadSet(ctx, setID)
+	if err != nil {
+		return nil, err
+	}
+
+	if baseline.Name == "" {
+		baseline.Name = "baseline"
+	}
+	if candidate.Name == "" {
+		candidate.Name = "candidate"
+	}
+	return compareReports(s.run(ctx, set, queries, baseline, k), s.run(ctx, set, queries, candidate, k)), nil
+}
+
+// loadSet 加载待评测的评测集，不存在或没有查询时返回错误
+func (s *Eval) loadSet(ctx context.Context, setID uint64) (*model.EvalSet, []model.EvalQuery, error) {
+	set, queries, err := s.GetSet(ctx, setID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if set == nil {
+		return nil, nil, gerror.NewCodef(gcode.CodeNotFound, "评测集不存在: %d", setID)
+	}
+	if len(queries) == 0 {
+		return nil, nil, gerror.NewCodef(gcode.CodeInvalidParameter, "评测集没有查询: %d", setID)
+	}
+	return set, queries, nil
+}
+
+// run 执行评测
+func (s *Eval) run(ctx context.Context, set *model.EvalSet, queries []model.EvalQuery, cfg model.EvalConfig, k int) *model.EvalReport {
+	if k <= 0 {
+		k = defaultK
+	}
+	if cfg.Mode == "" {
+		cfg.Mode = consts.SearchModeHybrid
+	}
+
+	report := &model.EvalReport{
+		SetID:    set.ID,
+		SetName:  set.Name,
+		RepoName: set.RepoName,
+		Config:   cfg,
+		K:        k,
+		Queries:  len(queries),
+		Details:  make([]model.EvalQueryResult, len(queries)),
+	}
+	var latencies []float64
+	for i, q := range queries {
+		detail := &report.Details[i]
+		detail.QueryID, detail.Query = q.ID, q.Query
+
+		opts := &model.SearchOptions{
+			Query:              q.Query,
+			RepoName:           set.RepoName,
+			Limit:              uint64(k),
+			Fusion:             cfg.Fusion,
+			Alpha:              cfg.Alpha,
+			Beta:               cfg.Beta,
+			Rerank:             cfg.Rerank,
+			Strategy:           cfg.Strategy,
+			LabelThreshold:     cfg.LabelThreshold,
+			PrefetchMultiplier: cfg.PrefetchMultiplier,
+		}
+		start := time.Now()
+		output, err := service.KnowledgeService().SearchKnowledge(ctx, cfg.Mode, opts)
+		detail.LatencyMs = model.NewStageTiming("", start).Ms
+		if err != nil {
+			g.Log().Warningf(ctx, "评测查询 %d 检索失败: %v", q.ID, err)
+			detail.Error = err.Error()
+			report.Failed++
+			continue
+		}
+
+		detail.Retrieved = make([]string, 0, len(output.Items))
+		for _, item := range output.Items {
+			detail.Retrieved = append(detail.Retrieved, item.ID)
+		}
+		grades := make(map[string]int, len(q.Judgments))
+		for _, j := range q.Judgments {
+			grades[j.ID] = j.Grade
+		}
+		detail.Recall = recallAtK(detail.Retrieved, grades, k)
+		detail.RR = reciprocalRank(detail.Retrieved, grades, k)
+		detail.NDCG = ndcgAtK(detail.Retrieved, grades, k)
+
+		report.Recall += detail.Recall
+		report.MRR += detail.RR
+		report.NDCG += detail.NDCG
+		latencies = append(latencies, detail.LatencyMs)
+	}
+
+	if succeeded := len(latencies); succeeded > 0 {
+		report.Recall /= float64(succeeded)
+		report.MRR /= float64(succeeded)
+		report.NDCG /= float64(succeeded)
+		report.Latency = latencyStats(latencies)
+	}
+	g.Log().Infof(ctx, "评测完成: 评测集 %d, 配置 %q, recall@%d=%.4f, MRR=%.4f, nDCG@%d=%.4f, 失败 %d",
+		set.ID, cfg.Name, k, report.Recall, report.MRR, k, report.NDCG, report.Failed)
+	return report
+}
+
+// compareReports 计算两个评测结果的指标差值，列出 nDCG 有变化的查询
+func compareReports(baseline, candidate *model.EvalReport) *model.EvalComparison {
+	comparison := &model.EvalComparison{
+		Baseline:  baseline,
+		Candidate: candidate,
+		Delta: model.EvalDelta{
+			Recall:     candidate.Recall - baseline.Recall,
+			MRR:        candidate.MRR - baseline.MRR,
+			NDCG:       candidate.NDCG - baseline.NDCG,
+			LatencyP50: candidate.Latency.P50 - baseline.Latency.P50,
+			LatencyP95: candidate.Latency.P95 - baseline.Latency.P95,
+		},
+		Changed: []model.EvalQueryDelta{},
+	}
+	// 两次评测的查询顺序一致，检索失败的查询无法比较
+	for i, b := range baseline.Details {
+		c := candidate.Details[i]
+		if b.Error != "" || c.Error != "" || b.NDCG == c.NDCG {
+			continue
+		}
+		comparison.Changed = append(comparison.Changed, model.EvalQueryDelta{
+			QueryID:   b.QueryID,
+			Query:     b.Query,
+			Baseline:  b.NDCG,
+			Candidate: c.NDCG,
+			Delta:     c.NDCG - b.NDCG,
+		})
+	}
+	sort.SliceStable(comparison.Changed, func(i, j int) bool {
+		return comparison.Changed[i].Delta < comparison.Changed[j].Delta
+	})
+	return comparison
+}
+
+// validateQueries 校验评测查询：查询内容不能为空，至少有一条相关度大于0的标注
+func validateQueries(queries []model.EvalQuery) error {
+	for i, q := range queries {
+		if q.Query == "" {
+			return gerror.NewCodef(gcode.CodeInvalidParameter, "第 %d 条评测查询的内容为空", i+1)
+		}
+		relevant := false
+		for _, j := range q.Judgments {
+			if j.ID == "" {
+				return gerror.NewCodef(gcode.CodeInvalidParameter, "评测查询 %q 的标注缺少知识条目ID", q.Query)
+			}
+			if j.Grade < 0 {
+				return gerror.NewCodef(gcode.CodeInvalidParameter, "评测查询 %q 的相关度等级不能为负数", q.Query)
+			}
+			relevant = relevant || j.Grade > 0
+		}
+		if !relevant {
+			return gerror.NewCodef(gcode.CodeInvalidParameter, "评测查询 %q 没有相关度大于0的标注", q.Query)
+		}
+	}
+	return nil
+}
+
+// insertQueries 批量写入评测查询
+func insertQueries(ctx context.Context, setID uint64, queries []model.EvalQuery) error {
+	if len(queries) == 0 {
+		return nil
+	}
+	rows := make([]do.EvalQuery, len(queries))
+	for i, q := range queries {
+		judgments, err := json.Marshal(q.Judgments)
+		if err != nil {
+			return err
+		}
+		rows[i] = do.EvalQuery{SetId: setID, Query: q.Query, Judgments: string(judgments)}
+	}
+	_, err := dao.EvalQuery.Ctx(ctx).Data(rows).Insert()
+	return err
+}
+
+// toEvalSet 转换评测集
+func toEvalSet(set *entity.EvalSet, queryCount int) model.EvalSet {
+	return model.EvalSet{
+		ID:          set.Id,
+		RepoName:    set.RepoName,
+		Name:        set.Name,
+		Description: set.Description,
+		QueryCount:  queryCount,
+		CreatedAt:   set.CreatedAt,
+		UpdatedAt:   set.UpdatedAt,
+	}
+}
@@ -0,0 +1,267 @@
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"knowledge-system-api/internal/dao"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/model/entity"
+	"knowledge-system-api/internal/service"
+)
+
+// contentBatchSize 导出训练样本时每批查询的知识数量
+const contentBatchSize = 500
+
+// feedbackRow 参与生成标注的反馈
+type feedbackRow struct {
+	Id                   uint64
+	SessionId            string
+	UserQuery            string
+	RewrittenQuery       string
+	RetrievedKnowledgeId string
+	Action               string
+	RepoName             string
+}
+
+// feedbackGroup 归一化后相同的一组查询
+type feedbackGroup struct {
+	repoName string
+	forms    map[string]int // 原始写法 -> 出现次数
+	first    []string       // 原始写法首次出现的顺序，出现次数相同时取先出现的
+	// votes 知识ID -> 会话ID -> 该会话最后一次的操作，同一会话重复反馈只计一次，匿名反馈各自计数
+	votes map[string]map[string]string
+}
+
+// BuildFeedbackDataset 将反馈按归一化后的查询去重汇总为分级的相关性标注
+// 关联到会话轮次的反馈使用改写后的独立查询；同一会话对同一查询与知识的多次反馈以最后一次为准
+func (s *Eval) BuildFeedbackDataset(ctx context.Context, opts model.FeedbackDatasetOptions) (*model.FeedbackDataset, error) {
+	m := dao.Feedback.Ctx(ctx).As("f").
+		InnerJoin("knowledge k", "k.id = f.retrieved_knowledge_id").
+		LeftJoin("session_turn t", "t.id = f.turn_id").
+		Fields("f.id, f.session_id, f.user_query, t.rewritten_query, f.retrieved_knowledge_id, f.action, k.repo_name")
+	if opts.RepoName != "" {
+		m = m.Where("k.repo_name", opts.RepoName)
+	}
+	if opts.StartTime != nil {
+		m = m.WhereGTE("f.timestamp", opts.StartTime)
+	}
+	if opts.EndTime != nil {
+		m = m.WhereLTE("f.timestamp", opts.EndTime)
+	}
+	var rows []feedbackRow
+	if err := m.OrderAsc("f.id").Scan(&rows); err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string]*feedbackGroup)
+	var keys []string
+	for _, row := range rows {
+		query := strings.TrimSpace(row.UserQuery)
+		if rewritten := strings.TrimSpace(row.RewrittenQuery); rewritten != "" {
+			query = rewritten
+		}
+		normalized := service.NormalizeQuery(query)
+		if normalized == "" {
+			continue
+		}
+
+		key := row.RepoName + "\x00" + normalized
+		group, ok := groups[key]
+		if !ok {
+			group = &feedbackGroup{
+				repoName: row.RepoName,
+				forms:    make(map[string]int),
+				votes:    make(map[string]map[string]string),
+			}
+			groups[key] = group
+			keys = append(keys, key)
+		}
+		if group.forms[query] == 0 {
+			group.first = append(group.first, query)
+		}
+		group.forms[query]++
+		if group.votes[row.RetrievedKnowledgeId] == nil {
+			group.votes[row.RetrievedKnowledgeId] = make(map[string]string)
+		}
+		voter := row.SessionId
+		if voter == "" {
+			voter = fmt.Sprintf("\x00%d", row.Id)
+		}
+		group.votes[row.RetrievedKnowledgeId][voter] = row.Action
+	}
+
+	cfg := service.LoadFeedbackDatasetConfig(ctx)
+	dataset := &model.FeedbackDataset{Feedbacks: len(rows), Queries: []model.FeedbackQuery{}}
+	for _, key := range keys {
+		query, ok := groups[key].toQuery(cfg)
+		if !ok {
+			dataset.Skipped++
+			continue
+		}
+		dataset.Queries = append(dataset.Queries, query)
+	}
+	sort.SliceStable(dataset.Queries, func(i, j int) bool {
+		a, b := dataset.Queries[i], dataset.Queries[j]
+		if a.RepoName != b.RepoName {
+			return a.RepoName < b.RepoName
+		}
+		return a.Feedbacks > b.Feedbacks
+	})
+	g.Log().Infof(ctx, "由 %d 条反馈生成 %d 条评测查询，丢弃 %d 条没有正向标注的查询",
+		dataset.Feedbacks, len(dataset.Queries), dataset.Skipped)
+	return dataset, nil
+}
+
+// toQuery 按净点赞数为每条知识定级，没有正向标注时返回 false
+func (group *feedbackGroup) toQuery(cfg *service.FeedbackDatasetConfig) (model.FeedbackQuery, bool) {
+	query := model.FeedbackQuery{RepoName: group.repoName, Variants: len(group.forms)}
+	for _, form := range group.first {
+		if group.forms[form] > group.forms[query.Query] {
+			query.Query = form
+		}
+	}
+
+	positive := false
+	for id, sessions := range group.votes {
+		likes, dislikes := 0, 0
+		for _, action := range sessions {
+			if action == "like" {
+				likes++
+			} else {
+				dislikes++
+			}
+		}
+		query.Feedbacks += likes + dislikes
+
+		net := float64(likes) - float64(dislikes)*cfg.DislikeWeight
+		grade := 0
+		for _, threshold := range cfg.GradeLikes {
+			if net >= float64(threshold) {
+				grade++
+			}
+		}
+		switch {
+		case grade > 0:
+			positive = true
+		case dislikes > 0 && net <= 0:
+			// 以点踩为主，作为负例
+		default:
+			continue
+		}
+		query.Judgments = append(query.Judgments, model.EvalJudgment{ID: id, Grade: min(grade, 3)})
+	}
+	sort.Slice(query.Judgments, func(i, j int) bool {
+		a, b := query.Judgments[i], query.Judgments[j]
+		if a.Grade != b.Grade {
+			return a.Grade > b.Grade
+		}
+		return a.ID < b.ID
+	})
+	return query, positive
+}
+
+// CreateSetsFromFeedback 按知识库将反馈生成的标注保存为评测集，name 为空时使用 feedback-<时间>
+func (s *Eval) CreateSetsFromFeedback(ctx context.Context, dataset *model.FeedbackDataset, name string) ([]model.EvalSet, error) {
+	if name == "" {
+		name = "feedback-" + gtime.Now().Format("YmdHis")
+	}
+
+	byRepo := make(map[string][]model.EvalQuery)
+	var repos []string
+	for _, q := range dataset.Queries {
+		if _, ok := byRepo[q.RepoName]; !ok {
+			repos = append(repos, q.RepoName)
+		}
+		byRepo[q.RepoName] = append(byRepo[q.RepoName], model.EvalQuery{Query: q.Query, Judgments: q.Judgments})
+	}
+
+	sets := make([]model.EvalSet, 0, len(repos))
+	for _, repoName := range repos {
+		queries := byRepo[repoName]
+		description := fmt.Sprintf("由用户反馈生成，%d 条查询", len(queries))
+		id, err := s.CreateSet(ctx, repoName, name, description, queries)
+		if err != nil {
+			return sets, err
+		}
+		sets = append(sets, model.EvalSet{
+			ID:          id,
+			RepoName:    repoName,
+			Name:        name,
+			Description: description,
+			QueryCount:  len(queries),
+		})
+	}
+	return sets, nil
+}
+
+// trainingPair JSONL 训练样本，与常见的向量模型与重排序模型微调格式一致
+type trainingPair struct {
+	Query string   `json:"query"`
+	Pos   []string `json:"pos"`
+	Neg   []string `json:"neg"`
+}
+
+// ExportTrainingPairs 将反馈生成的标注以 JSONL 训练样本写出，pos 按相关度从高到低排列，neg 为点踩为主的知识
+// 已删除的知识会被跳过，没有正例的查询不写出
+func (s *Eval) ExportTrainingPairs(ctx context.Context, dataset *model.FeedbackDataset, w io.Writer) (int, error) {
+	idSet := make(map[string]struct{})
+	for _, q := range dataset.Queries {
+		for _, j := range q.Judgments {
+			idSet[j.ID] = struct{}{}
+		}
+	}
+	ids := make([]string, 0, len(idSet))
+	for id := range idSet {
+		ids = append(ids, id)
+	}
+
+	contents := make(map[string]string, len(ids))
+	for start := 0; start < len(ids); start += contentBatchSize {
+		end := min(start+contentBatchSize, len(ids))
+		var items []entity.Knowledge
+		err := dao.Knowledge.Ctx(ctx).
+			Fields(dao.Knowledge.Columns().Id, dao.Knowledge.Columns().Content).
+			WhereIn(dao.Knowledge.Columns().Id, ids[start:end]).
+			Scan(&items)
+		if err != nil {
+			return 0, err
+		}
+		for _, item := range items {
+			contents[item.Id] = item.Content
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	written := 0
+	for _, q := range dataset.Queries {
+		pair := trainingPair{Query: q.Query, Pos: []string{}, Neg: []string{}}
+		for _, j := range q.Judgments {
+			content, ok := contents[j.ID]
+			if !ok {
+				continue
+			}
+			if j.Grade > 0 {
+				pair.Pos = append(pair.Pos, content)
+			} else {
+				pair.Neg = append(pair.Neg, content)
+			}
+		}
+		if len(pair.Pos) == 0 {
+			continue
+		}
+		if err := encoder.Encode(pair); err != nil {
+			return written, err
+		}
+		written++
+	}
+	return written, nil
+}
@@ -0,0 +1,103 @@
+package eval
+
+import (
+	"math"
+	"sort"
+
+	"knowledge-system-api/internal/model"
+)
+
+// recallAtK 前 k 条结果中相关知识（相关度大于0）占全部相关知识的比例
+func recallAtK(retrieved []string, grades map[string]int, k int) float64 {
+	relevant := 0
+	for _, grade := range grades {
+		if grade > 0 {
+			relevant++
+		}
+	}
+	if relevant == 0 {
+		return 0
+	}
+	hits := 0
+	for _, id := range topK(retrieved, k) {
+		if grades[id] > 0 {
+			hits++
+		}
+	}
+	return float64(hits) / float64(relevant)
+}
+
+// reciprocalRank 前 k 条结果中第一个相关结果排名的倒数，没有相关结果时为0
+func reciprocalRank(retrieved []string, grades map[string]int, k int) float64 {
+	for i, id := range topK(retrieved, k) {
+		if grades[id] > 0 {
+			return 1 / float64(i+1)
+		}
+	}
+	return 0
+}
+
+// ndcgAtK 归一化折损累计增益，增益为 2^grade-1，按 log2(排名+1) 折损，以标注的理想排序归一化
+func ndcgAtK(retrieved []string, grades map[string]int, k int) float64 {
+	var dcg float64
+	for i, id := range topK(retrieved, k) {
+		dcg += gain(grades[id]) / math.Log2(float64(i+2))
+	}
+
+	ideal := make([]int, 0, len(grades))
+	for _, grade := range grades {
+		if grade > 0 {
+			ideal = append(ideal, grade)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(ideal)))
+	var idcg float64
+	for i, grade := range ideal {
+		if i >= k {
+			break
+		}
+		idcg += gain(grade) / math.Log2(float64(i+2))
+	}
+	if idcg == 0 {
+		return 0
+	}
+	return dcg / idcg
+}
+
+// gain 相关度等级对应的增益
+func gain(grade int) float64 {
+	if grade <= 0 {
+		return 0
+	}
+	return math.Pow(2, float64(grade)) - 1
+}
+
+// topK 截取前 k 条结果
+func topK(retrieved []string, k int) []string {
+	if len(retrieved) > k {
+		return retrieved[:k]
+	}
+	return retrieved
+}
+
+// latencyStats 计算耗时的平均值、中位数、P95 与最大值，百分位取最近排名
+func latencyStats(latencies []float64) model.EvalLatency {
+	sorted := append([]float64(nil), latencies...)
+	sort.Float64s(sorted)
+	var sum float64
+	for _, l := range sorted {
+		sum += l
+	}
+	return model.EvalLatency{
+		Mean: sum / float64(len(sorted)),
+		P50:  percentile(sorted, 50),
+		P95:  percentile(sorted, 95),
+		Max:  sorted[len(sorted)-1],
+	}
+}
+
+// percentile 已排序数据的第 p 百分位（最近排名法）
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
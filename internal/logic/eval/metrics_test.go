@@ -0,0 +1,66 @@
+package eval
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNdcgAtK(t *testing.T) {
+	grades := map[string]int{"a": 3, "b": 2, "c": 1, "z": 0}
+	tests := []struct {
+		name      string
+		retrieved []string
+		grades    map[string]int
+		k         int
+		want      float64
+	}{
+		{name: "理想排序", retrieved: []string{"a", "b", "c"}, grades: grades, k: 3, want: 1},
+		{
+			name: "等级倒序", retrieved: []string{"c", "b", "a"}, grades: grades, k: 3,
+			want: (1 + 3/math.Log2(3) + 7.0/2) / (7 + 3/math.Log2(3) + 1.0/2),
+		},
+		{
+			name: "不相关结果占位", retrieved: []string{"b", "x", "a"}, grades: grades, k: 3,
+			want: (3 + 7.0/2) / (7 + 3/math.Log2(3) + 1.0/2),
+		},
+		{
+			name: "只计算前k条且理想排序同样截断", retrieved: []string{"b", "a", "c"}, grades: grades, k: 2,
+			want: (3 + 7/math.Log2(3)) / (7 + 3/math.Log2(3)),
+		},
+		{name: "相关度为0的标注不计增益", retrieved: []string{"z"}, grades: grades, k: 3, want: 0},
+		{name: "相关结果在前k条之外", retrieved: []string{"x", "a"}, grades: grades, k: 1, want: 0},
+		{name: "没有相关标注", retrieved: []string{"a"}, grades: map[string]int{"a": 0}, k: 3, want: 0},
+		{name: "没有检索结果", retrieved: nil, grades: grades, k: 3, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ndcgAtK(tt.retrieved, tt.grades, tt.k); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("ndcgAtK(%v, k=%d) = %v, want %v", tt.retrieved, tt.k, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		name   string
+		sorted []float64
+		p      float64
+		want   float64
+	}{
+		{name: "中位数", sorted: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, p: 50, want: 5},
+		{name: "P95取最近排名", sorted: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, p: 95, want: 10},
+		{name: "P100为最大值", sorted: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, p: 100, want: 10},
+		{name: "P0取第一个", sorted: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, p: 0, want: 1},
+		{name: "排名向上取整", sorted: []float64{1, 2, 3, 4}, p: 51, want: 3},
+		{name: "恰好整除", sorted: []float64{1, 2, 3, 4}, p: 50, want: 2},
+		{name: "单个数据", sorted: []float64{7}, p: 95, want: 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := percentile(tt.sorted, tt.p); got != tt.want {
+				t.Errorf("percentile(%v, %v) = %v, want %v", tt.sorted, tt.p, got, tt.want)
+			}
+		})
+	}
+}
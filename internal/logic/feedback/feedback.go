@@ -2,8 +2,8 @@ package feedback
 
 import (
 	"context"
-	"encoding/json"
 
+	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/os/gtime"
@@ -14,15 +14,6 @@ import (
 	"knowledge-system-api/internal/service"
 )
 
-// 标签结构体
-type Label struct {
-	Tag   string  `json:"tag"`
-	Score float64 `json:"score"`
-}
-
-// 默认测试会话ID
-const DefaultTestSessionID = "90c91010-d24b-4056-9c46-89aafe0ed4cb"
-
 // Feedback 是反馈服务实现
 type Feedback struct{}
 
@@ -31,26 +22,34 @@ func New() *Feedback {
 	return &Feedback{}
 }
 
-// Init 初始化
-func (s *Feedback) Init(ctx context.Context) {
-	service.RegisterFeedback(s)
-
-	// 注册定时任务，按照GoFrame规范获取配置
-	// cronPattern := g.Cfg().MustGet(ctx, "feedback.update.cron", "0 3 * * *").String()
-	// _, err := g.Cron().Add(ctx, cronPattern, func(ctx context.Context) {
-	// 	s.ProcessFeedbacks(ctx)
-	// })
-
-	// if err != nil {
-	// 	g.Log().Error(ctx, "注册反馈处理定时任务失败:", err)
-	// }
-}
-
 // Add 添加反馈
-func (s *Feedback) Add(ctx context.Context, sessionID, userQuery, knowledgeID, action string) (int64, error) {
-	// 如果没有提供会话ID，使用默认测试ID
-	if sessionID == "" {
-		sessionID = DefaultTestSessionID
+// 关联检索ID时该次检索必须展示过这条知识，未传的会话ID与查询取自展示记录；
+// 最终没有会话ID时记为匿名反馈，feedback.anonymous 为 false 时拒绝
+func (s *Feedback) Add(ctx context.Context, searchID, sessionID, userQuery, knowledgeID, action string) (int64, error) {
+	if sessionID != "" {
+		if err := service.Session().Validate(ctx, sessionID); err != nil {
+			return 0, err
+		}
+	}
+	if searchID != "" {
+		impression, err := findImpression(ctx, searchID, knowledgeID)
+		if err != nil {
+			return 0, err
+		}
+		if sessionID == "" {
+			sessionID = impression.SessionId
+		}
+		if userQuery == "" {
+			userQuery = impression.Query
+		}
+	}
+	if userQuery == "" {
+		return 0, gerror.NewCode(gcode.CodeInvalidParameter, "用户查询不能为空，未关联检索ID时需要传入查询")
+	}
+
+	anonymous, err := checkAnonymous(ctx, sessionID)
+	if err != nil {
+		return 0, err
 	}
 
 	// 检查知识ID是否存在
@@ -62,14 +61,29 @@ func (s *Feedback) Add(ctx context.Context, sessionID, userQuery, knowledgeID, a
 		return 0, gerror.New("知识ID不存在")
 	}
 
-	// 保存反馈
-	id, err := dao.Feedback.Ctx(ctx).Data(do.Feedback{
+	// 关联返回该知识的会话轮次，没有时为 NULL
+	data := do.Feedback{
 		SessionId:            sessionID,
 		UserQuery:            userQuery,
 		RetrievedKnowledgeId: knowledgeID,
 		Action:               action,
+		Anonymous:            anonymous,
 		Timestamp:            gtime.Now(),
-	}).InsertAndGetId()
+	}
+	if searchID != "" {
+		data.SearchId = searchID
+	}
+	if !anonymous {
+		turnID, err := service.Session().FindTurn(ctx, sessionID, knowledgeID)
+		if err != nil {
+			g.Log().Warningf(ctx, "查找反馈关联的会话轮次失败: %v", err)
+		} else if turnID > 0 {
+			data.TurnId = turnID
+		}
+	}
+
+	// 保存反馈
+	id, err := dao.Feedback.Ctx(ctx).Data(data).InsertAndGetId()
 
 	return id, err
 }
@@ -121,6 +135,9 @@ func (s *Feedback) List(ctx context.Context, sessionID, knowledgeID, action, sta
 			UserQuery:   gconv.String(item["user_query"]),
 			KnowledgeID: gconv.String(item["retrieved_knowledge_id"]),
 			Action:      gconv.String(item["action"]),
+			TurnID:      gconv.Uint64(item["turn_id"]),
+			SearchID:    gconv.String(item["search_id"]),
+			Anonymous:   gconv.Bool(item["anonymous"]),
 			Timestamp:   gconv.Time(item["timestamp"]),
 		}
 	}
@@ -128,127 +145,13 @@ func (s *Feedback) List(ctx context.Context, sessionID, knowledgeID, action, sta
 	return result, total, nil
 }
 
-// ProcessFeedbacks 处理反馈数据，更新标签分数
-func (s *Feedback) ProcessFeedbacks(ctx context.Context) error {
-	g.Log().Info(ctx, "开始处理用户反馈，更新知识标签分数")
-
-	// 按照GoFrame规范获取配置
-	likeAdjust := g.Cfg().MustGet(ctx, "feedback.tag_adjustment.like", 0.05).Float64()
-	dislikeAdjust := g.Cfg().MustGet(ctx, "feedback.tag_adjustment.dislike", -0.08).Float64()
-	minScore := g.Cfg().MustGet(ctx, "feedback.tag_adjustment.min_score", 0.1).Float64()
-	maxScore := g.Cfg().MustGet(ctx, "feedback.tag_adjustment.max_score", 10.0).Float64()
-
-	// 查询过去24小时的反馈数据
-	yesterday := gtime.Now().AddDate(0, 0, -1)
-	var feedbacks []struct {
-		KnowledgeID string `json:"retrieved_knowledge_id"`
-		Action      string `json:"action"`
+// checkAnonymous 没有会话ID时为匿名反馈，feedback.anonymous 为 false 时拒绝匿名反馈
+func checkAnonymous(ctx context.Context, sessionID string) (bool, error) {
+	if sessionID != "" {
+		return false, nil
 	}
-
-	err := g.DB().Model("feedback").Ctx(ctx).
-		Fields("retrieved_knowledge_id, action").
-		Where("timestamp >= ?", yesterday).
-		Scan(&feedbacks)
-	if err != nil {
-		return err
+	if !g.Cfg().MustGet(ctx, "feedback.anonymous", true).Bool() {
+		return true, gerror.NewCode(gcode.CodeInvalidParameter, "不接受匿名反馈，请传入通过 POST /session 获取的会话ID")
 	}
-
-	// 按知识ID分组统计反馈
-	feedbackStats := make(map[string]map[string]int)
-	for _, f := range feedbacks {
-		if _, exists := feedbackStats[f.KnowledgeID]; !exists {
-			feedbackStats[f.KnowledgeID] = map[string]int{
-				"like":    0,
-				"dislike": 0,
-			}
-		}
-		feedbackStats[f.KnowledgeID][f.Action]++
-	}
-
-	// 处理每个知识的反馈
-	for knowledgeID, stats := range feedbackStats {
-		// 只有存在"dislike"或大量"like"才进行处理
-		if stats["dislike"] == 0 && stats["like"] < 5 {
-			continue
-		}
-
-		// 查询知识
-		var knowledge struct {
-			ID     string `json:"id"`
-			Labels string `json:"labels"`
-		}
-
-		err := g.DB().Model("knowledge").Ctx(ctx).
-			Fields("id, labels").
-			Where("id", knowledgeID).
-			Scan(&knowledge)
-		if err != nil || knowledge.ID == "" {
-			g.Log().Warningf(ctx, "获取知识失败: %v, ID: %s", err, knowledgeID)
-			continue
-		}
-
-		// 解析标签
-		var labels []Label
-		if err := json.Unmarshal([]byte(knowledge.Labels), &labels); err != nil {
-			g.Log().Warningf(ctx, "解析标签失败: %v, ID: %s", err, knowledgeID)
-			continue
-		}
-
-		if len(labels) == 0 {
-			continue
-		}
-
-		// 计算调整量
-		adjustment := float64(stats["like"])*likeAdjust + float64(stats["dislike"])*dislikeAdjust
-
-		// 记录是否有变化
-		changed := false
-
-		// 调整所有标签的分数
-		for i := range labels {
-			oldScore := labels[i].Score
-
-			// 应用调整
-			newScore := oldScore + adjustment
-
-			// 确保分数在合理范围内
-			if newScore < minScore {
-				newScore = minScore
-			} else if newScore > maxScore {
-				newScore = maxScore
-			}
-
-			// 只有分数有变化才更新
-			if newScore != oldScore {
-				labels[i].Score = newScore
-				changed = true
-			}
-		}
-
-		// 有变化才更新数据库
-		if changed {
-			// 序列化标签
-			labelsJson, err := json.Marshal(labels)
-			if err != nil {
-				g.Log().Warningf(ctx, "序列化标签失败: %v, ID: %s", err, knowledgeID)
-				continue
-			}
-
-			// 更新知识的labels字段
-			_, err = g.DB().Model("knowledge").Ctx(ctx).
-				Data(g.Map{"labels": string(labelsJson)}).
-				Where("id", knowledgeID).
-				Update()
-
-			if err != nil {
-				g.Log().Errorf(ctx, "更新知识标签失败: %v, ID: %s", err, knowledgeID)
-			} else {
-				g.Log().Infof(ctx, "已更新知识标签, ID: %s, 点赞: %d, 点踩: %d, 调整值: %.2f",
-					knowledgeID, stats["like"], stats["dislike"], adjustment)
-			}
-		}
-	}
-
-	g.Log().Info(ctx, "反馈处理完成")
-	return nil
+	return true, nil
 }
@@ -0,0 +1,218 @@
+package feedback
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gcron"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"knowledge-system-api/internal/consts"
+	"knowledge-system-api/internal/dao"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/model/do"
+	"knowledge-system-api/internal/model/entity"
+	"knowledge-system-api/internal/service"
+)
+
+// qualityWatermark 质量分数调整任务的水位线名称
+const qualityWatermark = "quality"
+
+// knowledgeFeedbackStats 单条知识在本批次中的反馈统计
+type knowledgeFeedbackStats struct {
+	KnowledgeId string
+	RepoName    string
+	Likes       uint
+	Dislikes    uint
+}
+
+// StartSchedule 按 feedback.schedule 配置注册定时处理任务，同一进程内不会重叠执行
+func (s *Feedback) StartSchedule(ctx context.Context) error {
+	cfg := service.LoadFeedbackScheduleConfig(ctx)
+	if !cfg.Enabled {
+		g.Log().Info(ctx, "反馈定时处理未启用")
+		return nil
+	}
+	_, err := gcron.AddSingleton(ctx, cfg.Cron, func(ctx context.Context) {
+		if _, err := s.ProcessFeedbacks(ctx, consts.FeedbackRunTriggerCron); err != nil {
+			g.Log().Errorf(ctx, "定时处理反馈失败: %v", err)
+		}
+	}, "feedback_process")
+	if err != nil {
+		return gerror.Wrapf(err, "注册反馈定时处理任务失败: %s", cfg.Cron)
+	}
+	g.Log().Infof(ctx, "反馈定时处理已注册: %s", cfg.Cron)
+	return nil
+}
+
+// ProcessFeedbacks 处理水位线之后的反馈，按点赞/点踩数调整知识的质量分数并推进水位线
+// 质量分数与大模型标签分开保存，检索排序时按衰减后的值提升分数；每次调整都记录在 knowledge_quality_log。
+// 调整与水位线在同一事务中提交：失败时本批次全部回滚，下次从原水位线重新处理，每条反馈只会计入一次；
+// 水位线行加锁，多个实例并发处理时后者等待前者提交后再读取新的水位线。
+// 自增ID按分配顺序而不是提交顺序可见：较小ID的反馈可能在较大ID之后才提交，水位线越过它就会永久漏掉。
+// 因此只处理早于 safety_lag_seconds 的反馈，并在遇到第一条较新的反馈时停止，之后的留给下一次。
+func (s *Feedback) ProcessFeedbacks(ctx context.Context, trigger string) (*model.FeedbackRun, error) {
+	g.Log().Info(ctx, "开始处理用户反馈，更新知识质量分数")
+	cfg := service.LoadFeedbackScheduleConfig(ctx)
+	qualityCfg := service.LoadQualityConfig(ctx)
+	run := &model.FeedbackRun{Trigger: trigger, StartedAt: gtime.Now()}
+	repos := make(map[string]struct{})
+
+	err := dao.FeedbackWatermark.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		watermark, err := lockWatermark(ctx, qualityWatermark)
+		if err != nil {
+			return err
+		}
+		run.FromFeedbackID, run.ToFeedbackID = watermark, watermark
+
+		// 本批次处理水位线之后的前 batch_size 条反馈，遇到晚于安全延迟的反馈时截止
+		var rows []entity.Feedback
+		err = dao.Feedback.Ctx(ctx).
+			Fields(dao.Feedback.Columns().Id, dao.Feedback.Columns().Timestamp).
+			WhereGT(dao.Feedback.Columns().Id, watermark).
+			OrderAsc(dao.Feedback.Columns().Id).
+			Limit(cfg.BatchSize).
+			Scan(&rows)
+		if err != nil {
+			return err
+		}
+		cutoff := gtime.Now().Add(-time.Duration(cfg.SafetyLagSeconds) * time.Second)
+		for _, row := range rows {
+			if row.Timestamp == nil || !row.Timestamp.Before(cutoff) {
+				break
+			}
+			run.Feedbacks++
+			run.ToFeedbackID = row.Id
+		}
+		if run.Feedbacks == 0 {
+			return nil
+		}
+
+		// 按知识统计点赞与点踩数，已删除的知识不再调整
+		var stats []knowledgeFeedbackStats
+		err = dao.Feedback.Ctx(ctx).As("f").
+			InnerJoin("knowledge k", "k.id = f.retrieved_knowledge_id").
+			Fields("f.retrieved_knowledge_id AS knowledge_id, k.repo_name, "+
+				"SUM(f.action = 'like') AS likes, SUM(f.action = 'dislike') AS dislikes").
+			Where("f.id > ? AND f.id <= ?", run.FromFeedbackID, run.ToFeedbackID).
+			Group("f.retrieved_knowledge_id, k.repo_name").
+			Scan(&stats)
+		if err != nil {
+			return err
+		}
+
+		for _, stat := range stats {
+			delta := float64(stat.Likes)*qualityCfg.Like + float64(stat.Dislikes)*qualityCfg.Dislike
+			if delta == 0 {
+				continue
+			}
+			log, err := service.AdjustQuality(ctx, stat.KnowledgeId, consts.QualitySourceFeedback, stat.Likes, stat.Dislikes, delta)
+			if err != nil {
+				return gerror.Wrapf(err, "调整知识质量分数失败: %s", stat.KnowledgeId)
+			}
+			repos[stat.RepoName] = struct{}{}
+			run.Adjusted++
+			g.Log().Debugf(ctx, "已调整知识质量分数, ID: %s, 点赞: %d, 点踩: %d, 分数: %.4f -> %.4f",
+				stat.KnowledgeId, stat.Likes, stat.Dislikes, log.ScoreBefore, log.ScoreAfter)
+		}
+
+		_, err = dao.FeedbackWatermark.Ctx(ctx).
+			Data(do.FeedbackWatermark{FeedbackId: run.ToFeedbackID}).
+			Where(do.FeedbackWatermark{Name: qualityWatermark}).
+			Update()
+		return err
+	})
+
+	run.Status = consts.FeedbackRunSucceeded
+	if err != nil {
+		g.Log().Errorf(ctx, "反馈处理失败，本批次已回滚: %v", err)
+		run.Status = consts.FeedbackRunFailed
+		run.ToFeedbackID = run.FromFeedbackID
+		run.Feedbacks, run.Adjusted = 0, 0
+		run.Error = err.Error()
+	}
+	run.FinishedAt = gtime.Now()
+	if saveErr := saveRun(ctx, run); saveErr != nil {
+		g.Log().Errorf(ctx, "保存反馈处理记录失败: %v", saveErr)
+	}
+	if err != nil {
+		return run, err
+	}
+
+	// 质量分数影响排序，使相关知识库的检索缓存失效
+	for repoName := range repos {
+		service.InvalidateSearchCache(ctx, repoName)
+	}
+	g.Log().Infof(ctx, "反馈处理完成，反馈ID (%d, %d]，共 %d 条，调整 %d 条知识的质量分数",
+		run.FromFeedbackID, run.ToFeedbackID, run.Feedbacks, run.Adjusted)
+	return run, nil
+}
+
+// ListRuns 分页查询反馈处理记录，按时间倒序
+func (s *Feedback) ListRuns(ctx context.Context, page, pageSize int) ([]model.FeedbackRun, int, error) {
+	m := dao.FeedbackRun.Ctx(ctx)
+	total, err := m.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var rows []entity.FeedbackRun
+	if err := m.Page(page, pageSize).OrderDesc(dao.FeedbackRun.Columns().Id).Scan(&rows); err != nil {
+		return nil, 0, err
+	}
+	runs := make([]model.FeedbackRun, len(rows))
+	for i, row := range rows {
+		runs[i] = model.FeedbackRun{
+			ID:             row.Id,
+			Trigger:        row.TriggerType,
+			Status:         row.Status,
+			FromFeedbackID: row.FromFeedbackId,
+			ToFeedbackID:   row.ToFeedbackId,
+			Feedbacks:      row.Feedbacks,
+			Adjusted:       row.Adjusted,
+			Error:          row.Error,
+			StartedAt:      row.StartedAt,
+			FinishedAt:     row.FinishedAt,
+		}
+	}
+	return runs, total, nil
+}
+
+// lockWatermark 读取并锁定水位线，不存在时先创建，需在事务中调用
+func lockWatermark(ctx context.Context, name string) (uint64, error) {
+	_, err := dao.FeedbackWatermark.Ctx(ctx).
+		Data(do.FeedbackWatermark{Name: name, FeedbackId: 0}).
+		InsertIgnore()
+	if err != nil {
+		return 0, err
+	}
+	var watermark entity.FeedbackWatermark
+	err = dao.FeedbackWatermark.Ctx(ctx).
+		Where(do.FeedbackWatermark{Name: name}).
+		LockUpdate().
+		Scan(&watermark)
+	return watermark.FeedbackId, err
+}
+
+// saveRun 保存反馈处理记录
+func saveRun(ctx context.Context, run *model.FeedbackRun) error {
+	data := do.FeedbackRun{
+		TriggerType:    run.Trigger,
+		Status:         run.Status,
+		FromFeedbackId: run.FromFeedbackID,
+		ToFeedbackId:   run.ToFeedbackID,
+		Feedbacks:      run.Feedbacks,
+		Adjusted:       run.Adjusted,
+		StartedAt:      run.StartedAt,
+		FinishedAt:     run.FinishedAt,
+	}
+	if run.Error != "" {
+		data.Error = run.Error
+	}
+	id, err := dao.FeedbackRun.Ctx(ctx).Data(data).InsertAndGetId()
+	run.ID = uint64(id)
+	return err
+}
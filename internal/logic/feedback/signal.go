@@ -0,0 +1,270 @@
+package feedback
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"knowledge-system-api/internal/consts"
+	"knowledge-system-api/internal/dao"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/model/do"
+	"knowledge-system-api/internal/model/entity"
+	"knowledge-system-api/internal/service"
+)
+
+// entryStatsRow 知识条目展示统计的查询结果
+type entryStatsRow struct {
+	KnowledgeId string
+	RepoName    string
+	Impressions uint
+	Clicks      uint
+	Copies      uint
+	AvgPosition float64
+}
+
+// RecordImpressions 记录一次检索展示的知识条目，feedback.impressions 为 false 或没有条目时不记录
+func (s *Feedback) RecordImpressions(ctx context.Context, impression *model.Impression) error {
+	if len(impression.Items) == 0 || !g.Cfg().MustGet(ctx, "feedback.impressions", true).Bool() {
+		return nil
+	}
+	now := gtime.Now()
+	rows := make([]do.SearchImpression, len(impression.Items))
+	for i, item := range impression.Items {
+		rows[i] = do.SearchImpression{
+			SearchId:    impression.SearchID,
+			SessionId:   impression.SessionID,
+			Source:      impression.Source,
+			Query:       impression.Query,
+			KnowledgeId: item.KnowledgeID,
+			RepoName:    item.RepoName,
+			Position:    item.Position,
+			CreatedAt:   now,
+		}
+	}
+	_, err := dao.SearchImpression.Ctx(ctx).Data(rows).Insert()
+	return err
+}
+
+// AddEvent 记录对检索结果的点击、复制等行为，该次检索必须展示过这条知识
+// 未传会话ID时使用展示记录中的会话ID
+func (s *Feedback) AddEvent(ctx context.Context, searchID, sessionID, knowledgeID, event string) (uint64, error) {
+	if sessionID != "" {
+		if err := service.Session().Validate(ctx, sessionID); err != nil {
+			return 0, err
+		}
+	}
+	impression, err := findImpression(ctx, searchID, knowledgeID)
+	if err != nil {
+		return 0, err
+	}
+	if sessionID == "" {
+		sessionID = impression.SessionId
+	}
+
+	id, err := dao.SearchEvent.Ctx(ctx).Data(do.SearchEvent{
+		SearchId:    searchID,
+		SessionId:   sessionID,
+		KnowledgeId: knowledgeID,
+		Event:       event,
+		Position:    impression.Position,
+		CreatedAt:   gtime.Now(),
+	}).InsertAndGetId()
+	return uint64(id), err
+}
+
+// AddQueryFeedback 添加针对整次检索的反馈
+// 关联检索ID时，未传的查询与会话ID取自展示记录，展示的条目都来自同一知识库时补全知识库名称；
+// 没有结果的检索不产生展示记录，需要由调用方传入查询；最终没有会话ID时记为匿名反馈
+func (s *Feedback) AddQueryFeedback(ctx context.Context, feedback *model.QueryFeedback) (uint64, error) {
+	if feedback.SessionID != "" {
+		if err := service.Session().Validate(ctx, feedback.SessionID); err != nil {
+			return 0, err
+		}
+	}
+	if feedback.SearchID != "" {
+		var impressions []entity.SearchImpression
+		err := dao.SearchImpression.Ctx(ctx).
+			Where(do.SearchImpression{SearchId: feedback.SearchID}).
+			OrderAsc(dao.SearchImpression.Columns().Position).
+			Scan(&impressions)
+		if err != nil {
+			return 0, err
+		}
+		if len(impressions) > 0 {
+			first := impressions[0]
+			if feedback.Query == "" {
+				feedback.Query = first.Query
+			}
+			if feedback.SessionID == "" {
+				feedback.SessionID = first.SessionId
+			}
+			if feedback.RepoName == "" {
+				feedback.RepoName = first.RepoName
+				for _, impression := range impressions[1:] {
+					if impression.RepoName != first.RepoName {
+						feedback.RepoName = ""
+						break
+					}
+				}
+			}
+		}
+	}
+	if feedback.Query == "" {
+		return 0, gerror.NewCode(gcode.CodeInvalidParameter, "查询不能为空，未关联检索ID或该次检索没有结果时需要传入查询")
+	}
+	anonymous, err := checkAnonymous(ctx, feedback.SessionID)
+	if err != nil {
+		return 0, err
+	}
+	feedback.Anonymous = anonymous
+
+	feedback.CreatedAt = gtime.Now()
+	data := do.QueryFeedback{
+		SearchId:  feedback.SearchID,
+		SessionId: feedback.SessionID,
+		Query:     feedback.Query,
+		RepoName:  feedback.RepoName,
+		Rating:    feedback.Rating,
+		Anonymous: feedback.Anonymous,
+		CreatedAt: feedback.CreatedAt,
+	}
+	if feedback.Comment != "" {
+		data.Comment = feedback.Comment
+	}
+	id, err := dao.QueryFeedback.Ctx(ctx).Data(data).InsertAndGetId()
+	if err != nil {
+		return 0, err
+	}
+	feedback.ID = uint64(id)
+	if feedback.Rating == consts.QueryRatingNoRelevant {
+		g.Log().Infof(ctx, "收到无相关结果反馈, 知识库: %q, 查询: %s", feedback.RepoName, feedback.Query)
+	}
+	return feedback.ID, nil
+}
+
+// ListQueryFeedbacks 分页查询查询反馈，按时间倒序
+func (s *Feedback) ListQueryFeedbacks(ctx context.Context, opts model.QueryFeedbackListOptions, page, pageSize int) ([]model.QueryFeedback, int, error) {
+	cols := dao.QueryFeedback.Columns()
+	m := dao.QueryFeedback.Ctx(ctx)
+	if opts.RepoName != "" {
+		m = m.Where(cols.RepoName, opts.RepoName)
+	}
+	if opts.Rating != "" {
+		m = m.Where(cols.Rating, opts.Rating)
+	}
+	if opts.StartTime != nil {
+		m = m.WhereGTE(cols.CreatedAt, opts.StartTime)
+	}
+	if opts.EndTime != nil {
+		m = m.WhereLTE(cols.CreatedAt, opts.EndTime)
+	}
+	total, err := m.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var rows []entity.QueryFeedback
+	if err := m.Page(page, pageSize).OrderDesc(cols.Id).Scan(&rows); err != nil {
+		return nil, 0, err
+	}
+	feedbacks := make([]model.QueryFeedback, len(rows))
+	for i, row := range rows {
+		feedbacks[i] = model.QueryFeedback{
+			ID:        row.Id,
+			SearchID:  row.SearchId,
+			SessionID: row.SessionId,
+			Query:     row.Query,
+			RepoName:  row.RepoName,
+			Rating:    row.Rating,
+			Comment:   row.Comment,
+			Anonymous: row.Anonymous != 0,
+			CreatedAt: row.CreatedAt,
+		}
+	}
+	return feedbacks, total, nil
+}
+
+// EntryStats 分页统计知识条目的展示次数、点击率与复制率
+// 以展示为单位统计：一次展示被点击（复制）多次只计一次，时间范围按展示时间过滤
+func (s *Feedback) EntryStats(ctx context.Context, opts model.EntryStatsOptions, page, pageSize int) ([]model.EntryStats, int, error) {
+	m := dao.SearchImpression.Ctx(ctx).As("i").Safe()
+	if opts.RepoName != "" {
+		m = m.Where("i.repo_name", opts.RepoName)
+	}
+	if opts.KnowledgeID != "" {
+		m = m.Where("i.knowledge_id", opts.KnowledgeID)
+	}
+	if opts.StartTime != nil {
+		m = m.WhereGTE("i.created_at", opts.StartTime)
+	}
+	if opts.EndTime != nil {
+		m = m.WhereLTE("i.created_at", opts.EndTime)
+	}
+	m = m.Group("i.knowledge_id, i.repo_name").Having("COUNT(1) >= ?", max(opts.MinImpressions, 1))
+
+	total, err := m.Fields("i.knowledge_id").Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	clicked := eventExists(consts.SearchEventClick)
+	copied := eventExists(consts.SearchEventCopy)
+	order := "impressions DESC, i.knowledge_id"
+	if opts.OrderBy == "ctr" {
+		order = "ctr DESC, impressions DESC, i.knowledge_id"
+	}
+	var rows []entryStatsRow
+	err = m.Fields(fmt.Sprintf("i.knowledge_id, i.repo_name, COUNT(1) AS impressions, "+
+		"SUM(%s) AS clicks, SUM(%s) AS copies, SUM(%s) / COUNT(1) AS ctr, AVG(i.position) AS avg_position",
+		clicked, copied, clicked)).
+		Order(order).
+		Page(page, pageSize).
+		Scan(&rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	stats := make([]model.EntryStats, len(rows))
+	for i, row := range rows {
+		stats[i] = model.EntryStats{
+			KnowledgeID: row.KnowledgeId,
+			RepoName:    row.RepoName,
+			Impressions: row.Impressions,
+			Clicks:      row.Clicks,
+			Copies:      row.Copies,
+			AvgPosition: row.AvgPosition,
+		}
+		if row.Impressions > 0 {
+			stats[i].CTR = float64(row.Clicks) / float64(row.Impressions)
+			stats[i].CopyRate = float64(row.Copies) / float64(row.Impressions)
+		}
+	}
+	return stats, total, nil
+}
+
+// findImpression 查找检索展示该知识的记录，没有展示过时返回 CodeNotFound 错误
+func findImpression(ctx context.Context, searchID, knowledgeID string) (*entity.SearchImpression, error) {
+	var impression *entity.SearchImpression
+	err := dao.SearchImpression.Ctx(ctx).
+		Where(do.SearchImpression{SearchId: searchID, KnowledgeId: knowledgeID}).
+		OrderAsc(dao.SearchImpression.Columns().Id).
+		Scan(&impression)
+	if err != nil {
+		return nil, err
+	}
+	if impression == nil {
+		return nil, gerror.NewCodef(gcode.CodeNotFound, "检索 %s 没有展示知识 %s", searchID, knowledgeID)
+	}
+	return impression, nil
+}
+
+// eventExists 展示记录是否有指定行为的 SQL 表达式，event 只能是常量
+func eventExists(event string) string {
+	return fmt.Sprintf("EXISTS(SELECT 1 FROM search_event e WHERE e.search_id = i.search_id "+
+		"AND e.knowledge_id = i.knowledge_id AND e.event = '%s')", event)
+}
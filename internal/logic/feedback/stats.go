@@ -0,0 +1,185 @@
+package feedback
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"knowledge-system-api/internal/dao"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/model/do"
+)
+
+const (
+	// trendDefaultDays 未指定开始时间时趋势统计的天数
+	trendDefaultDays = 30
+	// trendMaxDays 趋势统计的最大天数
+	trendMaxDays = 366
+	// likeCounts 点赞与点踩数量的 SQL 表达式
+	likeCounts = "SUM(f.action = 'like') AS likes, SUM(f.action = 'dislike') AS dislikes"
+)
+
+// KnowledgeStats 分页统计知识条目的点赞与点踩数量，只统计仍存在的知识条目
+func (s *Feedback) KnowledgeStats(ctx context.Context, opts model.FeedbackStatsOptions, page, pageSize int) ([]model.KnowledgeFeedbackStats, int, error) {
+	m := feedbackStatsModel(ctx, opts).Group("k.id")
+	total, err := m.Fields("k.id").Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	order := "COUNT(1) DESC, k.id"
+	switch opts.OrderBy {
+	case "likes":
+		order = "likes DESC, dislikes, k.id"
+	case "dislikes":
+		order = "dislikes DESC, likes, k.id"
+	}
+	var stats []model.KnowledgeFeedbackStats
+	err = m.Fields("k.id AS knowledge_id, k.repo_name, k.summary, "+likeCounts).
+		Order(order).
+		Page(page, pageSize).
+		Scan(&stats)
+	if err != nil {
+		return nil, 0, err
+	}
+	return stats, total, nil
+}
+
+// TopDisliked 按知识库返回点踩最多的知识条目，没有点踩的条目不返回，知识库按名称排列
+func (s *Feedback) TopDisliked(ctx context.Context, opts model.FeedbackStatsOptions, limit int) ([]model.RepoDislikedEntries, error) {
+	var rows []model.KnowledgeFeedbackStats
+	err := feedbackStatsModel(ctx, opts).
+		Fields("k.id AS knowledge_id, k.repo_name, k.summary, " + likeCounts).
+		Group("k.id").
+		Having("dislikes > 0").
+		Order("k.repo_name, dislikes DESC, likes, k.id").
+		Scan(&rows)
+	if err != nil {
+		return nil, err
+	}
+
+	repos := []model.RepoDislikedEntries{}
+	for _, row := range rows {
+		if len(repos) == 0 || repos[len(repos)-1].RepoName != row.RepoName {
+			repos = append(repos, model.RepoDislikedEntries{RepoName: row.RepoName})
+		}
+		last := &repos[len(repos)-1]
+		if len(last.Entries) < limit {
+			last.Entries = append(last.Entries, row)
+		}
+	}
+	return repos, nil
+}
+
+// Trend 按天统计点赞与点踩数量
+// 未指定开始时间时统计结束时间（默认当前时间）之前的30天，时间跨度不能超过366天
+func (s *Feedback) Trend(ctx context.Context, opts model.FeedbackStatsOptions) ([]model.FeedbackTrendPoint, error) {
+	end := time.Now()
+	if opts.EndTime != nil {
+		end = opts.EndTime.Time
+	}
+	start := dayStart(end).AddDate(0, 0, 1-trendDefaultDays)
+	if opts.StartTime != nil {
+		start = opts.StartTime.Time
+	}
+	if start.After(end) {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "开始时间不能晚于结束时间")
+	}
+	if dayStart(end).Sub(dayStart(start)) >= trendMaxDays*24*time.Hour {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, "趋势统计的时间跨度不能超过%d天", trendMaxDays)
+	}
+	opts.StartTime, opts.EndTime = gtime.New(start), gtime.New(end)
+
+	var rows []model.FeedbackTrendPoint
+	err := feedbackStatsModel(ctx, opts).
+		Fields("DATE_FORMAT(f.timestamp, '%Y-%m-%d') AS date, " + likeCounts).
+		Group("date").
+		Scan(&rows)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]model.FeedbackTrendPoint, len(rows))
+	for _, row := range rows {
+		counts[row.Date] = row
+	}
+
+	var points []model.FeedbackTrendPoint
+	for day := dayStart(start); !day.After(end); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		point, ok := counts[date]
+		if !ok {
+			point = model.FeedbackTrendPoint{Date: date}
+		}
+		points = append(points, point)
+	}
+	return points, nil
+}
+
+// DeleteBySession 在一个事务中删除会话的点赞点踩反馈、查询反馈、检索行为、会话轮次、展示记录与检索记录
+// 已处理的反馈对质量分数的调整不会撤销
+func (s *Feedback) DeleteBySession(ctx context.Context, sessionID string) (*model.FeedbackDeletion, error) {
+	deletion := &model.FeedbackDeletion{}
+	err := dao.Feedback.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		var err error
+		if deletion.Feedbacks, err = deleteRows(dao.Feedback.Ctx(ctx).Where(do.Feedback{SessionId: sessionID})); err != nil {
+			return err
+		}
+		if deletion.QueryFeedbacks, err = deleteRows(dao.QueryFeedback.Ctx(ctx).Where(do.QueryFeedback{SessionId: sessionID})); err != nil {
+			return err
+		}
+		if deletion.Events, err = deleteRows(dao.SearchEvent.Ctx(ctx).Where(do.SearchEvent{SessionId: sessionID})); err != nil {
+			return err
+		}
+		if deletion.Turns, err = deleteRows(dao.SessionTurn.Ctx(ctx).Where(do.SessionTurn{SessionId: sessionID})); err != nil {
+			return err
+		}
+		if deletion.Impressions, err = deleteRows(dao.SearchImpression.Ctx(ctx).Where(do.SearchImpression{SessionId: sessionID})); err != nil {
+			return err
+		}
+		deletion.SearchLogs, err = deleteRows(dao.SearchLog.Ctx(ctx).Where(do.SearchLog{SessionId: sessionID}))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deletion, nil
+}
+
+// feedbackStatsModel 按统计范围过滤的反馈，关联知识条目以按知识库过滤
+func feedbackStatsModel(ctx context.Context, opts model.FeedbackStatsOptions) *gdb.Model {
+	m := dao.Feedback.Ctx(ctx).As("f").
+		InnerJoin("knowledge k", "k.id = f.retrieved_knowledge_id").
+		Safe()
+	if opts.RepoName != "" {
+		m = m.Where("k.repo_name", opts.RepoName)
+	}
+	if opts.KnowledgeID != "" {
+		m = m.Where("f.retrieved_knowledge_id", opts.KnowledgeID)
+	}
+	if opts.StartTime != nil {
+		m = m.WhereGTE("f.timestamp", opts.StartTime)
+	}
+	if opts.EndTime != nil {
+		m = m.WhereLTE("f.timestamp", opts.EndTime)
+	}
+	return m
+}
+
+// deleteRows 执行删除并返回删除的行数
+func deleteRows(m *gdb.Model) (int64, error) {
+	result, err := m.Delete()
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// dayStart 所在日期的零点
+func dayStart(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
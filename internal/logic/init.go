@@ -3,8 +3,13 @@ package logic
 
 import (
 	"knowledge-system-api/internal/helper"
+	"knowledge-system-api/internal/logic/analytics"
+	"knowledge-system-api/internal/logic/answer"
+	"knowledge-system-api/internal/logic/eval"
 	"knowledge-system-api/internal/logic/feedback"
 	"knowledge-system-api/internal/logic/knowledge"
+	"knowledge-system-api/internal/logic/repo"
+	"knowledge-system-api/internal/logic/session"
 	"knowledge-system-api/internal/service"
 
 	"github.com/gogf/gf/v2/frame/g"
@@ -18,7 +23,7 @@ func InitServiceLogic() {
 	service.RegisterKnowledgeLogic(
 		k.CreateKnowledge,
 		k.GetKnowledgeById,
-		// k.SearchKnowledgeByKeyword,
+		k.SearchKnowledgeByKeyword,
 		// k.SearchKnowledgeBySemantic,
 		k.SearchKnowledgeByHybrid,
 		k.CreateImportTask,
@@ -28,9 +33,24 @@ func InitServiceLogic() {
 		k.RecoverTasks,
 	)
 
+	// 初始化知识库管理服务
+	service.RegisterRepo(repo.New())
+
 	// 初始化反馈服务的业务逻辑
 	f := feedback.New()
 	service.RegisterFeedback(f)
+
+	// 初始化答案生成服务
+	service.RegisterAnswer(answer.New())
+
+	// 初始化多轮会话服务
+	service.RegisterSession(session.New())
+
+	// 初始化离线检索评测服务
+	service.RegisterEval(eval.New())
+
+	// 初始化检索统计分析服务
+	service.RegisterAnalytics(analytics.New())
 }
 
 func init() {
@@ -66,6 +86,9 @@ func cleanupServices() {
 	ctx := gctx.New()
 	g.Log().Info(ctx, "开始清理服务资源...")
 
+	// 取消后台进行中的查询标签质心训练
+	service.StopLabelCentroids()
+
 	// 关闭Qdrant客户端连接
 	service.CloseQdrantClient()
 
@@ -0,0 +1,55 @@
+package knowledge
+
+import (
+	"context"
+
+	"knowledge-system-api/internal/helper"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/service"
+)
+
+// explainQuery 记录查询标签在标签字典中的映射，以及发送给 Qdrant 的标签与词法稀疏向量
+// queryLabels 为空表示融合策略不使用标签，未分析查询标签
+func explainQuery(ctx context.Context, explain *model.SearchExplain, query string, queryLabels *model.QueryLabels) {
+	if queryLabels != nil {
+		explain.LabelSource = queryLabels.Source
+		explain.LabelConfidence = queryLabels.Confidence
+		explain.QueryLabels = queryLabels.Labels
+		for _, l := range queryLabels.Labels {
+			id, found := helper.Dictionary().GetID(ctx, l.Name)
+			if !found {
+				explain.DroppedLabels = append(explain.DroppedLabels, l)
+				continue
+			}
+			explain.MappedLabels = append(explain.MappedLabels, model.MappedLabel{Name: l.Name, ID: id, Score: l.Score})
+			explain.SparseVector.Indices = append(explain.SparseVector.Indices, id)
+			explain.SparseVector.Values = append(explain.SparseVector.Values, l.Score)
+		}
+	}
+	explain.LexicalVector.Indices, explain.LexicalVector.Values = service.LexicalQueryVector(query)
+}
+
+// explainItems 从各知识库的候选中查找当前页结果在各路向量上的分数
+func explainItems(explain *model.SearchExplain, results []model.SearchResult) {
+	candidates := make(map[string]model.CandidateExplain)
+	for _, repo := range explain.Repos {
+		for _, c := range repo.Candidates {
+			candidates[repo.RepoName+"/"+c.ID] = c
+		}
+	}
+
+	explain.Items = make([]model.ItemExplain, len(results))
+	for i, r := range results {
+		c := candidates[r.RepoName+"/"+r.ID]
+		explain.Items[i] = model.ItemExplain{
+			ID:           r.ID,
+			RepoName:     r.RepoName,
+			Score:        r.Score,
+			DenseScore:   c.DenseScore,
+			SparseScore:  c.SparseScore,
+			LexicalScore: c.LexicalScore,
+			RerankScore:  r.RerankScore,
+			Quality:      r.Quality,
+		}
+	}
+}
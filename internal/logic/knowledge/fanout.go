@@ -0,0 +1,164 @@
+package knowledge
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gogf/gf/v2/frame/g"
+
+	"knowledge-system-api/internal/consts"
+	"knowledge-system-api/internal/helper"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/service"
+)
+
+// searchRepos 解析参与检索的知识库：指定 repo_name 时只检索该知识库，否则检索 repo_names 或全部知识库
+func searchRepos(ctx context.Context, opts *model.SearchOptions) ([]string, error) {
+	if opts.RepoName != "" {
+		return []string{opts.RepoName}, nil
+	}
+	if len(opts.RepoNames) == 0 {
+		return service.Repo().List(ctx)
+	}
+
+	seen := make(map[string]bool)
+	var repos []string
+	for _, name := range opts.RepoNames {
+		if !seen[name] {
+			seen[name] = true
+			repos = append(repos, name)
+		}
+	}
+	return repos, nil
+}
+
+// fanoutVectorSearch 并行检索多个知识库，按知识库归一化分数后合并，返回合并结果中 [offset, offset+limit) 的部分
+// total 为各知识库满足过滤条件的候选总数之和；单个知识库失败时记录在 repoErrors 中，只有全部失败才返回错误
+func fanoutVectorSearch(ctx context.Context, opts *model.SearchOptions, repos []string, offset, limit uint64) (
+	points []model.VectorSearchResult, fusion string, total uint64, repoErrors []model.RepoError, err error) {
+	// 单个知识库无需归一化与合并，翻页直接交给 Qdrant，错误直接返回
+	if len(repos) == 1 {
+		searchOpts := *opts
+		searchOpts.RepoName = repos[0]
+		searchOpts.Offset = offset
+		searchOpts.Limit = limit
+		output, err := helper.VectorSearch(ctx, &searchOpts)
+		if err != nil {
+			return nil, "", 0, nil, err
+		}
+		return output.Points, output.Fusion, output.Total, nil, nil
+	}
+
+	// 合并后的第 offset 条之前可能来自任意知识库，每个知识库都需从第0条召回到当前页末尾
+	// 召回数量受 per_repo_limit 限制时，合并结果只有前 per_repo_limit 条是完整的，之后的结果不再返回
+	cfg := service.LoadSearchConfig(ctx)
+	perRepoLimit := offset + limit
+	capped := false
+	if cfg.Fanout.PerRepoLimit > 0 && cfg.Fanout.PerRepoLimit < perRepoLimit {
+		perRepoLimit = cfg.Fanout.PerRepoLimit
+		capped = true
+	}
+
+	outputs := make([]*model.VectorSearchOutput, len(repos))
+	errs := make([]error, len(repos))
+	sem := make(chan struct{}, cfg.Fanout.Concurrency)
+	var wg sync.WaitGroup
+	for i, name := range repos {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			searchOpts := *opts
+			searchOpts.RepoName = name
+			searchOpts.Offset = 0
+			searchOpts.Limit = perRepoLimit
+			outputs[i], errs[i] = helper.VectorSearch(ctx, &searchOpts)
+		}(i, name)
+	}
+	wg.Wait()
+
+	var fusions []string
+	for i, name := range repos {
+		if errs[i] != nil {
+			g.Log().Warningf(ctx, "知识库 %s 检索失败: %v", name, errs[i])
+			repoErrors = append(repoErrors, model.RepoError{RepoName: name, Message: errs[i].Error()})
+			continue
+		}
+		normalizeScores(outputs[i].Points, cfg.Fanout.Normalize)
+		points = append(points, outputs[i].Points...)
+		total += outputs[i].Total
+		if outputs[i].Fusion != "" && !containsString(fusions, outputs[i].Fusion) {
+			fusions = append(fusions, outputs[i].Fusion)
+		}
+	}
+	if len(repoErrors) == len(repos) {
+		return nil, "", 0, repoErrors, fmt.Errorf("所有知识库检索失败: %s", repoErrors[0].Message)
+	}
+
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].Score > points[j].Score
+	})
+	if capped && uint64(len(points)) > perRepoLimit {
+		points = points[:perRepoLimit]
+	}
+	if uint64(len(points)) <= offset {
+		points = nil
+	} else {
+		points = points[offset:]
+		if uint64(len(points)) > limit {
+			points = points[:limit]
+		}
+	}
+
+	// 各知识库回退情况可能不同，列出实际使用过的全部策略
+	sort.Strings(fusions)
+	g.Log().Debugf(ctx, "跨知识库检索完成: 知识库 %d 个，失败 %d 个，合并后 %d 条", len(repos), len(repoErrors), len(points))
+	return points, strings.Join(fusions, ","), total, repoErrors, nil
+}
+
+// normalizeScores 归一化单个知识库的检索分数，使不同集合的分数可以合并排序
+func normalizeScores(points []model.VectorSearchResult, method string) {
+	if method == consts.NormalizeNone || len(points) == 0 {
+		return
+	}
+
+	minScore, maxScore := points[0].Score, points[0].Score
+	for _, p := range points {
+		if p.Score < minScore {
+			minScore = p.Score
+		}
+		if p.Score > maxScore {
+			maxScore = p.Score
+		}
+	}
+
+	for i := range points {
+		switch method {
+		case consts.NormalizeMax:
+			if maxScore > 0 {
+				points[i].Score /= maxScore
+			}
+		case consts.NormalizeMinMax:
+			if maxScore > minScore {
+				points[i].Score = (points[i].Score - minScore) / (maxScore - minScore)
+			} else {
+				points[i].Score = 1
+			}
+		}
+	}
+}
+
+// containsString 判断字符串切片中是否包含指定值
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
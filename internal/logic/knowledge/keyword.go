@@ -0,0 +1,166 @@
+package knowledge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gogf/gf/v2/frame/g"
+
+	"knowledge-system-api/internal/consts"
+	"knowledge-system-api/internal/dao"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/model/entity"
+	"knowledge-system-api/internal/service"
+)
+
+// keywordMatchSQL 正文与摘要的全文索引匹配，全文索引使用 ngram 分词器以支持中文
+const keywordMatchSQL = "MATCH(content) AGAINST(? IN NATURAL LANGUAGE MODE) + MATCH(summary) AGAINST(? IN NATURAL LANGUAGE MODE)"
+
+// SearchKnowledgeByKeyword 关键词搜索知识条目（MySQL 全文索引）
+// 不指定知识库时检索全部知识库，分数为全文索引的相关度，与向量检索的分数不可比较
+func (s *Knowledge) SearchKnowledgeByKeyword(ctx context.Context, opts *model.SearchOptions) (*model.SearchOutput, error) {
+	g.Log().Debug(ctx, "开始关键词搜索")
+	page := s.newSearchPage(ctx, opts)
+
+	conditions := []string{fmt.Sprintf("(%s) > 0", keywordMatchSQL)}
+	whereArgs := []interface{}{opts.Query, opts.Query}
+	// 指定知识库时按其元数据字段声明转换条件值，否则按条件值推断类型
+	var schema []model.MetadataField
+	if opts.RepoName != "" {
+		conditions = append(conditions, "repo_name = ?")
+		whereArgs = append(whereArgs, opts.RepoName)
+		repo, err := service.Repo().Get(ctx, opts.RepoName)
+		if err != nil {
+			return nil, err
+		}
+		if repo != nil {
+			schema = repo.MetadataSchema
+		}
+	} else if len(opts.RepoNames) > 0 {
+		conditions = append(conditions, "repo_name IN (?)")
+		whereArgs = append(whereArgs, opts.RepoNames)
+	}
+	filterConditions, filterArgs, err := keywordFilterSQL(opts.Filter, schema)
+	if err != nil {
+		return nil, err
+	}
+	conditions = append(conditions, filterConditions...)
+	whereArgs = append(whereArgs, filterArgs...)
+
+	// 参数顺序：SELECT 中的相关度、WHERE 条件、LIMIT
+	where := strings.Join(conditions, " AND ")
+	sql := fmt.Sprintf("SELECT *, %s AS score FROM %s WHERE %s ORDER BY score DESC LIMIT ?, ?",
+		keywordMatchSQL, dao.Knowledge.Table(), where)
+	args := append([]interface{}{opts.Query, opts.Query}, whereArgs...)
+	args = append(args, page.fetchOffset, page.fetchLimit)
+
+	var rows []struct {
+		entity.Knowledge
+		Score float32 `orm:"score"`
+	}
+	if err := dao.Knowledge.DB().Ctx(ctx).Raw(sql, args...).Scan(&rows); err != nil {
+		return nil, err
+	}
+
+	results := make([]model.SearchResult, 0, len(rows))
+	for _, row := range rows {
+		var labels []model.LabelScore
+		if err := json.Unmarshal([]byte(row.Labels), &labels); err != nil {
+			g.Log().Warning(ctx, "解析标签JSON失败", err)
+			labels = []model.LabelScore{}
+		}
+		results = append(results, model.SearchResult{
+			ID:       row.Id,
+			RepoName: row.RepoName,
+			Content:  row.Content,
+			Labels:   labels,
+			Summary:  row.Summary,
+			Score:    row.Score,
+			Metadata: parseMetadata(ctx, row.Metadata),
+		})
+	}
+
+	// 候选总数使用相同的过滤条件统计，失败时不影响检索结果
+	var total uint64
+	countSQL := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", dao.Knowledge.Table(), where)
+	if value, err := dao.Knowledge.DB().Ctx(ctx).GetValue(ctx, countSQL, whereArgs...); err != nil {
+		g.Log().Warningf(ctx, "统计关键词检索候选总数失败: %v", err)
+	} else {
+		total = value.Uint64()
+	}
+
+	results, hasMore := s.finishPage(ctx, opts, page, results)
+
+	g.Log().Debugf(ctx, "关键词搜索完成: 共返回 %d 条结果", len(results))
+	return &model.SearchOutput{
+		Items:   results,
+		Fusion:  consts.FusionKeyword,
+		Total:   total,
+		HasMore: hasMore,
+	}, nil
+}
+
+// keywordFilterSQL 将检索过滤条件转换为 knowledge 表的 WHERE 条件，语义与 Qdrant 过滤器一致
+func keywordFilterSQL(f *model.SearchFilter, schema []model.MetadataField) (conditions []string, args []interface{}, err error) {
+	if f == nil {
+		return nil, nil, nil
+	}
+
+	for _, l := range f.Labels {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM JSON_TABLE(labels, '$[*]' COLUMNS("+
+			"name VARCHAR(255) PATH '$.name', score FLOAT PATH '$.score')) AS l WHERE l.name = ? AND l.score >= ?)")
+		args = append(args, l.Name, l.MinScore)
+	}
+	if !f.CreatedFrom.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, f.CreatedFrom.Local().String())
+	}
+	if !f.CreatedTo.IsZero() {
+		conditions = append(conditions, "created_at <= ?")
+		args = append(args, f.CreatedTo.Local().String())
+	}
+	if !f.UpdatedFrom.IsZero() {
+		conditions = append(conditions, "updated_at >= ?")
+		args = append(args, f.UpdatedFrom.Local().String())
+	}
+	if !f.UpdatedTo.IsZero() {
+		conditions = append(conditions, "updated_at <= ?")
+		args = append(args, f.UpdatedTo.Local().String())
+	}
+	if len(f.DocumentIDs) > 0 {
+		conditions = append(conditions, "id IN (?)")
+		args = append(args, f.DocumentIDs)
+	}
+	for _, mf := range f.Metadata {
+		m, typ, err := service.NormalizeMetadataFilter(mf, schema)
+		if err != nil {
+			return nil, nil, err
+		}
+		// 字段名已校验只包含安全字符，可直接拼接为 JSON 路径
+		value := fmt.Sprintf("JSON_EXTRACT(metadata, '$.%s')", m.Key)
+		if len(m.Match) > 0 {
+			// JSON_OVERLAPS 同时兼容单值与数组值的元数据
+			matchJson, err := json.Marshal(m.Match)
+			if err != nil {
+				return nil, nil, err
+			}
+			conditions = append(conditions, fmt.Sprintf("JSON_OVERLAPS(%s, CAST(? AS JSON))", value))
+			args = append(args, string(matchJson))
+		}
+		// 日期时间统一存储为 RFC3339 格式的 UTC 时间，按字符串比较即可
+		if typ == consts.MetadataTypeDatetime {
+			value = fmt.Sprintf("JSON_UNQUOTE(%s)", value)
+		}
+		if m.Gte != nil {
+			conditions = append(conditions, value+" >= ?")
+			args = append(args, m.Gte)
+		}
+		if m.Lte != nil {
+			conditions = append(conditions, value+" <= ?")
+			args = append(args, m.Lte)
+		}
+	}
+	return conditions, args, nil
+}
@@ -3,12 +3,14 @@ package knowledge
 import (
 	"context"
 	"encoding/json"
+	"knowledge-system-api/internal/consts"
 	"knowledge-system-api/internal/dao"
-	"knowledge-system-api/internal/helper"
 	"knowledge-system-api/internal/model"
 	"knowledge-system-api/internal/model/do"
 	"knowledge-system-api/internal/model/entity"
+	"knowledge-system-api/internal/service"
 	"sort"
+	"time"
 
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/os/gtime"
@@ -23,24 +25,38 @@ func New() *Knowledge {
 }
 
 // CreateKnowledge 创建知识条目
-func (s *Knowledge) CreateKnowledge(ctx context.Context, id, repoName, content string, labels []model.LabelScore, summary string) error {
+func (s *Knowledge) CreateKnowledge(ctx context.Context, id, repoName, content string, labels []model.LabelScore, summary string, metadata map[string]interface{}) error {
 	labelsJson, err := json.Marshal(labels)
 	if err != nil {
 		return err
 	}
 
+	data := do.Knowledge{
+		Id:       id,
+		RepoName: repoName,
+		Content:  content,
+		Labels:   string(labelsJson),
+		Summary:  summary,
+	}
+	if len(metadata) > 0 {
+		metadataJson, err := json.Marshal(metadata)
+		if err != nil {
+			return err
+		}
+		data.Metadata = string(metadataJson)
+	}
+
 	now := gtime.Now()
-	_, err = dao.Knowledge.Ctx(ctx).Data(do.Knowledge{
-		Id:        id,
-		RepoName:  repoName,
-		Content:   content,
-		Labels:    string(labelsJson),
-		Summary:   summary,
-		CreatedAt: now,
-		UpdatedAt: now,
-	}).InsertAndGetId()
-
-	return err
+	data.CreatedAt = now
+	data.UpdatedAt = now
+	_, err = dao.Knowledge.Ctx(ctx).Data(data).InsertAndGetId()
+	if err != nil {
+		return err
+	}
+
+	// 关键词检索直接读取 knowledge 表，写入后同样需要使检索缓存失效
+	service.InvalidateSearchCache(ctx, repoName)
+	return nil
 }
 
 // GetKnowledgeById 根据ID获取知识条目
@@ -69,37 +85,74 @@ func (s *Knowledge) GetKnowledgeById(ctx context.Context, id string) (*model.Kno
 		Content:   entity.Content,
 		Labels:    labels,
 		Summary:   entity.Summary,
+		Metadata:  parseMetadata(ctx, entity.Metadata),
 		CreatedAt: entity.CreatedAt,
 		UpdatedAt: entity.UpdatedAt,
 	}, nil
 }
 
 // SearchKnowledgeByHybrid 混合搜索知识条目（基于用户意图的语义检索）
-func (s *Knowledge) SearchKnowledgeByHybrid(ctx context.Context, query string, repoName string, limit uint64) ([]model.SearchResult, error) {
+func (s *Knowledge) SearchKnowledgeByHybrid(ctx context.Context, opts *model.SearchOptions) (*model.SearchOutput, error) {
 	g.Log().Debug(ctx, "开始混合搜索，基于标签和语义检索")
+	query := opts.Query
+	explain := opts.Explain
+	start := time.Now()
+	var timings []model.StageTiming
+
+	// 步骤1：分析用户查询意图，提取关键标签（dense/lexical 策略不使用标签，无需分析）
+	// 优先使用最近质心分类，置信度不足时才调用LLM，分析时计算的查询向量在检索时复用
+	var queryLabels *model.QueryLabels
+	if usesQueryLabels(opts.Fusion) {
+		g.Log().Debug(ctx, "分析用户查询意图")
+		queryLabels = service.AnalyzeQueryLabels(ctx, query, opts.LabelThreshold)
+		opts.Labels, opts.Vector = queryLabels.Labels, queryLabels.Vector
+		g.Log().Debugf(ctx, "查询标签来源: %s，标签数量: %d", queryLabels.Source, len(opts.Labels))
+	}
+	if explain != nil {
+		explainQuery(ctx, explain, query, queryLabels)
+	}
+	timings = append(timings, model.NewStageTiming("analyze_labels", start))
+
+	// hyde 策略以假设性答案的向量代替查询向量，标签仍由原始查询分析得到
+	debug := newSearchDebug(opts.Strategy)
+	if opts.Strategy == consts.SearchStrategyHyDE {
+		stageStart := time.Now()
+		applyHyDE(ctx, opts, debug)
+		timings = append(timings, model.NewStageTiming("hyde", stageStart))
+	}
 
-	// 步骤1：分析用户查询意图，提取关键标签
-	var labelScores []model.LabelScore
+	page := s.newSearchPage(ctx, opts)
 
-	if helper.LLMClassify != nil {
-		g.Log().Debug(ctx, "分析用户查询意图")
-		var err error
-		labelScores, _, err = helper.LLMClassify(ctx, query)
-		if err != nil {
-			g.Log().Warningf(ctx, "LLM分析失败: %v, 将使用纯向量搜索", err)
-		}
+	// 步骤2：确定参与检索的知识库，未指定时检索全部知识库
+	repos, err := searchRepos(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(repos) == 0 {
+		return &model.SearchOutput{Debug: debug, Explain: explain}, nil
 	}
 
-	// 使用高优先级标签进行过滤的向量检索
-	g.Log().Debugf(ctx, "开始向量检索，标签数量: %d", len(labelScores))
-	qdrantResults, err := helper.VectorSearch(repoName, query, labelScores, limit)
+	// 步骤3：使用高优先级标签进行过滤的向量检索，多个知识库时并行检索后合并；multi_query 策略对每个改写查询分别检索后融合
+	g.Log().Debugf(ctx, "开始向量检索，标签数量: %d，知识库数量: %d", len(opts.Labels), len(repos))
+	var points []model.VectorSearchResult
+	var fusion string
+	var total uint64
+	var repoErrors []model.RepoError
+	stageStart := time.Now()
+	if opts.Strategy == consts.SearchStrategyMultiQuery {
+		points, fusion, total, repoErrors, err = multiQuerySearch(ctx, opts, repos, page.fetchOffset, page.fetchLimit, debug)
+	} else {
+		points, fusion, total, repoErrors, err = fanoutVectorSearch(ctx, opts, repos, page.fetchOffset, page.fetchLimit)
+	}
 	if err != nil {
 		return nil, err
 	}
+	timings = append(timings, model.NewStageTiming("vector_search", stageStart))
 
-	// 步骤4：处理结果
+	// 步骤4：处理结果（集合按知识库划分，过滤条件已由 Qdrant 执行，无需再次校验）
+	stageStart = time.Now()
 	var results []model.SearchResult
-	for _, item := range qdrantResults {
+	for _, item := range points {
 		// 获取完整知识条目
 		knowledgeItem, err := s.GetKnowledgeById(ctx, item.ID)
 		if err != nil || knowledgeItem == nil {
@@ -107,11 +160,6 @@ func (s *Knowledge) SearchKnowledgeByHybrid(ctx context.Context, query string, r
 			continue
 		}
 
-		// 如果指定了知识库名称，但结果不匹配，则跳过
-		if repoName != "" && knowledgeItem.RepoName != repoName {
-			continue
-		}
-
 		// 添加到结果集
 		results = append(results, model.SearchResult{
 			ID:       knowledgeItem.ID,
@@ -120,6 +168,7 @@ func (s *Knowledge) SearchKnowledgeByHybrid(ctx context.Context, query string, r
 			Labels:   knowledgeItem.Labels,
 			Summary:  knowledgeItem.Summary,
 			Score:    item.Score,
+			Metadata: knowledgeItem.Metadata,
 		})
 	}
 
@@ -128,8 +177,44 @@ func (s *Knowledge) SearchKnowledgeByHybrid(ctx context.Context, query string, r
 		return results[i].Score > results[j].Score
 	})
 
+	timings = append(timings, model.NewStageTiming("load_knowledge", stageStart))
+
+	// 步骤5：重排序、最低分数过滤、分页与高亮
+	stageStart = time.Now()
+	results, hasMore := s.finishPage(ctx, opts, page, results)
+	timings = append(timings, model.NewStageTiming("finish_page", stageStart))
+	if explain != nil {
+		explainItems(explain, results)
+	}
+	timings = append(timings, model.NewStageTiming("total", start))
+	if explain != nil {
+		explain.Timings = timings
+	}
+
 	g.Log().Debugf(ctx, "混合搜索完成: 共返回 %d 条结果", len(results))
-	return results, nil
+	return &model.SearchOutput{
+		Items:   results,
+		Fusion:  fusion,
+		Errors:  repoErrors,
+		Total:   total,
+		HasMore: hasMore,
+		Debug:   debug,
+		Explain: explain,
+		Timings: timings,
+	}, nil
+}
+
+// parseMetadata 解析元数据JSON，未设置元数据时返回 nil
+func parseMetadata(ctx context.Context, metadataJson string) map[string]interface{} {
+	if metadataJson == "" {
+		return nil
+	}
+	var metadata map[string]interface{}
+	if err := json.Unmarshal([]byte(metadataJson), &metadata); err != nil {
+		g.Log().Warning(ctx, "解析元数据JSON失败", err)
+		return nil
+	}
+	return metadata
 }
 
 // GetAllRepos 获取所有知识库名称
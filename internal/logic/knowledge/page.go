@@ -0,0 +1,89 @@
+package knowledge
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/frame/g"
+
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/service"
+)
+
+// searchPage 检索的召回窗口
+// 不重排序且不按质量分数提升时直接使用检索后端的 offset 翻页；重排序或质量分数提升会改变召回结果的顺序，
+// 需要从第0条召回到当前页末尾（重排序时至少 rerank.top_n 条），调整顺序后再在内存中跳过前面的页。
+// 两种情况都多召回一条，用于判断是否还有下一页。
+type searchPage struct {
+	rerank      bool
+	fetchOffset uint64 // 传给检索后端的 offset
+	fetchLimit  uint64 // 传给检索后端的 limit
+	skip        uint64 // 召回后在内存中跳过的条数
+	limit       uint64 // 每页条数
+}
+
+// newSearchPage 根据翻页参数与重排序配置计算召回窗口
+func (s *Knowledge) newSearchPage(ctx context.Context, opts *model.SearchOptions) *searchPage {
+	rerankCfg := service.LoadRerankConfig(ctx)
+	page := &searchPage{rerank: rerankCfg.Enabled, limit: opts.Limit}
+	if opts.Rerank != nil {
+		page.rerank = *opts.Rerank
+	}
+
+	if !page.rerank && service.LoadQualityConfig(ctx).BoostWeight == 0 {
+		page.fetchOffset = opts.Offset
+		page.fetchLimit = opts.Limit + 1
+		return page
+	}
+	page.skip = opts.Offset
+	page.fetchLimit = opts.Offset + opts.Limit + 1
+	if page.rerank && rerankCfg.TopN > page.fetchLimit {
+		page.fetchLimit = rerankCfg.TopN
+	}
+	return page
+}
+
+// finishPage 依次执行重排序、质量分数提升、最低分数过滤、分页截取与高亮，返回当前页结果与是否还有下一页
+func (s *Knowledge) finishPage(ctx context.Context, opts *model.SearchOptions, page *searchPage, results []model.SearchResult) ([]model.SearchResult, bool) {
+	// 重排序失败时保留检索阶段的顺序
+	if page.rerank {
+		var err error
+		results, err = service.RerankResults(ctx, opts.Query, results)
+		if err != nil {
+			g.Log().Warningf(ctx, "%v, 将使用检索分数排序", err)
+		}
+	}
+
+	// 按衰减后的质量分数提升排序分数，在跳过前面的页之前调整整个召回窗口的顺序
+	results = service.ApplyQualityBoost(ctx, results)
+
+	// 结果已按分数降序排列，遇到第一个低于最低分数的结果即可截断
+	if opts.MinScore != nil {
+		for i, r := range results {
+			score := r.Score
+			if r.RerankScore != nil {
+				score = *r.RerankScore
+			}
+			if score < *opts.MinScore {
+				results = results[:i]
+				break
+			}
+		}
+	}
+
+	if uint64(len(results)) <= page.skip {
+		return nil, false
+	}
+	results = results[page.skip:]
+	hasMore := uint64(len(results)) > page.limit
+	if hasMore {
+		results = results[:page.limit]
+	}
+
+	if opts.Highlight {
+		h := service.NewHighlighter(ctx, opts.Query)
+		for i := range results {
+			results[i].Highlights = h.Highlights(results[i].Content, results[i].Summary)
+		}
+	}
+	return results, hasMore
+}
@@ -0,0 +1,148 @@
+package knowledge
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/gogf/gf/v2/frame/g"
+
+	"knowledge-system-api/internal/consts"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/service"
+)
+
+// newSearchDebug 非 standard 策略时创建中间结果，standard 策略返回 nil
+func newSearchDebug(strategy string) *model.SearchDebug {
+	if strategy == "" || strategy == consts.SearchStrategyStandard {
+		return nil
+	}
+	return &model.SearchDebug{Strategy: strategy}
+}
+
+// fallbackStandard 生成失败时回退 standard 策略，记录失败原因
+func fallbackStandard(ctx context.Context, debug *model.SearchDebug, err error) {
+	g.Log().Warningf(ctx, "%s 策略生成失败: %v, 回退 standard 策略", debug.Strategy, err)
+	debug.Strategy = consts.SearchStrategyStandard
+	debug.Error = err.Error()
+}
+
+// usesQueryLabels 融合策略是否使用查询标签（dense/lexical 策略不使用）
+func usesQueryLabels(fusion string) bool {
+	return fusion != consts.FusionDense && fusion != consts.FusionLexical
+}
+
+// applyHyDE 生成假设性答案，以答案的向量代替查询向量；查询文本保持不变，词法检索、重排序与高亮仍使用原始查询
+func applyHyDE(ctx context.Context, opts *model.SearchOptions, debug *model.SearchDebug) {
+	// lexical 策略只使用词法稀疏向量，假设性答案不起作用
+	if opts.Fusion == consts.FusionLexical {
+		debug.Strategy = consts.SearchStrategyStandard
+		return
+	}
+
+	cfg := service.LoadQueryExpansionConfig(ctx)
+	answer, err := service.HypotheticalAnswer(ctx, cfg, opts.Query)
+	if err != nil {
+		fallbackStandard(ctx, debug, err)
+		return
+	}
+	vector, err := service.Vectorize(ctx, answer)
+	if err != nil {
+		fallbackStandard(ctx, debug, err)
+		return
+	}
+	opts.Vector = vector
+	debug.HypotheticalAnswer = answer
+	g.Log().Debugf(ctx, "HyDE 假设性答案: %s", answer)
+}
+
+// multiQueryOutput 单个查询的检索结果
+type multiQueryOutput struct {
+	points     []model.VectorSearchResult
+	fusion     string
+	total      uint64
+	repoErrors []model.RepoError
+	err        error
+}
+
+// multiQuerySearch 由大模型生成多个改写查询，与原始查询分别检索后按倒数排名融合，返回融合结果中 [offset, offset+limit) 的部分
+// 改写失败时回退为只检索原始查询；total 取各查询候选总数的最大值
+func multiQuerySearch(ctx context.Context, opts *model.SearchOptions, repos []string, offset, limit uint64, debug *model.SearchDebug) (
+	points []model.VectorSearchResult, fusion string, total uint64, repoErrors []model.RepoError, err error) {
+	cfg := service.LoadQueryExpansionConfig(ctx)
+	expanded, err := service.ExpandQueries(ctx, cfg, opts.Query)
+	if err != nil {
+		fallbackStandard(ctx, debug, err)
+		return fanoutVectorSearch(ctx, opts, repos, offset, limit)
+	}
+	queries := append([]string{opts.Query}, expanded...)
+	debug.Queries = queries
+	g.Log().Debugf(ctx, "多查询检索: %v", queries)
+
+	// 融合后的第 offset 条之前可能来自任意查询，每个查询都需从第0条召回到当前页末尾
+	outputs := make([]multiQueryOutput, len(queries))
+	var wg sync.WaitGroup
+	for i, query := range queries {
+		wg.Add(1)
+		go func(i int, query string) {
+			defer wg.Done()
+			queryOpts := *opts
+			if i > 0 {
+				queryOpts.Query = query
+				// 只记录原始查询的检索过程
+				queryOpts.Labels, queryOpts.Vector, queryOpts.Explain = nil, nil, nil
+				if usesQueryLabels(opts.Fusion) {
+					queryLabels := service.AnalyzeQueryLabels(ctx, query, opts.LabelThreshold)
+					queryOpts.Labels, queryOpts.Vector = queryLabels.Labels, queryLabels.Vector
+				}
+			}
+			o := &outputs[i]
+			o.points, o.fusion, o.total, o.repoErrors, o.err = fanoutVectorSearch(ctx, &queryOpts, repos, 0, offset+limit)
+		}(i, query)
+	}
+	wg.Wait()
+
+	// 倒数排名融合：同一知识条目在各查询结果中的得分为 1/(k+排名) 之和
+	scores := make(map[string]float32)
+	var fused []model.VectorSearchResult
+	var firstErr error
+	succeeded := 0
+	for i, o := range outputs {
+		if o.err != nil {
+			g.Log().Warningf(ctx, "查询 %q 检索失败: %v", queries[i], o.err)
+			if firstErr == nil {
+				firstErr = o.err
+			}
+			continue
+		}
+		if succeeded == 0 {
+			fusion, repoErrors = o.fusion, o.repoErrors
+		}
+		succeeded++
+		total = max(total, o.total)
+		for rank, p := range o.points {
+			if _, ok := scores[p.ID]; !ok {
+				fused = append(fused, p)
+			}
+			scores[p.ID] += 1 / float32(cfg.RRFK+rank+1)
+		}
+	}
+	if succeeded == 0 {
+		return nil, "", 0, repoErrors, firstErr
+	}
+
+	for i := range fused {
+		fused[i].Score = scores[fused[i].ID]
+	}
+	sort.SliceStable(fused, func(i, j int) bool {
+		return fused[i].Score > fused[j].Score
+	})
+	if uint64(len(fused)) <= offset {
+		return nil, fusion, total, repoErrors, nil
+	}
+	fused = fused[offset:]
+	if uint64(len(fused)) > limit {
+		fused = fused[:limit]
+	}
+	return fused, fusion, total, repoErrors, nil
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/os/gctx"
 	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/util/gconv"
 	"github.com/google/uuid"
 )
 
@@ -118,6 +119,7 @@ func (s *Knowledge) CreateImportTask(ctx context.Context, items []model.TaskItem
 			sourceData, err := json.Marshal(map[string]interface{}{
 				"repo_name": item.RepoName,
 				"content":   item.Content,
+				"metadata":  item.Metadata,
 			})
 			if err != nil {
 				return err
@@ -202,6 +204,7 @@ func (s *Knowledge) GetTaskStatus(ctx context.Context, taskID string) (*model.Im
 				TaskID:       item.TaskId,
 				RepoName:     sourceData["repo_name"].(string),
 				Content:      sourceData["content"].(string),
+				Metadata:     gconv.Map(sourceData["metadata"]),
 				Status:       item.Status,
 				ErrorMessage: item.ErrorMessage,
 			})
@@ -312,6 +315,7 @@ func (s *Knowledge) processTask(ctx context.Context, taskID string) error {
 		// 准备任务项数据
 		content := itemData["content"].(string)
 		repoName := itemData["repo_name"].(string)
+		metadata := gconv.Map(itemData["metadata"])
 
 		// 更新任务状态
 		progress := uint(float64(processed+failed+uint(i)) / float64(task.Total) * 100)
@@ -328,7 +332,7 @@ func (s *Knowledge) processTask(ctx context.Context, taskID string) error {
 		}).Where(do.ImportTaskItem{Id: item.Id}).Update()
 
 		// 处理单个条目
-		err := s.processTaskItemContent(ctx, content, repoName)
+		err := s.processTaskItemContent(ctx, content, repoName, metadata)
 		if err != nil {
 			g.Log().Error(ctx, "处理任务项失败:", err, "item:", item)
 			// 更新任务条目状态为失败
@@ -375,7 +379,7 @@ func (s *Knowledge) processTask(ctx context.Context, taskID string) error {
 }
 
 // processTaskItemContent 处理单个任务条目内容
-func (s *Knowledge) processTaskItemContent(ctx context.Context, content string, repoName string) error {
+func (s *Knowledge) processTaskItemContent(ctx context.Context, content string, repoName string, metadata map[string]interface{}) error {
 	// 检查服务是否已初始化
 	if helper.LLMClassify == nil {
 		return fmt.Errorf("LLM分类服务未初始化")
@@ -385,8 +389,16 @@ func (s *Knowledge) processTaskItemContent(ctx context.Context, content string,
 		return fmt.Errorf("向量化服务未初始化")
 	}
 
-	// 1. 生成唯一ID
+	// 1. 生成唯一ID，并按知识库的字段声明转换元数据
 	id := uuid.NewString()
+	repo, err := service.Repo().Ensure(ctx, repoName)
+	if err != nil {
+		return fmt.Errorf("获取知识库失败: %w", err)
+	}
+	metadata, err = service.NormalizeMetadata(metadata, repo.MetadataSchema)
+	if err != nil {
+		return err
+	}
 
 	// 2. 调用LLM进行分类，获取标签和摘要
 	labels, summary, err := helper.LLMClassify(ctx, content)
@@ -402,13 +414,13 @@ func (s *Knowledge) processTaskItemContent(ctx context.Context, content string,
 		labelThreshold, labelCountBeforeFilter, len(labels)))
 
 	// 5. 存入向量数据库
-	if err := helper.QdrantUpsert(ctx, repoName, id, content, summary, labels); err != nil {
+	if err := helper.QdrantUpsert(ctx, repoName, id, content, summary, labels, metadata); err != nil {
 		return fmt.Errorf("保存到向量库失败: %w", err)
 	}
 
 	// 6. 存入MySQL
 	knowledgeService := service.KnowledgeService()
-	if err := knowledgeService.CreateKnowledge(ctx, id, repoName, content, labels, summary); err != nil {
+	if err := knowledgeService.CreateKnowledge(ctx, id, repoName, content, labels, summary, metadata); err != nil {
 		return fmt.Errorf("保存到MySQL失败: %w", err)
 	}
 
@@ -0,0 +1,379 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gcache"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/google/uuid"
+
+	"knowledge-system-api/internal/dao"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/model/do"
+	"knowledge-system-api/internal/model/entity"
+	"knowledge-system-api/internal/service"
+)
+
+// likeEscaper 转义 LIKE 通配符，JSON_SEARCH 按 LIKE 规则匹配，知识库名称中的 _ 需要转义
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// Repo 知识库管理服务实现
+type Repo struct {
+	// cache 知识库名称 -> *model.RepoInfo，避免每次读写都查询MySQL
+	// 缓存只在本实例内失效，按 repo.cache_ttl 过期，使其他实例的重命名与字段声明在该时间内生效
+	cache *gcache.Cache
+	// ensureLock 串行化知识库创建，避免并发导入时重复创建集合
+	ensureLock sync.Mutex
+}
+
+// New 创建知识库管理服务
+func New() *Repo {
+	return &Repo{cache: gcache.New()}
+}
+
+// cacheTTL 知识库信息的本地缓存时间，读取配置 repo.cache_ttl（秒），默认30秒
+func cacheTTL(ctx context.Context) time.Duration {
+	return time.Duration(g.Cfg().MustGet(ctx, "repo.cache_ttl", 30).Int()) * time.Second
+}
+
+// Get 获取知识库信息，不存在时返回 nil
+func (s *Repo) Get(ctx context.Context, name string) (*model.RepoInfo, error) {
+	if v, err := s.cache.Get(ctx, name); err == nil && !v.IsNil() {
+		return v.Val().(*model.RepoInfo), nil
+	}
+
+	var repo entity.KnowledgeRepo
+	err := dao.KnowledgeRepo.Ctx(ctx).Where(do.KnowledgeRepo{Name: name}).Scan(&repo)
+	if err != nil {
+		return nil, err
+	}
+	if repo.Id == "" {
+		return nil, nil
+	}
+
+	info := toRepoInfo(&repo)
+	if err := s.cache.Set(ctx, name, info, cacheTTL(ctx)); err != nil {
+		g.Log().Warningf(ctx, "缓存知识库 %s 信息失败: %v", name, err)
+	}
+	return info, nil
+}
+
+// Ensure 获取知识库信息，不存在时创建 Qdrant 集合与别名
+func (s *Repo) Ensure(ctx context.Context, name string) (*model.RepoInfo, error) {
+	if info, err := s.Get(ctx, name); err != nil || info != nil {
+		return info, err
+	}
+
+	s.ensureLock.Lock()
+	defer s.ensureLock.Unlock()
+
+	// 获取锁后再检查一次，可能已被其他协程创建
+	if info, err := s.Get(ctx, name); err != nil || info != nil {
+		return info, err
+	}
+
+	if err := service.ValidateRepoName(name); err != nil {
+		return nil, err
+	}
+
+	id := strings.ReplaceAll(uuid.NewString(), "-", "")
+	alias := "kb_" + id
+	collection := alias + "_" + gtime.Now().Format("YmdHis")
+
+	// 兼容旧数据：早期版本直接以知识库名称作为集合名称，存在且未被使用时让别名指向旧集合
+	legacy, err := legacyCollection(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if legacy {
+		g.Log().Infof(ctx, "发现以知识库名称命名的旧集合 %s，将通过别名 %s 接管", name, alias)
+		collection = name
+	} else if err := service.QdrantCreateCollection(ctx, collection); err != nil {
+		return nil, err
+	}
+
+	if err := service.QdrantCreateAlias(ctx, alias, collection); err != nil {
+		return nil, err
+	}
+
+	now := gtime.Now()
+	_, err = dao.KnowledgeRepo.Ctx(ctx).Data(do.KnowledgeRepo{
+		Id:         id,
+		Name:       name,
+		Alias:      alias,
+		Collection: collection,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}).Insert()
+	if err != nil {
+		return nil, gerror.Wrapf(err, "保存知识库映射失败: %s", name)
+	}
+
+	g.Log().Infof(ctx, "知识库 %s 已创建: id=%s, alias=%s, collection=%s", name, id, alias, collection)
+	return s.Get(ctx, name)
+}
+
+// 以下函数变量便于测试替换
+var (
+	// collectionExists 检查 Qdrant 集合是否存在
+	collectionExists = service.QdrantCollectionExists
+	// collectionInUse 检查集合是否已被某个知识库使用
+	collectionInUse = func(ctx context.Context, collection string) (bool, error) {
+		count, err := dao.KnowledgeRepo.Ctx(ctx).Where(do.KnowledgeRepo{Collection: collection}).Count()
+		return count > 0, err
+	}
+)
+
+// legacyCollection 判断是否接管以知识库名称命名的旧集合
+// 重命名不改变物理集合，旧知识库改名后集合仍以原名称命名，之后以原名称新建的知识库不能接管该集合
+func legacyCollection(ctx context.Context, name string) (bool, error) {
+	exists, err := collectionExists(ctx, name)
+	if err != nil || !exists {
+		return false, err
+	}
+	inUse, err := collectionInUse(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	return !inUse, nil
+}
+
+// List 获取所有已创建集合的知识库名称
+func (s *Repo) List(ctx context.Context) ([]string, error) {
+	var names []string
+	err := dao.KnowledgeRepo.Ctx(ctx).
+		Fields(dao.KnowledgeRepo.Columns().Name).
+		OrderAsc(dao.KnowledgeRepo.Columns().Name).
+		Scan(&names)
+	return names, err
+}
+
+// Describe 获取知识库详情，包括向量数量与条目数量
+func (s *Repo) Describe(ctx context.Context, name string) (*model.RepoInfo, error) {
+	repo, err := s.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if repo == nil {
+		return nil, nil
+	}
+
+	// 复制一份，避免修改缓存中的对象
+	info := *repo
+	info.PointsCount, err = service.QdrantCountPoints(ctx, info.Alias)
+	if err != nil {
+		g.Log().Warningf(ctx, "统计知识库 %s 向量数量失败: %v", name, err)
+	}
+	info.KnowledgeCount, err = dao.Knowledge.Ctx(ctx).Where(do.Knowledge{RepoName: name}).Count()
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// Rename 重命名知识库，Qdrant 集合与别名保持不变
+func (s *Repo) Rename(ctx context.Context, name, newName string) error {
+	if name == newName {
+		return gerror.NewCode(gcode.CodeInvalidParameter, "新名称不能与原名称相同")
+	}
+	if err := service.ValidateRepoName(newName); err != nil {
+		return gerror.WrapCode(gcode.CodeInvalidParameter, err)
+	}
+
+	repo, err := s.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+	if repo == nil {
+		return gerror.NewCodef(gcode.CodeNotFound, "知识库不存在: %s", name)
+	}
+
+	exists, err := s.Get(ctx, newName)
+	if err != nil {
+		return err
+	}
+	if exists != nil {
+		return gerror.NewCodef(gcode.CodeInvalidParameter, "知识库已存在: %s", newName)
+	}
+
+	err = dao.KnowledgeRepo.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		if _, err := dao.KnowledgeRepo.Ctx(ctx).
+			Data(do.KnowledgeRepo{Name: newName, UpdatedAt: gtime.Now()}).
+			Where(do.KnowledgeRepo{Id: repo.ID}).
+			Update(); err != nil {
+			return err
+		}
+		if _, err := dao.Knowledge.Ctx(ctx).
+			Data(do.Knowledge{RepoName: newName}).
+			Where(do.Knowledge{RepoName: name}).
+			Update(); err != nil {
+			return err
+		}
+		if _, err := dao.EvalSet.Ctx(ctx).
+			Data(do.EvalSet{RepoName: newName}).
+			Where(do.EvalSet{RepoName: name}).
+			Update(); err != nil {
+			return err
+		}
+		// 展示记录、查询反馈与检索记录按名称关联知识库，一并修改，避免统计与评测数据按旧名称分裂
+		if _, err := dao.SearchImpression.Ctx(ctx).
+			Data(do.SearchImpression{RepoName: newName}).
+			Where(do.SearchImpression{RepoName: name}).
+			Update(); err != nil {
+			return err
+		}
+		if _, err := dao.QueryFeedback.Ctx(ctx).
+			Data(do.QueryFeedback{RepoName: newName}).
+			Where(do.QueryFeedback{RepoName: name}).
+			Update(); err != nil {
+			return err
+		}
+		if _, err := dao.SearchLog.Ctx(ctx).
+			Data("repo_names = JSON_REPLACE(repo_names, JSON_UNQUOTE(JSON_SEARCH(repo_names, 'one', ?)), ?)", likeEscaper.Replace(name), newName).
+			Where("JSON_CONTAINS(repo_names, JSON_QUOTE(?))", name).
+			Update(); err != nil {
+			return err
+		}
+		// 尚未处理的导入条目中也记录了知识库名称，一并修改，避免重命名后又创建旧名称的知识库
+		_, err := dao.ImportTaskItem.Ctx(ctx).
+			Data("source_data = JSON_SET(source_data, '$.repo_name', ?)", newName).
+			Where("status IN (?)", []string{"pending", "processing"}).
+			Where("JSON_UNQUOTE(JSON_EXTRACT(source_data, '$.repo_name')) = ?", name).
+			Update()
+		return err
+	})
+	if err != nil {
+		return gerror.Wrapf(err, "重命名知识库失败: %s -> %s", name, newName)
+	}
+
+	if _, err := s.cache.Remove(ctx, name, newName); err != nil {
+		g.Log().Warningf(ctx, "清除知识库缓存失败: %v", err)
+	}
+	service.InvalidateSearchCache(ctx, name, newName)
+	g.Log().Infof(ctx, "知识库已重命名: %s -> %s (id=%s)", name, newName, repo.ID)
+	return nil
+}
+
+// LexicalStats 获取知识库词法索引统计，统计值频繁变化，不走缓存
+func (s *Repo) LexicalStats(ctx context.Context, name string) (docCount, tokenCount uint64, err error) {
+	var repo entity.KnowledgeRepo
+	err = dao.KnowledgeRepo.Ctx(ctx).
+		Fields(dao.KnowledgeRepo.Columns().DocCount, dao.KnowledgeRepo.Columns().TokenCount).
+		Where(do.KnowledgeRepo{Name: name}).
+		Scan(&repo)
+	if err != nil {
+		return 0, 0, err
+	}
+	return repo.DocCount, repo.TokenCount, nil
+}
+
+// AddLexicalStats 按增量调整知识库词法索引统计，增量可以为负，调整后不小于0
+func (s *Repo) AddLexicalStats(ctx context.Context, name string, docCount, tokenCount int64) error {
+	columns := dao.KnowledgeRepo.Columns()
+	_, err := dao.KnowledgeRepo.Ctx(ctx).
+		Data(g.Map{
+			columns.DocCount:   addCount(columns.DocCount, docCount),
+			columns.TokenCount: addCount(columns.TokenCount, tokenCount),
+		}).
+		Where(do.KnowledgeRepo{Name: name}).
+		Update()
+	return err
+}
+
+// addCount 无符号计数列加上增量的 SQL 表达式，减少时不低于0
+func addCount(column string, delta int64) gdb.Raw {
+	if delta >= 0 {
+		return gdb.Raw(column + "+" + gconv.String(delta))
+	}
+	n := gconv.String(-delta)
+	return gdb.Raw("IF(" + column + ">" + n + "," + column + "-" + n + ",0)")
+}
+
+// AddMetadataFields 追加元数据字段声明并创建 Qdrant payload 索引，已声明字段的类型不能修改
+// 声明只影响之后导入的条目，已导入条目的元数据不会按新类型重新转换
+func (s *Repo) AddMetadataFields(ctx context.Context, name string, fields []model.MetadataField) (*model.RepoInfo, error) {
+	seen := make(map[string]bool)
+	for _, f := range fields {
+		if err := service.ValidateMetadataKey(f.Key); err != nil {
+			return nil, err
+		}
+		if !service.IsValidMetadataType(f.Type) {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter, "元数据字段 %s 的类型不合法: %s", f.Key, f.Type)
+		}
+		if seen[f.Key] {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter, "元数据字段重复声明: %s", f.Key)
+		}
+		seen[f.Key] = true
+	}
+
+	repo, err := s.Ensure(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := append([]model.MetadataField{}, repo.MetadataSchema...)
+	var added []model.MetadataField
+	for _, f := range fields {
+		switch typ := service.MetadataFieldType(schema, f.Key); typ {
+		case "":
+			added = append(added, f)
+		case f.Type:
+		default:
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter, "元数据字段 %s 已声明为 %s 类型，不能修改为 %s", f.Key, typ, f.Type)
+		}
+	}
+	if len(added) == 0 {
+		return repo, nil
+	}
+
+	if err := service.QdrantCreateMetadataIndexes(ctx, repo.Alias, added); err != nil {
+		return nil, err
+	}
+	schema = append(schema, added...)
+	schemaJson, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	_, err = dao.KnowledgeRepo.Ctx(ctx).
+		Data(do.KnowledgeRepo{MetadataSchema: string(schemaJson), UpdatedAt: gtime.Now()}).
+		Where(do.KnowledgeRepo{Id: repo.ID}).
+		Update()
+	if err != nil {
+		return nil, gerror.Wrapf(err, "保存元数据字段声明失败: %s", name)
+	}
+
+	if _, err := s.cache.Remove(ctx, name); err != nil {
+		g.Log().Warningf(ctx, "清除知识库缓存失败: %v", err)
+	}
+	g.Log().Infof(ctx, "知识库 %s 新增元数据字段: %v", name, added)
+	return s.Get(ctx, name)
+}
+
+// toRepoInfo 转换为业务模型
+func toRepoInfo(repo *entity.KnowledgeRepo) *model.RepoInfo {
+	info := &model.RepoInfo{
+		ID:         repo.Id,
+		Name:       repo.Name,
+		Alias:      repo.Alias,
+		Collection: repo.Collection,
+		DocCount:   repo.DocCount,
+		TokenCount: repo.TokenCount,
+		CreatedAt:  repo.CreatedAt,
+		UpdatedAt:  repo.UpdatedAt,
+	}
+	if repo.MetadataSchema != "" {
+		if err := json.Unmarshal([]byte(repo.MetadataSchema), &info.MetadataSchema); err != nil {
+			g.Log().Warningf(context.Background(), "解析知识库 %s 的元数据字段声明失败: %v", repo.Name, err)
+		}
+	}
+	return info
+}
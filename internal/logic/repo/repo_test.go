@@ -0,0 +1,54 @@
+package repo
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLegacyCollection(t *testing.T) {
+	// collections 为 Qdrant 中的集合，repos 为 knowledge_repo 中知识库名称 -> 集合
+	collections := map[string]bool{"foo": true}
+	repos := map[string]string{}
+	collectionExists = func(_ context.Context, name string) (bool, error) {
+		return collections[name], nil
+	}
+	collectionInUse = func(_ context.Context, collection string) (bool, error) {
+		for _, c := range repos {
+			if c == collection {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	ctx := context.Background()
+	steps := []struct {
+		name string
+		run  func() // 检查前执行的操作
+		repo string
+		want bool
+	}{
+		{name: "没有旧集合", repo: "baz", want: false},
+		{name: "首次创建时接管旧集合", repo: "foo", want: true},
+		{name: "接管后不能被其他知识库再次接管", run: func() { repos["foo"] = "foo" }, repo: "foo", want: false},
+		{
+			name: "重命名后以原名称重新创建时不接管",
+			run:  func() { repos["bar"] = repos["foo"]; delete(repos, "foo") },
+			repo: "foo", want: false,
+		},
+	}
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			if step.run != nil {
+				step.run()
+			}
+			got, err := legacyCollection(ctx, step.repo)
+			if err != nil {
+				t.Fatalf("legacyCollection(%q) error = %v", step.repo, err)
+			}
+			if got != step.want {
+				t.Errorf("legacyCollection(%q) = %v, want %v", step.repo, got, step.want)
+			}
+		})
+	}
+}
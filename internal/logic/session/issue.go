@@ -0,0 +1,56 @@
+package session
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/google/uuid"
+
+	"knowledge-system-api/internal/dao"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/model/do"
+	"knowledge-system-api/internal/model/entity"
+	"knowledge-system-api/internal/service"
+)
+
+// Create 签发新的会话ID，按 session.ttl_days 设置过期时间
+func (s *Session) Create(ctx context.Context) (*model.UserSession, error) {
+	now := gtime.Now()
+	session := &model.UserSession{ID: uuid.New().String(), CreatedAt: now}
+	data := do.UserSession{Id: session.ID, CreatedAt: now}
+	if days := service.LoadSessionConfig(ctx).TTLDays; days > 0 {
+		session.ExpiresAt = now.AddDate(0, 0, days)
+		data.ExpiresAt = session.ExpiresAt
+	}
+	if _, err := dao.UserSession.Ctx(ctx).Data(data).Insert(); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// findUserSession 按ID查询签发的会话，不存在时返回 nil，便于测试替换
+var findUserSession = func(ctx context.Context, sessionID string) (*entity.UserSession, error) {
+	var session *entity.UserSession
+	err := dao.UserSession.Ctx(ctx).Where(do.UserSession{Id: sessionID}).Scan(&session)
+	return session, err
+}
+
+// Validate 校验会话ID是否由 Create 签发且未过期
+func (s *Session) Validate(ctx context.Context, sessionID string) error {
+	if !service.LoadSessionConfig(ctx).Validate {
+		return nil
+	}
+	session, err := findUserSession(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil {
+		return gerror.NewCodef(gcode.CodeInvalidParameter, "会话ID不存在: %s，请通过 POST /session 获取会话ID", sessionID)
+	}
+	if session.ExpiresAt != nil && session.ExpiresAt.Before(gtime.Now()) {
+		return gerror.NewCodef(gcode.CodeInvalidParameter, "会话已过期: %s，请通过 POST /session 获取新的会话ID", sessionID)
+	}
+	return nil
+}
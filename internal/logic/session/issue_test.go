@@ -0,0 +1,62 @@
+package session
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gcfg"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"knowledge-system-api/internal/model/entity"
+)
+
+func TestSessionValidate(t *testing.T) {
+	// issued 为已签发的会话ID -> 过期时间
+	issued := map[string]*gtime.Time{
+		"issued":  nil,
+		"valid":   gtime.Now().AddDate(0, 0, 1),
+		"expired": gtime.Now().AddDate(0, 0, -1),
+	}
+	findUserSession = func(_ context.Context, sessionID string) (*entity.UserSession, error) {
+		expiresAt, ok := issued[sessionID]
+		if !ok {
+			return nil, nil
+		}
+		return &entity.UserSession{Id: sessionID, ExpiresAt: expiresAt}, nil
+	}
+	defer g.Cfg().SetAdapter(g.Cfg().GetAdapter())
+
+	tests := []struct {
+		name      string
+		config    string
+		sessionID string
+		wantErr   bool
+	}{
+		{name: "默认开启校验时拒绝自行生成的会话ID", config: "session:\n  ttl_days: 30", sessionID: "made-up", wantErr: true},
+		{name: "开启校验时拒绝自行生成的会话ID", config: "session:\n  validate: true", sessionID: "made-up", wantErr: true},
+		{name: "开启校验时接受签发的会话ID", config: "session:\n  validate: true", sessionID: "issued"},
+		{name: "开启校验时接受未过期的会话ID", config: "session:\n  validate: true", sessionID: "valid"},
+		{name: "开启校验时拒绝已过期的会话ID", config: "session:\n  validate: true", sessionID: "expired", wantErr: true},
+		{name: "关闭校验时接受自行生成的会话ID", config: "session:\n  validate: false", sessionID: "made-up"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter, err := gcfg.NewAdapterContent(tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			g.Cfg().SetAdapter(adapter)
+
+			err = New().Validate(context.Background(), tt.sessionID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate(%q) error = %v, wantErr %v", tt.sessionID, err, tt.wantErr)
+			}
+			if err != nil && gerror.Code(err) != gcode.CodeInvalidParameter {
+				t.Errorf("Validate(%q) code = %v, want %v", tt.sessionID, gerror.Code(err), gcode.CodeInvalidParameter)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"knowledge-system-api/internal/dao"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/model/do"
+	"knowledge-system-api/internal/model/entity"
+	"knowledge-system-api/internal/service"
+)
+
+// Session 多轮会话服务实现，会话轮次保存在 session_turn 表
+type Session struct{}
+
+// New 创建多轮会话服务
+func New() *Session {
+	return &Session{}
+}
+
+// Rewrite 结合会话最近几轮的查询改写追问
+// 与上一轮原始查询相同时（翻页、重试）沿用上一轮的改写结果；改写失败或超时时使用原始查询
+func (s *Session) Rewrite(ctx context.Context, sessionID, query string) (*model.QueryRewrite, error) {
+	result := &model.QueryRewrite{Query: query, RewrittenQuery: query}
+	cfg := service.LoadSessionConfig(ctx)
+	if cfg.HistoryTurns <= 0 {
+		return result, nil
+	}
+
+	history, err := s.RecentTurns(ctx, sessionID, cfg.HistoryTurns)
+	if err != nil {
+		return nil, err
+	}
+	if len(history) == 0 {
+		return result, nil
+	}
+
+	last := history[len(history)-1]
+	if last.Query == query {
+		result.RewrittenQuery = last.RewrittenQuery
+		result.Repeated = true
+		result.TurnID = last.ID
+		return result, nil
+	}
+	if !cfg.Rewrite {
+		return result, nil
+	}
+
+	rewritten, err := service.RewriteQuery(ctx, cfg, history, query)
+	if err != nil {
+		g.Log().Warningf(ctx, "改写会话 %s 的查询失败: %v, 使用原始查询", sessionID, err)
+		return result, nil
+	}
+	g.Log().Debugf(ctx, "会话 %s 查询改写: %s -> %s", sessionID, query, rewritten)
+	result.RewrittenQuery = rewritten
+	return result, nil
+}
+
+// AppendTurn 记录一轮检索
+func (s *Session) AppendTurn(ctx context.Context, sessionID, query, rewrittenQuery string, knowledgeIDs []string) (uint64, error) {
+	if knowledgeIDs == nil {
+		knowledgeIDs = []string{}
+	}
+	idsJson, err := json.Marshal(knowledgeIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := dao.SessionTurn.Ctx(ctx).Data(do.SessionTurn{
+		SessionId:      sessionID,
+		Query:          query,
+		RewrittenQuery: rewrittenQuery,
+		KnowledgeIds:   string(idsJson),
+		CreatedAt:      gtime.Now(),
+	}).InsertAndGetId()
+	if err != nil {
+		return 0, err
+	}
+	return uint64(id), nil
+}
+
+// RecentTurns 按时间顺序返回会话最近的 limit 轮
+func (s *Session) RecentTurns(ctx context.Context, sessionID string, limit int) ([]model.SessionTurn, error) {
+	var rows []entity.SessionTurn
+	err := dao.SessionTurn.Ctx(ctx).
+		Where(do.SessionTurn{SessionId: sessionID}).
+		OrderDesc(dao.SessionTurn.Columns().Id).
+		Limit(limit).
+		Scan(&rows)
+	if err != nil {
+		return nil, err
+	}
+
+	turns := make([]model.SessionTurn, len(rows))
+	for i, row := range rows {
+		var ids []string
+		if row.KnowledgeIds != "" {
+			if err := json.Unmarshal([]byte(row.KnowledgeIds), &ids); err != nil {
+				g.Log().Warning(ctx, "解析会话轮次知识ID失败", err)
+			}
+		}
+		// 查询结果为倒序，转换为时间顺序
+		turns[len(rows)-1-i] = model.SessionTurn{
+			ID:             row.Id,
+			SessionID:      row.SessionId,
+			Query:          row.Query,
+			RewrittenQuery: row.RewrittenQuery,
+			KnowledgeIDs:   ids,
+			CreatedAt:      row.CreatedAt,
+		}
+	}
+	return turns, nil
+}
+
+// FindTurn 查找会话中返回过指定知识的最近一轮，没有这样的轮次时返回0
+func (s *Session) FindTurn(ctx context.Context, sessionID, knowledgeID string) (uint64, error) {
+	value, err := dao.SessionTurn.Ctx(ctx).
+		Fields(dao.SessionTurn.Columns().Id).
+		Where(do.SessionTurn{SessionId: sessionID}).
+		Where("JSON_CONTAINS(knowledge_ids, JSON_QUOTE(?))", knowledgeID).
+		OrderDesc(dao.SessionTurn.Columns().Id).
+		Value()
+	if err != nil {
+		return 0, err
+	}
+	return value.Uint64(), nil
+}
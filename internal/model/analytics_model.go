@@ -0,0 +1,56 @@
+package model
+
+import "github.com/gogf/gf/v2/os/gtime"
+
+// ContentGapOptions 内容缺口报告的范围
+type ContentGapOptions struct {
+	RepoName  string      `json:"repo_name"`  // 知识库名称，为空时处理全部知识库
+	StartTime *gtime.Time `json:"start_time"` // 时间起（含），为空时不限
+	EndTime   *gtime.Time `json:"end_time"`   // 时间止（含），为空时不限
+	Limit     int         `json:"limit"`      // 返回的主题数量，为0时使用配置
+}
+
+// ContentGapReport 内容缺口报告：未被满足的查询按语义聚类后的主题，按出现次数从多到少排列
+type ContentGapReport struct {
+	Signals  int          `json:"signals"`  // 参与统计的信号数量（点踩、无相关结果反馈等）
+	Queries  int          `json:"queries"`  // 归一化去重后的查询数量
+	Skipped  int          `json:"skipped"`  // 超过数量上限或向量化失败而未参与聚类的查询数量
+	Clusters []GapCluster `json:"clusters"` // 主题列表
+}
+
+// GapCluster 语义相近的一组未被满足的查询
+type GapCluster struct {
+	Label    string     `json:"label"`     // 大模型生成的主题名称，生成失败时为出现次数最多的查询
+	RepoName string     `json:"repo_name"` // 知识库名称，跨知识库或未知时为空
+	Count    int        `json:"count"`     // 组内信号数量之和
+	Signals  GapSignals `json:"signals"`   // 按类型统计的信号数量
+	Queries  []GapQuery `json:"queries"`   // 组内的查询，按出现次数从多到少排列
+}
+
+// GapQuery 未被满足的查询
+type GapQuery struct {
+	Query   string     `json:"query"`   // 出现次数最多的原始写法
+	Count   int        `json:"count"`   // 信号数量
+	Signals GapSignals `json:"signals"` // 按类型统计的信号数量
+}
+
+// GapSignals 查询未被满足的信号
+type GapSignals struct {
+	Dislikes   int `json:"dislikes"`    // 点踩多于点赞的查询上的点踩数
+	NoRelevant int `json:"no_relevant"` // “没有相关结果”的查询反馈数
+	ZeroResult int `json:"zero_result"` // 没有检索结果的次数
+	LowScore   int `json:"low_score"`   // 结果分数低于阈值的次数
+}
+
+// Add 累加信号数量
+func (s *GapSignals) Add(other GapSignals) {
+	s.Dislikes += other.Dislikes
+	s.NoRelevant += other.NoRelevant
+	s.ZeroResult += other.ZeroResult
+	s.LowScore += other.LowScore
+}
+
+// Total 信号总数
+func (s GapSignals) Total() int {
+	return s.Dislikes + s.NoRelevant + s.ZeroResult + s.LowScore
+}
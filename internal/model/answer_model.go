@@ -0,0 +1,36 @@
+package model
+
+// AnswerOptions 答案生成参数
+type AnswerOptions struct {
+	Search        SearchOptions `json:"search"`         // 检索参数
+	Mode          string        `json:"mode"`           // 检索模式：keyword/semantic/hybrid/lexical
+	ContextTokens int           `json:"context_tokens"` // 上下文的 token 预算，为0时使用配置
+}
+
+// AnswerContext 参与答案生成的知识条目，Index 为提示词与引用中使用的编号
+type AnswerContext struct {
+	Index    int     `json:"index"`     // 引用编号，从1开始
+	ID       string  `json:"id"`        // 知识条目ID
+	RepoName string  `json:"repo_name"` // 知识库名称
+	Summary  string  `json:"summary"`   // 摘要
+	Score    float32 `json:"score"`     // 检索分数
+	Tokens   int     `json:"tokens"`    // 在上下文中占用的估算 token 数
+}
+
+// Citation 答案中的引用
+type Citation struct {
+	Index    int    `json:"index"`     // 答案中的引用编号，即 [n] 中的 n
+	ID       string `json:"id"`        // 知识条目ID
+	RepoName string `json:"repo_name"` // 知识库名称
+}
+
+// AnswerOutput 答案生成结果
+type AnswerOutput struct {
+	Answer    string          `json:"answer"`    // 生成的答案，引用以 [n] 形式内嵌
+	Citations []Citation      `json:"citations"` // 答案中实际出现的引用，按首次出现的顺序
+	Contexts  []AnswerContext `json:"contexts"`  // 放入上下文的知识条目
+	Fusion    string          `json:"fusion"`    // 检索使用的融合策略
+	Results   []SearchResult  `json:"-"`         // 检索结果，包含未放入上下文的条目
+	Total     uint64          `json:"-"`         // 检索结果总数
+	Timings   []StageTiming   `json:"timings"`   // 检索各阶段与生成答案的耗时
+}
@@ -13,9 +13,12 @@ import (
 type Feedback struct {
 	g.Meta               `orm:"table:feedback, do:true"`
 	Id                   interface{} // 主键ID
-	SessionId            interface{} // 用户会话ID
+	SessionId            interface{} // 用户会话ID，匿名反馈时为空
 	UserQuery            interface{} // 用户查询内容
 	RetrievedKnowledgeId interface{} // 被检索到的知识ID
 	Action               interface{} // 反馈操作类型
+	TurnId               interface{} // 关联的会话轮次ID
+	SearchId             interface{} // 产生该反馈的检索ID
+	Anonymous            interface{} // 是否匿名反馈（没有会话ID）
 	Timestamp            *gtime.Time // 反馈时间
 }
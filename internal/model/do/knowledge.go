@@ -17,6 +17,7 @@ type Knowledge struct {
 	Content   interface{} // 知识内容
 	Labels    interface{} // 标签分数数组
 	Summary   interface{} // 内容摘要
+	Metadata  interface{} // 用户自定义元数据
 	CreatedAt *gtime.Time // 创建时间
 	UpdatedAt *gtime.Time // 更新时间
 }
@@ -10,10 +10,13 @@ import (
 
 // Feedback is the golang structure for table feedback.
 type Feedback struct {
-	Id                   uint64      `json:"id"                   orm:"id"                     description:"主键ID"`      // 主键ID
-	SessionId            string      `json:"sessionId"            orm:"session_id"             description:"用户会话ID"`    // 用户会话ID
-	UserQuery            string      `json:"userQuery"            orm:"user_query"             description:"用户查询内容"`    // 用户查询内容
-	RetrievedKnowledgeId string      `json:"retrievedKnowledgeId" orm:"retrieved_knowledge_id" description:"被检索到的知识ID"` // 被检索到的知识ID
-	Action               string      `json:"action"               orm:"action"                 description:"反馈操作类型"`    // 反馈操作类型
-	Timestamp            *gtime.Time `json:"timestamp"            orm:"timestamp"              description:"反馈时间"`      // 反馈时间
+	Id                   uint64      `json:"id"                   orm:"id"                     description:"主键ID"`           // 主键ID
+	SessionId            string      `json:"sessionId"            orm:"session_id"             description:"用户会话ID，匿名反馈时为空"` // 用户会话ID，匿名反馈时为空
+	UserQuery            string      `json:"userQuery"            orm:"user_query"             description:"用户查询内容"`         // 用户查询内容
+	RetrievedKnowledgeId string      `json:"retrievedKnowledgeId" orm:"retrieved_knowledge_id" description:"被检索到的知识ID"`      // 被检索到的知识ID
+	Action               string      `json:"action"               orm:"action"                 description:"反馈操作类型"`         // 反馈操作类型
+	TurnId               uint64      `json:"turnId"               orm:"turn_id"                description:"关联的会话轮次ID"`      // 关联的会话轮次ID
+	SearchId             string      `json:"searchId"             orm:"search_id"              description:"产生该反馈的检索ID"`     // 产生该反馈的检索ID
+	Anonymous            int         `json:"anonymous"            orm:"anonymous"              description:"是否匿名反馈（没有会话ID）"` // 是否匿名反馈（没有会话ID）
+	Timestamp            *gtime.Time `json:"timestamp"            orm:"timestamp"              description:"反馈时间"`           // 反馈时间
 }
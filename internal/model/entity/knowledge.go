@@ -15,6 +15,7 @@ type Knowledge struct {
 	Content   string      `json:"content"   orm:"content"    description:"知识内容"`           // 知识内容
 	Labels    string      `json:"labels"    orm:"labels"     description:"标签分数数组"`         // 标签分数数组
 	Summary   string      `json:"summary"   orm:"summary"    description:"内容摘要"`           // 内容摘要
+	Metadata  string      `json:"metadata"  orm:"metadata"   description:"用户自定义元数据"`       // 用户自定义元数据
 	CreatedAt *gtime.Time `json:"createdAt" orm:"created_at" description:"创建时间"`           // 创建时间
 	UpdatedAt *gtime.Time `json:"updatedAt" orm:"updated_at" description:"更新时间"`           // 更新时间
 }
@@ -0,0 +1,125 @@
+package model
+
+import "github.com/gogf/gf/v2/os/gtime"
+
+// EvalSet 检索评测集
+type EvalSet struct {
+	ID          uint64      `json:"id"`          // 评测集ID
+	RepoName    string      `json:"repo_name"`   // 知识库名称
+	Name        string      `json:"name"`        // 评测集名称，同一知识库内唯一
+	Description string      `json:"description"` // 评测集说明
+	QueryCount  int         `json:"query_count"` // 查询数量
+	CreatedAt   *gtime.Time `json:"created_at"`  // 创建时间
+	UpdatedAt   *gtime.Time `json:"updated_at"`  // 更新时间
+}
+
+// EvalQuery 带相关性标注的评测查询
+type EvalQuery struct {
+	ID        uint64         `json:"id"`        // 查询ID
+	Query     string         `json:"query"`     // 查询内容
+	Judgments []EvalJudgment `json:"judgments"` // 相关知识及其相关度等级
+}
+
+// EvalJudgment 相关性标注
+type EvalJudgment struct {
+	ID    string `json:"id"`    // 知识条目ID
+	Grade int    `json:"grade"` // 相关度等级，越高越相关，0 表示不相关
+}
+
+// EvalConfig 评测使用的检索配置，未设置的项使用当前配置文件的值
+type EvalConfig struct {
+	Name               string   `json:"name"`                          // 配置名称，用于在报告中区分
+	Mode               string   `json:"mode"`                          // 检索模式，默认 hybrid
+	Fusion             string   `json:"fusion,omitempty"`              // 融合策略
+	Alpha              *float32 `json:"alpha,omitempty"`               // weighted 策略的密集向量权重
+	Beta               *float32 `json:"beta,omitempty"`                // weighted 策略的标签稀疏向量权重
+	Rerank             *bool    `json:"rerank,omitempty"`              // 是否重排序
+	Strategy           string   `json:"strategy,omitempty"`            // 检索策略
+	LabelThreshold     *float32 `json:"label_threshold,omitempty"`     // 查询标签阈值
+	PrefetchMultiplier *uint64  `json:"prefetch_multiplier,omitempty"` // 预查询候选数量倍数
+}
+
+// EvalReport 单个检索配置在评测集上的评测结果，指标为各查询的平均值
+type EvalReport struct {
+	SetID    uint64            `json:"set_id"`    // 评测集ID
+	SetName  string            `json:"set_name"`  // 评测集名称
+	RepoName string            `json:"repo_name"` // 知识库名称
+	Config   EvalConfig        `json:"config"`    // 检索配置
+	K        int               `json:"k"`         // 评测的结果数量
+	Queries  int               `json:"queries"`   // 查询数量
+	Failed   int               `json:"failed"`    // 检索失败的查询数量，不计入指标
+	Recall   float64           `json:"recall"`    // recall@k
+	MRR      float64           `json:"mrr"`       // 平均倒数排名
+	NDCG     float64           `json:"ndcg"`      // nDCG@k
+	Latency  EvalLatency       `json:"latency"`   // 检索耗时
+	Details  []EvalQueryResult `json:"details"`   // 各查询的评测结果
+}
+
+// EvalLatency 检索耗时统计（毫秒）
+type EvalLatency struct {
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P95  float64 `json:"p95"`
+	Max  float64 `json:"max"`
+}
+
+// EvalQueryResult 单个查询的评测结果
+type EvalQueryResult struct {
+	QueryID   uint64   `json:"query_id"`        // 查询ID
+	Query     string   `json:"query"`           // 查询内容
+	Recall    float64  `json:"recall"`          // recall@k
+	RR        float64  `json:"rr"`              // 第一个相关结果排名的倒数，前 k 条没有相关结果时为0
+	NDCG      float64  `json:"ndcg"`            // nDCG@k
+	LatencyMs float64  `json:"latency_ms"`      // 检索耗时（毫秒）
+	Retrieved []string `json:"retrieved"`       // 检索到的前 k 条知识ID
+	Error     string   `json:"error,omitempty"` // 检索失败的原因
+}
+
+// EvalComparison 两个检索配置在同一评测集上的对比
+type EvalComparison struct {
+	Baseline  *EvalReport      `json:"baseline"`  // 基准配置的评测结果
+	Candidate *EvalReport      `json:"candidate"` // 候选配置的评测结果
+	Delta     EvalDelta        `json:"delta"`     // 候选配置减去基准配置的指标差值
+	Changed   []EvalQueryDelta `json:"changed"`   // nDCG 有变化的查询，按变化量从差到好排列
+}
+
+// EvalDelta 指标差值
+type EvalDelta struct {
+	Recall     float64 `json:"recall"`
+	MRR        float64 `json:"mrr"`
+	NDCG       float64 `json:"ndcg"`
+	LatencyP50 float64 `json:"latency_p50"`
+	LatencyP95 float64 `json:"latency_p95"`
+}
+
+// EvalQueryDelta 单个查询在两个配置下的 nDCG
+type EvalQueryDelta struct {
+	QueryID   uint64  `json:"query_id"`
+	Query     string  `json:"query"`
+	Baseline  float64 `json:"baseline"`
+	Candidate float64 `json:"candidate"`
+	Delta     float64 `json:"delta"`
+}
+
+// FeedbackDatasetOptions 由反馈生成评测数据的范围
+type FeedbackDatasetOptions struct {
+	RepoName  string      `json:"repo_name"`  // 知识库名称，为空时处理全部知识库
+	StartTime *gtime.Time `json:"start_time"` // 反馈时间起（含），为空时不限
+	EndTime   *gtime.Time `json:"end_time"`   // 反馈时间止（含），为空时不限
+}
+
+// FeedbackDataset 由反馈生成的相关性标注
+type FeedbackDataset struct {
+	Feedbacks int             `json:"feedbacks"` // 参与统计的反馈数量
+	Skipped   int             `json:"skipped"`   // 没有正向标注而被丢弃的查询数量
+	Queries   []FeedbackQuery `json:"queries"`   // 归一化去重后的查询，按知识库、反馈数量排列
+}
+
+// FeedbackQuery 归一化后相同的一组查询及其相关性标注
+type FeedbackQuery struct {
+	RepoName  string         `json:"repo_name"` // 知识库名称
+	Query     string         `json:"query"`     // 出现次数最多的原始写法
+	Variants  int            `json:"variants"`  // 合并的不同写法数量
+	Feedbacks int            `json:"feedbacks"` // 去重后的反馈数量
+	Judgments []EvalJudgment `json:"judgments"` // 相关性标注，相关度为0的是以点踩为主的负例
+}
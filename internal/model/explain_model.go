@@ -0,0 +1,84 @@
+package model
+
+import (
+	"sync"
+	"time"
+)
+
+// SearchExplain 检索过程说明，用于排查结果排序的原因
+// 跨知识库检索时各知识库并行写入 Repos，需通过 AddRepo 追加
+type SearchExplain struct {
+	LabelSource     string        `json:"label_source"`     // 查询标签来源：centroid/llm/none
+	LabelConfidence float32       `json:"label_confidence"` // 最近质心分类的置信度
+	QueryLabels     []LabelScore  `json:"query_labels"`     // 分析得到的查询标签
+	MappedLabels    []MappedLabel `json:"mapped_labels"`    // 在标签字典中找到的标签，组成标签稀疏向量
+	DroppedLabels   []LabelScore  `json:"dropped_labels"`   // 标签字典中不存在而被忽略的标签
+	SparseVector    SparseVector  `json:"sparse_vector"`    // 发送给 Qdrant 的标签稀疏向量
+	LexicalVector   SparseVector  `json:"lexical_vector"`   // 发送给 Qdrant 的词法稀疏向量
+	Repos           []RepoExplain `json:"repos"`            // 各知识库的检索过程
+	Items           []ItemExplain `json:"items"`            // 当前页结果的各路分数
+	Timings         []StageTiming `json:"timings"`          // 各阶段耗时
+	mu              sync.Mutex
+}
+
+// MappedLabel 在标签字典中找到的查询标签
+type MappedLabel struct {
+	Name  string  `json:"name"`  // 标签名称
+	ID    uint32  `json:"id"`    // 稀疏向量中的维度
+	Score float32 `json:"score"` // 标签分数，即稀疏向量中该维度的值
+}
+
+// SparseVector 稀疏向量
+type SparseVector struct {
+	Indices []uint32  `json:"indices"`
+	Values  []float32 `json:"values"`
+}
+
+// RepoExplain 单个知识库的检索过程
+type RepoExplain struct {
+	RepoName   string             `json:"repo_name"`  // 知识库名称
+	Fusion     string             `json:"fusion"`     // 实际使用的融合策略
+	Candidates []CandidateExplain `json:"candidates"` // 标签稀疏向量预查询的候选与最终结果，按预查询顺序排列
+	Timings    []StageTiming      `json:"timings"`    // 各阶段耗时
+}
+
+// CandidateExplain 候选在各路向量上的分数，未被该路召回时为空
+type CandidateExplain struct {
+	ID           string   `json:"id"`                      // 知识条目ID
+	Prefetched   bool     `json:"prefetched"`              // 是否在标签稀疏向量预查询的候选中
+	Returned     bool     `json:"returned"`                // 是否在该知识库的检索结果中
+	Score        float32  `json:"score"`                   // 检索结果中的融合分数，未返回时为0
+	DenseScore   *float32 `json:"dense_score,omitempty"`   // 正文密集向量分数
+	SparseScore  *float32 `json:"sparse_score,omitempty"`  // 标签稀疏向量分数
+	LexicalScore *float32 `json:"lexical_score,omitempty"` // 词法稀疏向量分数
+}
+
+// ItemExplain 当前页结果的各路分数
+type ItemExplain struct {
+	ID           string   `json:"id"`                      // 知识条目ID
+	RepoName     string   `json:"repo_name"`               // 知识库名称
+	Score        float32  `json:"score"`                   // 检索阶段分数，未重排序时已按质量分数提升
+	DenseScore   *float32 `json:"dense_score,omitempty"`   // 正文密集向量分数
+	SparseScore  *float32 `json:"sparse_score,omitempty"`  // 标签稀疏向量分数
+	LexicalScore *float32 `json:"lexical_score,omitempty"` // 词法稀疏向量分数
+	RerankScore  *float32 `json:"rerank_score,omitempty"`  // 重排序分数（已按质量分数提升）
+	Quality      *float32 `json:"quality,omitempty"`       // 衰减后的质量分数
+}
+
+// StageTiming 阶段耗时
+type StageTiming struct {
+	Stage string  `json:"stage"` // 阶段名称
+	Ms    float64 `json:"ms"`    // 耗时（毫秒）
+}
+
+// AddRepo 追加单个知识库的检索过程，可并发调用
+func (e *SearchExplain) AddRepo(repo RepoExplain) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.Repos = append(e.Repos, repo)
+}
+
+// NewStageTiming 计算从 start 开始的阶段耗时
+func NewStageTiming(stage string, start time.Time) StageTiming {
+	return StageTiming{Stage: stage, Ms: float64(time.Since(start).Microseconds()) / 1000}
+}
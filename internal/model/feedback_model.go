@@ -0,0 +1,117 @@
+package model
+
+import "github.com/gogf/gf/v2/os/gtime"
+
+// FeedbackRun 一次反馈处理的记录
+type FeedbackRun struct {
+	ID             uint64      `json:"id"`               // 记录ID
+	Trigger        string      `json:"trigger"`          // 触发方式：cron/manual
+	Status         string      `json:"status"`           // 处理结果：succeeded/failed
+	FromFeedbackID uint64      `json:"from_feedback_id"` // 处理前的水位线（不含）
+	ToFeedbackID   uint64      `json:"to_feedback_id"`   // 处理后的水位线（含），失败时与处理前相同
+	Feedbacks      uint        `json:"feedbacks"`        // 处理的反馈数量
+	Adjusted       uint        `json:"adjusted"`         // 调整质量分数的知识数量
+	Error          string      `json:"error,omitempty"`  // 失败原因
+	StartedAt      *gtime.Time `json:"started_at"`       // 开始时间
+	FinishedAt     *gtime.Time `json:"finished_at"`      // 结束时间
+}
+
+// Impression 一次检索展示给用户的知识条目
+type Impression struct {
+	SearchID  string           // 检索ID
+	SessionID string           // 用户会话ID，未指定时为空
+	Source    string           // 展示来源：search/answer
+	Query     string           // 实际用于检索的查询
+	Items     []ImpressionItem // 展示的知识条目
+}
+
+// ImpressionItem 展示的知识条目
+type ImpressionItem struct {
+	KnowledgeID string
+	RepoName    string
+	Position    uint // 展示位置，从1开始，含翻页偏移
+}
+
+// QueryFeedback 针对整次检索的反馈
+type QueryFeedback struct {
+	ID        uint64      `json:"id"`         // 反馈ID
+	SearchID  string      `json:"search_id"`  // 关联的检索ID，未关联时为空
+	SessionID string      `json:"session_id"` // 用户会话ID
+	Query     string      `json:"query"`      // 用户查询
+	RepoName  string      `json:"repo_name"`  // 知识库名称，跨知识库或未知时为空
+	Rating    string      `json:"rating"`     // 整体评价：no_relevant/partial/helpful
+	Comment   string      `json:"comment"`    // 补充说明
+	Anonymous bool        `json:"anonymous"`  // 是否匿名反馈（没有会话ID）
+	CreatedAt *gtime.Time `json:"created_at"` // 反馈时间
+}
+
+// QueryFeedbackListOptions 查询反馈的过滤条件
+type QueryFeedbackListOptions struct {
+	RepoName  string      // 知识库名称，为空时不限
+	Rating    string      // 整体评价，为空时不限
+	StartTime *gtime.Time // 反馈时间起（含），为空时不限
+	EndTime   *gtime.Time // 反馈时间止（含），为空时不限
+}
+
+// EntryStatsOptions 知识条目展示与点击统计的范围
+type EntryStatsOptions struct {
+	RepoName       string      // 知识库名称，为空时不限
+	KnowledgeID    string      // 知识ID，为空时不限
+	StartTime      *gtime.Time // 展示时间起（含），为空时不限
+	EndTime        *gtime.Time // 展示时间止（含），为空时不限
+	MinImpressions int         // 最少展示次数，展示次数太少的条目点击率没有参考意义
+	OrderBy        string      // 排序：impressions 按展示次数，ctr 按点击率，均为降序
+}
+
+// EntryStats 知识条目的展示与点击统计
+type EntryStats struct {
+	KnowledgeID string  `json:"knowledge_id"` // 知识ID
+	RepoName    string  `json:"repo_name"`    // 知识库名称
+	Impressions uint    `json:"impressions"`  // 展示次数
+	Clicks      uint    `json:"clicks"`       // 被点击的展示次数，同一次展示多次点击只计一次
+	Copies      uint    `json:"copies"`       // 被复制的展示次数，同一次展示多次复制只计一次
+	CTR         float64 `json:"ctr"`          // 点击率：clicks / impressions
+	CopyRate    float64 `json:"copy_rate"`    // 复制率：copies / impressions
+	AvgPosition float64 `json:"avg_position"` // 平均展示位置
+}
+
+// FeedbackStatsOptions 点赞点踩统计的范围
+type FeedbackStatsOptions struct {
+	RepoName    string      // 知识库名称，为空时不限
+	KnowledgeID string      // 知识ID，为空时不限
+	StartTime   *gtime.Time // 反馈时间起（含），为空时不限
+	EndTime     *gtime.Time // 反馈时间止（含），为空时不限
+	OrderBy     string      // 排序：total 按反馈总数，likes 按点赞数，dislikes 按点踩数，均为降序
+}
+
+// KnowledgeFeedbackStats 知识条目的点赞与点踩统计
+type KnowledgeFeedbackStats struct {
+	KnowledgeID string `json:"knowledge_id"` // 知识ID
+	RepoName    string `json:"repo_name"`    // 知识库名称
+	Summary     string `json:"summary"`      // 内容摘要
+	Likes       uint   `json:"likes"`        // 点赞数
+	Dislikes    uint   `json:"dislikes"`     // 点踩数
+}
+
+// RepoDislikedEntries 知识库中点踩最多的知识条目
+type RepoDislikedEntries struct {
+	RepoName string                   `json:"repo_name"` // 知识库名称
+	Entries  []KnowledgeFeedbackStats `json:"entries"`   // 按点踩数从多到少排列，点踩数相同时点赞少的在前
+}
+
+// FeedbackTrendPoint 一天的反馈数量
+type FeedbackTrendPoint struct {
+	Date     string `json:"date"`     // 日期，格式:YYYY-MM-DD
+	Likes    uint   `json:"likes"`    // 点赞数
+	Dislikes uint   `json:"dislikes"` // 点踩数
+}
+
+// FeedbackDeletion 按会话删除的记录数量
+type FeedbackDeletion struct {
+	Feedbacks      int64 `json:"feedbacks"`       // 点赞点踩反馈
+	QueryFeedbacks int64 `json:"query_feedbacks"` // 针对整次检索的反馈
+	Events         int64 `json:"events"`          // 点击、复制等检索行为
+	Turns          int64 `json:"turns"`           // 会话轮次
+	Impressions    int64 `json:"impressions"`     // 检索展示记录
+	SearchLogs     int64 `json:"search_logs"`     // 检索记录
+}
@@ -6,14 +6,15 @@ import (
 
 // KnowledgeItem 知识条目业务模型
 type KnowledgeItem struct {
-	ID        string       `json:"id"`               // 唯一ID
-	RepoName  string       `json:"repo_name"`        // 知识库名称
-	Content   string       `json:"content"`          // 知识内容
-	Labels    []LabelScore `json:"labels"`           // 标签分数数组
-	Summary   string       `json:"summary"`          // 内容摘要
-	Vector    []float32    `json:"vector,omitempty"` // 向量，用于临时存储分数
-	CreatedAt *gtime.Time  `json:"created_at"`       // 创建时间
-	UpdatedAt *gtime.Time  `json:"updated_at"`       // 更新时间
+	ID        string                 `json:"id"`                 // 唯一ID
+	RepoName  string                 `json:"repo_name"`          // 知识库名称
+	Content   string                 `json:"content"`            // 知识内容
+	Labels    []LabelScore           `json:"labels"`             // 标签分数数组
+	Summary   string                 `json:"summary"`            // 内容摘要
+	Metadata  map[string]interface{} `json:"metadata,omitempty"` // 用户自定义元数据
+	Vector    []float32              `json:"vector,omitempty"`   // 向量，用于临时存储分数
+	CreatedAt *gtime.Time            `json:"created_at"`         // 创建时间
+	UpdatedAt *gtime.Time            `json:"updated_at"`         // 更新时间
 }
 
 // LabelScore 标签分数
@@ -24,12 +25,120 @@ type LabelScore struct {
 
 // SearchResult 搜索结果
 type SearchResult struct {
-	ID       string       `json:"id"`        // 条目ID
-	RepoName string       `json:"repo_name"` // 知识库名称
-	Content  string       `json:"content"`   // 知识内容
-	Labels   []LabelScore `json:"labels"`    // 标签分数数组
-	Summary  string       `json:"summary"`   // 内容摘要
-	Score    float32      `json:"score"`     // 搜索匹配分数
+	ID       string                 `json:"id"`                 // 条目ID
+	RepoName string                 `json:"repo_name"`          // 知识库名称
+	Content  string                 `json:"content"`            // 知识内容
+	Labels   []LabelScore           `json:"labels"`             // 标签分数数组
+	Summary  string                 `json:"summary"`            // 内容摘要
+	Score    float32                `json:"score"`              // 搜索匹配分数
+	Metadata map[string]interface{} `json:"metadata,omitempty"` // 用户自定义元数据
+	// 高亮片段，仅在请求高亮且命中查询词时返回
+	Highlights *Highlights `json:"highlights,omitempty"`
+	// 重排序分数，仅在开启重排序时返回；Score 保持为检索阶段分数
+	RerankScore *float32 `json:"rerank_score,omitempty"`
+	// 衰减后的质量分数，仅在有质量调整记录且开启质量提升时返回；排序分数（开启重排序时为 RerankScore）已按其提升
+	Quality *float32 `json:"quality,omitempty"`
+}
+
+// Highlights 检索结果的高亮片段，命中的查询词用高亮标签包裹，未命中的字段为空
+type Highlights struct {
+	Content string `json:"content,omitempty"` // 正文片段
+	Summary string `json:"summary,omitempty"` // 摘要片段
+}
+
+// SearchOptions 检索参数
+type SearchOptions struct {
+	Query     string         `json:"query"`                // 查询内容
+	RepoName  string         `json:"repo_name"`            // 知识库名称，为空时检索 RepoNames 或全部知识库
+	RepoNames []string       `json:"repo_names,omitempty"` // 参与检索的知识库列表
+	Limit     uint64         `json:"limit"`                // 返回结果数量
+	Offset    uint64         `json:"offset"`               // 跳过的结果数量，用于翻页
+	MinScore  *float32       `json:"min_score,omitempty"`  // 最低分数，开启重排序时作用于重排序分数
+	Highlight bool           `json:"highlight"`            // 是否返回高亮片段
+	Fusion    string         `json:"fusion"`               // 融合策略：dense/sparse_dense/rrf/dbsf/weighted，为空时使用配置
+	Alpha     *float32       `json:"alpha,omitempty"`      // weighted 策略中密集向量分数的权重，为空时使用配置
+	Beta      *float32       `json:"beta,omitempty"`       // weighted 策略中标签稀疏向量分数的权重，为空时使用配置
+	Rerank    *bool          `json:"rerank,omitempty"`     // 是否对结果重排序，为空时使用配置 rerank.enabled
+	Filter    *SearchFilter  `json:"filter,omitempty"`     // 过滤条件
+	Strategy  string         `json:"strategy,omitempty"`   // 检索策略：standard/multi_query/hyde，为空时为 standard
+	Labels    []LabelScore   `json:"labels"`               // 查询标签，由业务层分析查询意图后填充
+	Vector    []float32      `json:"-"`                    // 查询的密集向量，分析标签时已计算则复用，为空时由检索层计算
+	Explain   *SearchExplain `json:"-"`                    // 不为空时记录检索过程，各层向其中写入说明
+	// 以下用于评测不同配置，为空时使用配置文件的值
+	LabelThreshold     *float32 `json:"label_threshold,omitempty"`     // 查询标签阈值
+	PrefetchMultiplier *uint64  `json:"prefetch_multiplier,omitempty"` // 预查询候选数量倍数
+}
+
+// QueryLabels 查询标签分析结果
+type QueryLabels struct {
+	Labels     []LabelScore `json:"labels"`     // 查询标签，分数与导入时的标签评分（1-5分）一致
+	Vector     []float32    `json:"-"`          // 分析过程中计算的查询密集向量，未计算时为空
+	Source     string       `json:"source"`     // 标签来源：centroid/llm/none
+	Confidence float32      `json:"confidence"` // 最近质心分类的置信度（与最近质心的余弦相似度）
+}
+
+// SearchFilter 检索过滤条件，各条件之间为“且”的关系
+type SearchFilter struct {
+	Labels      []LabelFilter    `json:"labels,omitempty"`       // 标签条件，要求每个标签都存在且不低于最低分数
+	CreatedFrom *gtime.Time      `json:"created_from,omitempty"` // 创建时间起（含）
+	CreatedTo   *gtime.Time      `json:"created_to,omitempty"`   // 创建时间止（含）
+	UpdatedFrom *gtime.Time      `json:"updated_from,omitempty"` // 更新时间起（含）
+	UpdatedTo   *gtime.Time      `json:"updated_to,omitempty"`   // 更新时间止（含）
+	DocumentIDs []string         `json:"document_ids,omitempty"` // 限定的知识条目ID
+	Metadata    []MetadataFilter `json:"metadata,omitempty"`     // 元数据条件
+}
+
+// MetadataFilter 元数据过滤条件，Match 与范围条件可同时使用
+type MetadataFilter struct {
+	Key   string        `json:"key"`             // 元数据字段名
+	Match []interface{} `json:"match,omitempty"` // 等于其中任意一个值
+	Gte   interface{}   `json:"gte,omitempty"`   // 大于等于
+	Lte   interface{}   `json:"lte,omitempty"`   // 小于等于
+}
+
+// MetadataField 知识库元数据字段声明，声明过的字段在 Qdrant 中建立 payload 索引
+type MetadataField struct {
+	Key  string `json:"key"`  // 字段名
+	Type string `json:"type"` // 字段类型：keyword/integer/float/bool/datetime
+}
+
+// LabelFilter 标签过滤条件
+type LabelFilter struct {
+	Name     string  `json:"name"`      // 标签名称
+	MinScore float32 `json:"min_score"` // 最低分数，0 表示只要求标签存在
+}
+
+// SearchOutput 检索输出
+type SearchOutput struct {
+	Items   []SearchResult `json:"items"`             // 检索结果
+	Fusion  string         `json:"fusion"`            // 实际使用的融合策略（稀疏查询为空时可能回退为 dense）
+	Errors  []RepoError    `json:"errors,omitempty"`  // 跨知识库检索时失败的知识库，其余知识库的结果照常返回
+	Total   uint64         `json:"total"`             // 满足知识库与过滤条件的候选总数（估计值），不考虑最低分数
+	HasMore bool           `json:"has_more"`          // 是否还有下一页
+	Debug   *SearchDebug   `json:"debug,omitempty"`   // 检索策略的中间结果，standard 策略时为空
+	Explain *SearchExplain `json:"explain,omitempty"` // 检索过程说明，仅在请求 explain 时返回
+	Timings []StageTiming  `json:"timings,omitempty"` // 各阶段耗时，关键词检索不记录
+}
+
+// SearchDebug 检索策略的中间结果
+type SearchDebug struct {
+	Strategy           string   `json:"strategy"`                      // 实际使用的检索策略，生成失败回退时为 standard
+	Queries            []string `json:"queries,omitempty"`             // multi_query 策略参与检索的查询，第一条为原始查询
+	HypotheticalAnswer string   `json:"hypothetical_answer,omitempty"` // hyde 策略生成的假设性答案
+	Error              string   `json:"error,omitempty"`               // 生成失败的原因
+}
+
+// RepoError 单个知识库的检索错误
+type RepoError struct {
+	RepoName string `json:"repo_name"` // 知识库名称
+	Message  string `json:"message"`   // 错误信息
+}
+
+// VectorSearchOutput 向量检索输出
+type VectorSearchOutput struct {
+	Points []VectorSearchResult `json:"points"` // 检索到的点
+	Fusion string               `json:"fusion"` // 实际使用的融合策略
+	Total  uint64               `json:"total"`  // 满足过滤条件的点数量（估计值）
 }
 
 // VectorSearchResult 向量搜索结果
@@ -55,10 +164,36 @@ type ImportTask struct {
 
 // TaskItem 任务条目
 type TaskItem struct {
-	ID           int64  `json:"id,omitempty"`      // 条目ID，可选，数据库自增
-	TaskID       string `json:"task_id,omitempty"` // 所属任务ID
-	RepoName     string `json:"repo_name"`         // 知识库名称
-	Content      string `json:"content"`           // 知识内容
-	Status       string `json:"status"`            // 处理状态：pending, processing, completed, failed
-	ErrorMessage string `json:"error_message"`     // 处理失败时的错误信息
+	ID           int64                  `json:"id,omitempty"`       // 条目ID，可选，数据库自增
+	TaskID       string                 `json:"task_id,omitempty"`  // 所属任务ID
+	RepoName     string                 `json:"repo_name"`          // 知识库名称
+	Content      string                 `json:"content"`            // 知识内容
+	Metadata     map[string]interface{} `json:"metadata,omitempty"` // 用户自定义元数据
+	Status       string                 `json:"status"`             // 处理状态：pending, processing, completed, failed
+	ErrorMessage string                 `json:"error_message"`      // 处理失败时的错误信息
+}
+
+// RepoInfo 知识库信息
+type RepoInfo struct {
+	ID             string          `json:"id"`              // 知识库内部ID
+	Name           string          `json:"name"`            // 知识库名称
+	Alias          string          `json:"alias"`           // Qdrant集合别名，读写均通过别名进行
+	Collection     string          `json:"collection"`      // 别名当前指向的Qdrant物理集合
+	DocCount       uint64          `json:"doc_count"`       // 已建立词法索引的文档数
+	TokenCount     uint64          `json:"token_count"`     // 已建立词法索引的词条总数
+	MetadataSchema []MetadataField `json:"metadata_schema"` // 元数据字段声明
+	PointsCount    uint64          `json:"points_count"`    // Qdrant中的向量数量（仅describe时填充）
+	KnowledgeCount int             `json:"knowledge_count"` // MySQL中的知识条目数量（仅describe时填充）
+	CreatedAt      *gtime.Time     `json:"created_at"`      // 创建时间
+	UpdatedAt      *gtime.Time     `json:"updated_at"`      // 更新时间
+}
+
+// SearchCacheStats 检索结果缓存统计
+type SearchCacheStats struct {
+	Enabled bool    `json:"enabled"`  // 是否启用缓存
+	Backend string  `json:"backend"`  // 缓存后端：memory/redis
+	Hits    uint64  `json:"hits"`     // 命中次数
+	Misses  uint64  `json:"misses"`   // 未命中次数
+	HitRate float64 `json:"hit_rate"` // 命中率
+	Size    int     `json:"size"`     // 当前缓存的结果数量（仅 memory 后端）
 }
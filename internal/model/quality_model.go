@@ -0,0 +1,16 @@
+package model
+
+import "github.com/gogf/gf/v2/os/gtime"
+
+// QualityLog 知识质量分数的一次调整
+type QualityLog struct {
+	ID          uint64      `json:"id"`           // 日志ID
+	KnowledgeID string      `json:"knowledge_id"` // 知识ID
+	Source      string      `json:"source"`       // 调整来源，如 feedback
+	Likes       uint        `json:"likes"`        // 本次计入的点赞数
+	Dislikes    uint        `json:"dislikes"`     // 本次计入的点踩数
+	ScoreBefore float64     `json:"score_before"` // 调整前的质量分数（已衰减到调整时刻）
+	Delta       float64     `json:"delta"`        // 调整量
+	ScoreAfter  float64     `json:"score_after"`  // 调整后的质量分数（已限制在上下限内）
+	CreatedAt   *gtime.Time `json:"created_at"`   // 调整时间
+}
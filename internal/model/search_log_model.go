@@ -0,0 +1,61 @@
+package model
+
+import "github.com/gogf/gf/v2/os/gtime"
+
+// SearchLog 一次检索的记录
+type SearchLog struct {
+	ID          uint64            `json:"id"`                  // 记录ID
+	SearchID    string            `json:"search_id"`           // 检索ID
+	Source      string            `json:"source"`              // 检索来源：search/answer
+	SessionID   string            `json:"session_id"`          // 用户会话ID，未指定时为空
+	Query       string            `json:"query"`               // 用户原始查询
+	SearchQuery string            `json:"search_query"`        // 实际用于检索的查询，多轮会话中为改写后的查询
+	RepoNames   []string          `json:"repo_names"`          // 检索的知识库，为空表示全部知识库
+	Mode        string            `json:"mode"`                // 检索模式
+	Fusion      string            `json:"fusion"`              // 实际使用的融合策略
+	Params      SearchLogParams   `json:"params"`              // 检索参数
+	Filter      *SearchFilter     `json:"filter,omitempty"`    // 过滤条件
+	Results     []SearchLogResult `json:"results"`             // 返回的知识ID与分数
+	Total       uint64            `json:"total"`               // 满足过滤条件的候选总数
+	TopScore    *float32          `json:"top_score,omitempty"` // 第一条结果的排序分数（重排序时为重排序分数），没有结果时为空
+	Cached      bool              `json:"cached"`              // 是否命中检索缓存
+	LatencyMs   float64           `json:"latency_ms"`          // 总耗时（毫秒），含多轮会话改写
+	Timings     []StageTiming     `json:"timings"`             // 各阶段耗时，命中缓存与关键词检索时为空
+	Error       string            `json:"error,omitempty"`     // 失败原因
+	CreatedAt   *gtime.Time       `json:"created_at"`          // 检索时间
+}
+
+// SearchLogParams 检索记录中的请求参数，未填写的项为空
+type SearchLogParams struct {
+	Limit     uint64   `json:"limit"`               // 返回结果数量
+	Offset    uint64   `json:"offset"`              // 跳过的结果数量
+	MinScore  *float32 `json:"min_score,omitempty"` // 最低分数
+	Fusion    string   `json:"fusion,omitempty"`    // 请求的融合策略
+	Alpha     *float32 `json:"alpha,omitempty"`     // weighted 策略的密集向量权重
+	Beta      *float32 `json:"beta,omitempty"`      // weighted 策略的标签稀疏向量权重
+	Rerank    *bool    `json:"rerank,omitempty"`    // 是否重排序
+	Strategy  string   `json:"strategy,omitempty"`  // 检索策略
+	Highlight bool     `json:"highlight,omitempty"` // 是否返回高亮片段
+	Explain   bool     `json:"explain,omitempty"`   // 是否返回检索过程说明
+}
+
+// SearchLogResult 检索记录中的一条结果
+type SearchLogResult struct {
+	ID          string   `json:"id"`                     // 知识ID
+	RepoName    string   `json:"repo_name"`              // 知识库名称
+	Score       float32  `json:"score"`                  // 检索分数
+	RerankScore *float32 `json:"rerank_score,omitempty"` // 重排序分数
+}
+
+// SearchLogListOptions 检索记录的过滤条件
+type SearchLogListOptions struct {
+	RepoName    string      // 检索过该知识库的记录，为空时不限
+	SessionID   string      // 会话ID，为空时不限
+	Source      string      // 检索来源，为空时不限
+	Query       string      // 原始查询或实际检索的查询包含该内容，为空时不限
+	KnowledgeID string      // 结果中包含该知识，为空时不限
+	Failed      bool        // 只查询失败的检索
+	ZeroResult  bool        // 只查询没有结果的检索
+	StartTime   *gtime.Time // 检索时间起（含），为空时不限
+	EndTime     *gtime.Time // 检索时间止（含），为空时不限
+}
@@ -0,0 +1,29 @@
+package model
+
+import "github.com/gogf/gf/v2/os/gtime"
+
+// SessionTurn 会话中的一轮检索
+type SessionTurn struct {
+	ID             uint64      `json:"id"`              // 轮次ID
+	SessionID      string      `json:"session_id"`      // 会话ID
+	Query          string      `json:"query"`           // 用户原始查询
+	RewrittenQuery string      `json:"rewritten_query"` // 改写后的独立查询
+	KnowledgeIDs   []string    `json:"knowledge_ids"`   // 本轮返回的知识ID
+	CreatedAt      *gtime.Time `json:"created_at"`      // 创建时间
+}
+
+// QueryRewrite 会话查询改写结果
+type QueryRewrite struct {
+	Query          string // 用户原始查询
+	RewrittenQuery string // 用于检索的独立查询，未改写时与原始查询相同
+	// Repeated 与会话上一轮的原始查询相同（翻页或重试），沿用上一轮的改写结果且不记录新的轮次
+	Repeated bool
+	TurnID   uint64 // Repeated 为 true 时为上一轮的轮次ID
+}
+
+// UserSession 签发的用户会话
+type UserSession struct {
+	ID        string      `json:"session_id"` // 会话ID
+	CreatedAt *gtime.Time `json:"created_at"` // 签发时间
+	ExpiresAt *gtime.Time `json:"expires_at"` // 过期时间，为空表示不过期
+}
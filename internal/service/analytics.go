@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/frame/g"
+
+	"knowledge-system-api/internal/model"
+)
+
+// ContentGapConfig 内容缺口报告配置
+type ContentGapConfig struct {
+	Similarity      float64 `yaml:"similarity" json:"similarity"`               // 查询与主题中心的余弦相似度不低于该值时归入同一主题
+	MaxQueries      int     `yaml:"max_queries" json:"max_queries"`             // 参与聚类的查询数量上限，按出现次数取前若干条，避免一次向量化过多查询
+	MaxClusters     int     `yaml:"max_clusters" json:"max_clusters"`           // 默认返回的主题数量，只为返回的主题生成名称
+	SampleQueries   int     `yaml:"sample_queries" json:"sample_queries"`       // 生成主题名称时提供给大模型的查询数量
+	LabelBudgetMs   int     `yaml:"label_budget_ms" json:"label_budget_ms"`     // 生成单个主题名称的最长等待时间（毫秒），超时使用出现次数最多的查询
+	LabelPromptPath string  `yaml:"label_prompt_path" json:"label_prompt_path"` // 主题名称的提示词模板，使用 {queries} 占位符
+	// LowScore 按实际使用的融合策略配置的低分阈值：检索记录中结果的最高检索分数（不含重排序分数）低于该值时视为未被满足，
+	// 不同策略的分数不可比较，未配置的策略不统计低分检索
+	LowScore      map[string]float64 `yaml:"low_score" json:"low_score"`
+	SearchLogDays int                `yaml:"search_log_days" json:"search_log_days"` // 未指定开始时间时读取结束时间之前多少天的检索记录
+	MaxSearchLogs int                `yaml:"max_search_logs" json:"max_search_logs"` // 读取检索记录的条数上限，超过时只读取最近的记录
+}
+
+// LoadContentGapConfig 读取analytics.gaps配置，未配置的项使用默认值
+func LoadContentGapConfig(ctx context.Context) *ContentGapConfig {
+	cfg := &ContentGapConfig{}
+	if err := g.Cfg().MustGet(ctx, "analytics.gaps").Scan(cfg); err != nil {
+		g.Log().Warningf(ctx, "加载analytics.gaps配置失败，使用默认配置: %v", err)
+	}
+	if cfg.Similarity <= 0 || cfg.Similarity > 1 {
+		cfg.Similarity = 0.85
+	}
+	if cfg.MaxQueries <= 0 {
+		cfg.MaxQueries = 2000
+	}
+	if cfg.MaxClusters <= 0 {
+		cfg.MaxClusters = 20
+	}
+	if cfg.SampleQueries <= 0 {
+		cfg.SampleQueries = 10
+	}
+	if cfg.LabelBudgetMs <= 0 {
+		cfg.LabelBudgetMs = 10000
+	}
+	if cfg.SearchLogDays <= 0 {
+		cfg.SearchLogDays = 30
+	}
+	if cfg.MaxSearchLogs <= 0 {
+		cfg.MaxSearchLogs = 20000
+	}
+	if cfg.LabelPromptPath == "" {
+		cfg.LabelPromptPath = "resource/prompts/gap_label"
+	}
+	return cfg
+}
+
+// IAnalytics 检索统计分析服务接口
+type IAnalytics interface {
+	// ContentGaps 将未被满足的查询按语义聚类，生成按出现次数排列的内容缺口报告
+	ContentGaps(ctx context.Context, opts model.ContentGapOptions) (*model.ContentGapReport, error)
+}
+
+var (
+	localAnalytics IAnalytics
+)
+
+// Analytics 获取检索统计分析服务
+func Analytics() IAnalytics {
+	if localAnalytics == nil {
+		panic("implement not found for interface IAnalytics, forgot register?")
+	}
+	return localAnalytics
+}
+
+// RegisterAnalytics 注册检索统计分析服务
+func RegisterAnalytics(i IAnalytics) {
+	localAnalytics = i
+}
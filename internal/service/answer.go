@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"unicode"
+
+	"github.com/gogf/gf/v2/frame/g"
+
+	"knowledge-system-api/internal/model"
+)
+
+// AnswerConfig 答案生成配置
+type AnswerConfig struct {
+	PromptPath      string `yaml:"prompt_path" json:"prompt_path"`             // 答案生成的提示词模板，使用 {context} 与 {question} 占位符
+	ContextTokens   int    `yaml:"context_tokens" json:"context_tokens"`       // 上下文的 token 预算
+	TopK            int    `yaml:"top_k" json:"top_k"`                         // 检索的候选数量
+	NoContextAnswer string `yaml:"no_context_answer" json:"no_context_answer"` // 没有检索到内容时的固定回答，不调用大模型
+}
+
+// LoadAnswerConfig 读取answer配置，未配置的项使用默认值
+func LoadAnswerConfig(ctx context.Context) *AnswerConfig {
+	cfg := &AnswerConfig{}
+	if err := g.Cfg().MustGet(ctx, "answer").Scan(cfg); err != nil {
+		g.Log().Warningf(ctx, "加载answer配置失败，使用默认配置: %v", err)
+	}
+	if cfg.PromptPath == "" {
+		cfg.PromptPath = "resource/prompts/answer"
+	}
+	if cfg.ContextTokens <= 0 {
+		cfg.ContextTokens = 3000
+	}
+	if cfg.TopK <= 0 {
+		cfg.TopK = 8
+	}
+	if cfg.NoContextAnswer == "" {
+		cfg.NoContextAnswer = "知识库中没有找到与问题相关的内容。"
+	}
+	return cfg
+}
+
+// IAnswer 答案生成服务接口
+type IAnswer interface {
+	// Answer 检索知识并生成带引用的答案，onToken 不为空时以流式方式逐段回调生成的文本
+	Answer(ctx context.Context, opts *model.AnswerOptions, onToken func(token string) error) (*model.AnswerOutput, error)
+}
+
+var (
+	localAnswer IAnswer
+)
+
+// Answer 获取答案生成服务
+func Answer() IAnswer {
+	if localAnswer == nil {
+		panic("implement not found for interface IAnswer, forgot register?")
+	}
+	return localAnswer
+}
+
+// RegisterAnswer 注册答案生成服务
+func RegisterAnswer(i IAnswer) {
+	localAnswer = i
+}
+
+// EstimateTokens 估算文本的 token 数：汉字等 CJK 字符按每字1个，其余字符按每4个字符1个
+// 不同模型的分词器不同，仅用于控制上下文长度
+func EstimateTokens(text string) int {
+	cjk, other := 0, 0
+	for _, r := range text {
+		if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+			cjk++
+		} else {
+			other++
+		}
+	}
+	return cjk + (other+3)/4
+}
@@ -23,6 +23,11 @@ func NewOllamaEmbeddingClient(cfg OllamaEmbeddingConfig) *OllamaEmbeddingClient
 	return &OllamaEmbeddingClient{cfg: cfg}
 }
 
+// CacheIdentity 缓存标识：向量化没有提示词，只使用模型名称
+func (c *OllamaEmbeddingClient) CacheIdentity() (modelName, version string) {
+	return "ollama/" + c.cfg.Model, ""
+}
+
 // Embed 调用Ollama生成向量
 func (c *OllamaEmbeddingClient) Embed(ctx context.Context, text string) ([]float32, error) {
 	body := map[string]interface{}{
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"io"
+
+	"github.com/gogf/gf/v2/frame/g"
+
+	"knowledge-system-api/internal/model"
+)
+
+// FeedbackDatasetConfig 由反馈生成评测数据的配置
+type FeedbackDatasetConfig struct {
+	GradeLikes    []int   `yaml:"grade_likes" json:"grade_likes"`       // 相关度 1、2、3 级所需的净点赞数（点赞数 - 点踩数 * dislike_weight）
+	DislikeWeight float64 `yaml:"dislike_weight" json:"dislike_weight"` // 一次点踩抵消的点赞数
+}
+
+// LoadFeedbackDatasetConfig 读取eval.feedback配置，未配置的项使用默认值
+func LoadFeedbackDatasetConfig(ctx context.Context) *FeedbackDatasetConfig {
+	cfg := &FeedbackDatasetConfig{}
+	if err := g.Cfg().MustGet(ctx, "eval.feedback").Scan(cfg); err != nil {
+		g.Log().Warningf(ctx, "加载eval.feedback配置失败，使用默认配置: %v", err)
+	}
+	if len(cfg.GradeLikes) == 0 {
+		cfg.GradeLikes = []int{1, 3, 5}
+	}
+	if cfg.DislikeWeight <= 0 {
+		cfg.DislikeWeight = 1
+	}
+	return cfg
+}
+
+// IEval 离线检索评测服务接口
+type IEval interface {
+	// CreateSet 创建评测集，返回评测集ID
+	CreateSet(ctx context.Context, repoName, name, description string, queries []model.EvalQuery) (uint64, error)
+
+	// ListSets 查询评测集列表，repoName 为空时返回全部知识库的评测集
+	ListSets(ctx context.Context, repoName string) ([]model.EvalSet, error)
+
+	// GetSet 获取评测集及其全部查询，不存在时返回 nil
+	GetSet(ctx context.Context, id uint64) (*model.EvalSet, []model.EvalQuery, error)
+
+	// DeleteSet 删除评测集及其全部查询
+	DeleteSet(ctx context.Context, id uint64) error
+
+	// AddQueries 向评测集追加查询，返回追加的数量
+	AddQueries(ctx context.Context, setID uint64, queries []model.EvalQuery) (int, error)
+
+	// Run 使用指定检索配置逐条执行评测集中的查询，计算 recall@k、MRR、nDCG@k 与检索耗时
+	Run(ctx context.Context, setID uint64, cfg model.EvalConfig, k int) (*model.EvalReport, error)
+
+	// Compare 在同一评测集上依次评测两个检索配置并对比
+	Compare(ctx context.Context, setID uint64, baseline, candidate model.EvalConfig, k int) (*model.EvalComparison, error)
+
+	// BuildFeedbackDataset 将反馈按归一化后的查询去重汇总为分级的相关性标注
+	BuildFeedbackDataset(ctx context.Context, opts model.FeedbackDatasetOptions) (*model.FeedbackDataset, error)
+
+	// CreateSetsFromFeedback 按知识库将反馈生成的标注保存为评测集，返回创建的评测集
+	CreateSetsFromFeedback(ctx context.Context, dataset *model.FeedbackDataset, name string) ([]model.EvalSet, error)
+
+	// ExportTrainingPairs 将反馈生成的标注以 JSONL 训练样本（query/pos/neg）写出，返回写出的行数
+	ExportTrainingPairs(ctx context.Context, dataset *model.FeedbackDataset, w io.Writer) (int, error)
+}
+
+var (
+	localEval IEval
+)
+
+// Eval 获取离线检索评测服务
+func Eval() IEval {
+	if localEval == nil {
+		panic("implement not found for interface IEval, forgot register?")
+	}
+	return localEval
+}
+
+// RegisterEval 注册离线检索评测服务
+func RegisterEval(i IEval) {
+	localEval = i
+}
@@ -4,8 +4,39 @@ package service
 import (
 	"context"
 	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+
+	"knowledge-system-api/internal/model"
 )
 
+// FeedbackScheduleConfig 反馈定时处理配置
+type FeedbackScheduleConfig struct {
+	Enabled   bool   `yaml:"enabled" json:"enabled"`       // 是否启用定时处理
+	Cron      string `yaml:"cron" json:"cron"`             // gcron 表达式（含秒）
+	BatchSize int    `yaml:"batch_size" json:"batch_size"` // 每次最多处理的反馈数量，剩余的留给下一次
+	// SafetyLagSeconds 只处理早于该秒数的反馈，需大于写入反馈的事务耗时与实例间的时钟偏差
+	SafetyLagSeconds int `yaml:"safety_lag_seconds" json:"safety_lag_seconds"`
+}
+
+// LoadFeedbackScheduleConfig 读取feedback.schedule配置，未配置的项使用默认值
+func LoadFeedbackScheduleConfig(ctx context.Context) *FeedbackScheduleConfig {
+	cfg := &FeedbackScheduleConfig{Enabled: true}
+	if err := g.Cfg().MustGet(ctx, "feedback.schedule").Scan(cfg); err != nil {
+		g.Log().Warningf(ctx, "加载feedback.schedule配置失败，使用默认配置: %v", err)
+	}
+	if cfg.Cron == "" {
+		cfg.Cron = "0 0 3 * * *"
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 10000
+	}
+	if cfg.SafetyLagSeconds <= 0 {
+		cfg.SafetyLagSeconds = 60
+	}
+	return cfg
+}
+
 // FeedbackItem 反馈项
 type FeedbackItem struct {
 	ID          string
@@ -13,19 +44,55 @@ type FeedbackItem struct {
 	UserQuery   string
 	KnowledgeID string
 	Action      string
+	TurnID      uint64 // 关联的会话轮次ID，未关联时为0
+	SearchID    string // 产生该反馈的检索ID，未关联时为空
+	Anonymous   bool   // 是否匿名反馈（没有会话ID）
 	Timestamp   time.Time
 }
 
 // IFeedback 反馈服务接口
 type IFeedback interface {
-	// Add 添加反馈
-	Add(ctx context.Context, sessionID, userQuery, knowledgeID, action string) (int64, error)
+	// Add 添加反馈，关联检索ID时该次检索必须展示过这条知识
+	Add(ctx context.Context, searchID, sessionID, userQuery, knowledgeID, action string) (int64, error)
 
 	// List 查询反馈列表
 	List(ctx context.Context, sessionID, knowledgeID, action, startTime, endTime string, page, pageSize int) ([]FeedbackItem, int, error)
 
-	// ProcessFeedbacks 处理反馈数据，更新标签分数
-	ProcessFeedbacks(ctx context.Context) error
+	// ProcessFeedbacks 处理水位线之后的反馈，调整知识质量分数并推进水位线，返回本次处理记录
+	ProcessFeedbacks(ctx context.Context, trigger string) (*model.FeedbackRun, error)
+
+	// ListRuns 分页查询反馈处理记录，按时间倒序
+	ListRuns(ctx context.Context, page, pageSize int) ([]model.FeedbackRun, int, error)
+
+	// StartSchedule 按 feedback.schedule 配置注册定时处理任务
+	StartSchedule(ctx context.Context) error
+
+	// RecordImpressions 记录一次检索展示的知识条目，feedback.impressions 为 false 时不记录
+	RecordImpressions(ctx context.Context, impression *model.Impression) error
+
+	// AddEvent 记录对检索结果的点击、复制等行为，返回行为ID
+	AddEvent(ctx context.Context, searchID, sessionID, knowledgeID, event string) (uint64, error)
+
+	// AddQueryFeedback 添加针对整次检索的反馈，返回反馈ID
+	AddQueryFeedback(ctx context.Context, feedback *model.QueryFeedback) (uint64, error)
+
+	// ListQueryFeedbacks 分页查询查询反馈，按时间倒序
+	ListQueryFeedbacks(ctx context.Context, opts model.QueryFeedbackListOptions, page, pageSize int) ([]model.QueryFeedback, int, error)
+
+	// EntryStats 分页统计知识条目的展示次数、点击率与复制率
+	EntryStats(ctx context.Context, opts model.EntryStatsOptions, page, pageSize int) ([]model.EntryStats, int, error)
+
+	// KnowledgeStats 分页统计知识条目的点赞与点踩数量
+	KnowledgeStats(ctx context.Context, opts model.FeedbackStatsOptions, page, pageSize int) ([]model.KnowledgeFeedbackStats, int, error)
+
+	// TopDisliked 按知识库返回点踩最多的知识条目，每个知识库最多 limit 条
+	TopDisliked(ctx context.Context, opts model.FeedbackStatsOptions, limit int) ([]model.RepoDislikedEntries, error)
+
+	// Trend 按天统计点赞与点踩数量，没有反馈的日期计为0
+	Trend(ctx context.Context, opts model.FeedbackStatsOptions) ([]model.FeedbackTrendPoint, error)
+
+	// DeleteBySession 删除会话的全部反馈与检索行为，返回各类记录的删除数量
+	DeleteBySession(ctx context.Context, sessionID string) (*model.FeedbackDeletion, error)
 }
 
 var (
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"html"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gogf/gf/v2/frame/g"
+
+	"knowledge-system-api/internal/model"
+)
+
+// HighlightConfig 检索结果高亮配置
+type HighlightConfig struct {
+	PreTag       string `yaml:"pre_tag" json:"pre_tag"`             // 命中词前的标签
+	PostTag      string `yaml:"post_tag" json:"post_tag"`           // 命中词后的标签
+	FragmentSize int    `yaml:"fragment_size" json:"fragment_size"` // 片段的最大字数，超出时截取第一个命中词附近的内容
+}
+
+// LoadHighlightConfig 读取search.highlight配置，未配置的项使用默认值
+func LoadHighlightConfig(ctx context.Context) *HighlightConfig {
+	cfg := &HighlightConfig{}
+	if err := g.Cfg().MustGet(ctx, "search.highlight").Scan(cfg); err != nil {
+		g.Log().Warningf(ctx, "加载highlight配置失败，使用默认配置: %v", err)
+	}
+	if cfg.PreTag == "" && cfg.PostTag == "" {
+		cfg.PreTag = "<em>"
+		cfg.PostTag = "</em>"
+	}
+	if cfg.FragmentSize <= 0 {
+		cfg.FragmentSize = 120
+	}
+	return cfg
+}
+
+// Highlighter 按查询分词结果标记命中词
+type Highlighter struct {
+	cfg   *HighlightConfig
+	terms []string
+}
+
+// NewHighlighter 对查询分词（搜索引擎模式，包含长词中的子词），按长度降序匹配，避免短词截断长词
+// 只保留两个字及以上的词，查询全部为单字时才使用单字
+func NewHighlighter(ctx context.Context, query string) *Highlighter {
+	seen := make(map[string]bool)
+	var terms, singles []string
+	for _, tok := range Tokenizer().CutForSearch(query) {
+		tok = strings.ToLower(strings.TrimSpace(tok))
+		if tok == "" || seen[tok] {
+			continue
+		}
+		seen[tok] = true
+		if utf8.RuneCountInString(tok) >= 2 {
+			terms = append(terms, tok)
+		} else {
+			singles = append(singles, tok)
+		}
+	}
+	if len(terms) == 0 {
+		terms = singles
+	}
+	sort.SliceStable(terms, func(i, j int) bool {
+		return utf8.RuneCountInString(terms[i]) > utf8.RuneCountInString(terms[j])
+	})
+	return &Highlighter{cfg: LoadHighlightConfig(ctx), terms: terms}
+}
+
+// Highlights 生成正文与摘要的高亮片段，两者都未命中时返回 nil
+func (h *Highlighter) Highlights(content, summary string) *model.Highlights {
+	result := &model.Highlights{
+		Content: h.Fragment(content),
+		Summary: h.Fragment(summary),
+	}
+	if result.Content == "" && result.Summary == "" {
+		return nil
+	}
+	return result
+}
+
+// Fragment 截取命中词附近的片段并标记命中词，未命中时返回空字符串
+// 片段中的原文经过 HTML 转义，高亮标签可直接渲染
+func (h *Highlighter) Fragment(text string) string {
+	if text == "" || len(h.terms) == 0 {
+		return ""
+	}
+
+	runes := []rune(text)
+	lower := []rune(strings.ToLower(text))
+	if len(lower) != len(runes) {
+		// 大小写转换改变了字符数量（极少见），直接按原文匹配
+		lower = runes
+	}
+
+	// 标记命中的字符，长词优先
+	hit := make([]bool, len(runes))
+	first := -1
+	for _, term := range h.terms {
+		tr := []rune(term)
+		for i := 0; i+len(tr) <= len(lower); i++ {
+			if hit[i] || string(lower[i:i+len(tr)]) != term {
+				continue
+			}
+			for k := i; k < i+len(tr); k++ {
+				hit[k] = true
+			}
+			if first < 0 || i < first {
+				first = i
+			}
+			i += len(tr) - 1
+		}
+	}
+	if first < 0 {
+		return ""
+	}
+
+	// 以第一个命中词为中心偏左截取片段
+	start, end := 0, len(runes)
+	if len(runes) > h.cfg.FragmentSize {
+		start = first - h.cfg.FragmentSize/4
+		if start < 0 {
+			start = 0
+		}
+		end = start + h.cfg.FragmentSize
+		if end > len(runes) {
+			end = len(runes)
+			start = end - h.cfg.FragmentSize
+		}
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	for i := start; i < end; {
+		j := i
+		for j < end && hit[j] == hit[i] {
+			j++
+		}
+		segment := html.EscapeString(string(runes[i:j]))
+		if hit[i] {
+			b.WriteString(h.cfg.PreTag)
+			b.WriteString(segment)
+			b.WriteString(h.cfg.PostTag)
+		} else {
+			b.WriteString(segment)
+		}
+		i = j
+	}
+	if end < len(runes) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
@@ -0,0 +1,96 @@
+package service
+
+import "testing"
+
+func TestHighlighterFragment(t *testing.T) {
+	tests := []struct {
+		name         string
+		terms        []string
+		fragmentSize int
+		text         string
+		want         string
+	}{
+		{name: "空文本", terms: []string{"报销"}, fragmentSize: 120, text: "", want: ""},
+		{name: "没有查询词", terms: nil, fragmentSize: 120, text: "报销比例", want: ""},
+		{name: "未命中", terms: []string{"报销"}, fragmentSize: 120, text: "门诊费用", want: ""},
+		{
+			name: "长词优先", terms: []string{"报销比例", "报销"}, fragmentSize: 120,
+			text: "医保报销比例是多少", want: "医保<em>报销比例</em>是多少",
+		},
+		{
+			name: "相邻命中合并为一个标签", terms: []string{"报销", "比例"}, fragmentSize: 120,
+			text: "报销比例", want: "<em>报销比例</em>",
+		},
+		{
+			name: "多处命中", terms: []string{"报销"}, fragmentSize: 120,
+			text: "门诊报销与住院报销", want: "门诊<em>报销</em>与住院<em>报销</em>",
+		},
+		{
+			name: "忽略大小写并保留原文", terms: []string{"api"}, fragmentSize: 120,
+			text: "调用API接口", want: "调用<em>API</em>接口",
+		},
+		{
+			name: "原文HTML转义", terms: []string{"报销"}, fragmentSize: 120,
+			text: "<b>报销</b>", want: "&lt;b&gt;<em>报销</em>&lt;/b&gt;",
+		},
+		{
+			name: "超长文本以命中词为中心偏左截取", terms: []string{"报销"}, fragmentSize: 10,
+			text: "一二三四五六七八九十报销一二三四五六七八九十", want: "…九十<em>报销</em>一二三四五六…",
+		},
+		{
+			name: "命中词靠近结尾时截取末尾", terms: []string{"报销"}, fragmentSize: 10,
+			text: "一二三四五六七八九十一二三四五六七八九十报销", want: "…三四五六七八九十<em>报销</em>",
+		},
+		{
+			name: "命中词靠近开头时从头截取", terms: []string{"报销"}, fragmentSize: 10,
+			text: "报销一二三四五六七八九十", want: "<em>报销</em>一二三四五六七八…",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Highlighter{
+				cfg:   &HighlightConfig{PreTag: "<em>", PostTag: "</em>", FragmentSize: tt.fragmentSize},
+				terms: tt.terms,
+			}
+			if got := h.Fragment(tt.text); got != tt.want {
+				t.Errorf("Fragment(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHighlighterHighlights(t *testing.T) {
+	h := &Highlighter{
+		cfg:   &HighlightConfig{PreTag: "<em>", PostTag: "</em>", FragmentSize: 120},
+		terms: []string{"报销"},
+	}
+	tests := []struct {
+		name        string
+		content     string
+		summary     string
+		wantNil     bool
+		wantContent string
+		wantSummary string
+	}{
+		{name: "都未命中", content: "门诊费用", summary: "费用说明", wantNil: true},
+		{name: "只有摘要命中", content: "门诊费用", summary: "报销说明", wantSummary: "<em>报销</em>说明"},
+		{name: "都命中", content: "住院报销", summary: "报销说明", wantContent: "住院<em>报销</em>", wantSummary: "<em>报销</em>说明"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := h.Highlights(tt.content, tt.summary)
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("Highlights() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("Highlights() = nil")
+			}
+			if got.Content != tt.wantContent || got.Summary != tt.wantSummary {
+				t.Errorf("Highlights() = %+v, want content %q summary %q", got, tt.wantContent, tt.wantSummary)
+			}
+		})
+	}
+}
@@ -8,13 +8,19 @@ import (
 // KnowledgeService 知识库业务接口
 type KnowledgeService interface {
 	// CreateKnowledge 创建知识条目
-	CreateKnowledge(ctx context.Context, id, repoName, content string, labels []model.LabelScore, summary string) error
+	CreateKnowledge(ctx context.Context, id, repoName, content string, labels []model.LabelScore, summary string, metadata map[string]interface{}) error
 
 	// GetKnowledgeById 根据ID获取知识条目
 	GetKnowledgeById(ctx context.Context, id string) (*model.KnowledgeItem, error)
 
+	// SearchKnowledgeByKeyword 关键词搜索知识条目（MySQL 全文索引）
+	SearchKnowledgeByKeyword(ctx context.Context, opts *model.SearchOptions) (*model.SearchOutput, error)
+
 	// SearchKnowledgeByHybrid 混合搜索知识条目（关键词+语义）
-	SearchKnowledgeByHybrid(ctx context.Context, query string, repoName string, limit uint64) ([]model.SearchResult, error)
+	SearchKnowledgeByHybrid(ctx context.Context, opts *model.SearchOptions) (*model.SearchOutput, error)
+
+	// SearchKnowledge 按检索模式搜索知识条目
+	SearchKnowledge(ctx context.Context, mode string, opts *model.SearchOptions) (*model.SearchOutput, error)
 
 	// CreateImportTask 创建导入任务
 	CreateImportTask(ctx context.Context, items []model.TaskItem) (string, error)
@@ -23,13 +23,16 @@ func SearchKnowledge(ctx context.Context, query, repoName, mode string, topK int
 	if topK <= 0 {
 		topK = 5
 	}
-	labels, _, err := LLMClassifyByConfig(ctx, query)
-	if err != nil {
-		return nil, fmt.Errorf("LLM标签分析失败: %w", err)
-	}
-	vector, err := Vectorize(ctx, query)
-	if err != nil {
-		return nil, fmt.Errorf("向量化失败: %w", err)
+	// 查询标签优先使用最近质心分类，置信度不足时才调用LLM
+	queryLabels := AnalyzeQueryLabels(ctx, query, nil)
+	labels := queryLabels.Labels
+	vector := queryLabels.Vector
+	if len(vector) == 0 {
+		var err error
+		vector, err = Vectorize(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("向量化失败: %w", err)
+		}
 	}
 
 	switch mode {
@@ -2,10 +2,13 @@ package service
 
 import (
 	"context"
+	"knowledge-system-api/internal/consts"
 	"knowledge-system-api/internal/helper"
 	"knowledge-system-api/internal/model"
 	"knowledge-system-api/internal/service/interfaces"
 
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
 )
 
@@ -14,10 +17,7 @@ func init() {
 	helper.SetVectorize(Vectorize)
 
 	// 初始化向量搜索函数
-	helper.SetVectorSearch(func(repoName string, content string, labels []model.LabelScore, limit uint64) ([]model.VectorSearchResult, error) {
-		ctx := context.Background()
-		return QdrantSearch(ctx, repoName, content, labels, limit)
-	})
+	helper.SetVectorSearch(QdrantSearch)
 
 	// 初始化 LLM 分类函数
 	helper.SetLLMClassify(LLMClassifyByConfig)
@@ -34,9 +34,9 @@ func init() {
 	})
 }
 
-// LLMClassifyByConfig 调用配置指定的大模型推理后端
+// LLMClassifyByConfig 调用配置指定的大模型推理后端，相同内容优先使用模型结果缓存
 func LLMClassifyByConfig(ctx context.Context, content string) (labels []model.LabelScore, summary string, err error) {
-	return GetLLMClient().Classify(ctx, content)
+	return cachedClassify(ctx, GetLLMClient(), content)
 }
 
 // FilterLabels 过滤低分标签
@@ -50,9 +50,9 @@ func FilterLabels(labels []model.LabelScore, threshold float32) []model.LabelSco
 	return filtered
 }
 
-// Vectorize 调用配置指定的向量化后端
+// Vectorize 调用配置指定的向量化后端，相同内容优先使用模型结果缓存
 func Vectorize(ctx context.Context, content string) ([]float32, error) {
-	return GetEmbeddingClient().Embed(ctx, content)
+	return cachedVectorize(ctx, GetEmbeddingClient(), content)
 }
 
 // 知识库服务接口实现
@@ -69,13 +69,16 @@ func KnowledgeService() interfaces.KnowledgeService {
 // 以下方法将通过 logic 层注入实现
 var (
 	// CreateKnowledgeLogic 创建知识条目逻辑
-	CreateKnowledgeLogic func(ctx context.Context, id, repoName, content string, labels []model.LabelScore, summary string) error
+	CreateKnowledgeLogic func(ctx context.Context, id, repoName, content string, labels []model.LabelScore, summary string, metadata map[string]interface{}) error
 
 	// GetKnowledgeByIdLogic 根据ID获取知识条目逻辑
 	GetKnowledgeByIdLogic func(ctx context.Context, id string) (*model.KnowledgeItem, error)
 
+	// SearchKnowledgeByKeywordLogic 关键词搜索知识条目逻辑
+	SearchKnowledgeByKeywordLogic func(ctx context.Context, opts *model.SearchOptions) (*model.SearchOutput, error)
+
 	// SearchKnowledgeByHybridLogic 混合搜索知识条目逻辑
-	SearchKnowledgeByHybridLogic func(ctx context.Context, query string, repoName string, limit uint64) ([]model.SearchResult, error)
+	SearchKnowledgeByHybridLogic func(ctx context.Context, opts *model.SearchOptions) (*model.SearchOutput, error)
 
 	// CreateImportTaskLogic 创建导入任务逻辑
 	CreateImportTaskLogic func(ctx context.Context, items []model.TaskItem) (string, error)
@@ -95,9 +98,10 @@ var (
 
 // RegisterKnowledgeLogic 注册知识库业务逻辑实现
 func RegisterKnowledgeLogic(
-	createKnowledge func(ctx context.Context, id, repoName, content string, labels []model.LabelScore, summary string) error,
+	createKnowledge func(ctx context.Context, id, repoName, content string, labels []model.LabelScore, summary string, metadata map[string]interface{}) error,
 	getKnowledgeById func(ctx context.Context, id string) (*model.KnowledgeItem, error),
-	searchByHybrid func(ctx context.Context, query string, repoName string, limit uint64) ([]model.SearchResult, error),
+	searchByKeyword func(ctx context.Context, opts *model.SearchOptions) (*model.SearchOutput, error),
+	searchByHybrid func(ctx context.Context, opts *model.SearchOptions) (*model.SearchOutput, error),
 	createImportTask func(ctx context.Context, items []model.TaskItem) (string, error),
 	getTaskStatus func(ctx context.Context, taskId string) (*model.ImportTask, error),
 	updateTaskStatus func(ctx context.Context, taskId string, status string, progress uint, processed uint, failed uint, message string) error,
@@ -106,6 +110,7 @@ func RegisterKnowledgeLogic(
 ) {
 	CreateKnowledgeLogic = createKnowledge
 	GetKnowledgeByIdLogic = getKnowledgeById
+	SearchKnowledgeByKeywordLogic = searchByKeyword
 	SearchKnowledgeByHybridLogic = searchByHybrid
 	CreateImportTaskLogic = createImportTask
 	GetTaskStatusLogic = getTaskStatus
@@ -115,11 +120,11 @@ func RegisterKnowledgeLogic(
 }
 
 // CreateKnowledge 创建知识条目
-func (s *knowledgeServiceImpl) CreateKnowledge(ctx context.Context, id, repoName, content string, labels []model.LabelScore, summary string) error {
+func (s *knowledgeServiceImpl) CreateKnowledge(ctx context.Context, id, repoName, content string, labels []model.LabelScore, summary string, metadata map[string]interface{}) error {
 	if CreateKnowledgeLogic == nil {
 		return context.Canceled
 	}
-	return CreateKnowledgeLogic(ctx, id, repoName, content, labels, summary)
+	return CreateKnowledgeLogic(ctx, id, repoName, content, labels, summary, metadata)
 }
 
 // GetKnowledgeById 根据ID获取知识条目
@@ -130,12 +135,47 @@ func (s *knowledgeServiceImpl) GetKnowledgeById(ctx context.Context, id string)
 	return GetKnowledgeByIdLogic(ctx, id)
 }
 
+// SearchKnowledgeByKeyword 关键词搜索知识条目（MySQL 全文索引）
+func (s *knowledgeServiceImpl) SearchKnowledgeByKeyword(ctx context.Context, opts *model.SearchOptions) (*model.SearchOutput, error) {
+	if SearchKnowledgeByKeywordLogic == nil {
+		return nil, context.Canceled
+	}
+	return SearchKnowledgeByKeywordLogic(ctx, opts)
+}
+
 // SearchKnowledgeByHybrid 混合搜索知识条目（关键词+语义）
-func (s *knowledgeServiceImpl) SearchKnowledgeByHybrid(ctx context.Context, query string, repoName string, limit uint64) ([]model.SearchResult, error) {
+func (s *knowledgeServiceImpl) SearchKnowledgeByHybrid(ctx context.Context, opts *model.SearchOptions) (*model.SearchOutput, error) {
 	if SearchKnowledgeByHybridLogic == nil {
 		return nil, context.Canceled
 	}
-	return SearchKnowledgeByHybridLogic(ctx, query, repoName, limit)
+	return SearchKnowledgeByHybridLogic(ctx, opts)
+}
+
+// SearchKnowledge 按检索模式搜索知识条目
+// semantic、lexical 分别为固定使用 dense、lexical 融合策略的混合检索，keyword 使用 MySQL 全文索引
+func (s *knowledgeServiceImpl) SearchKnowledge(ctx context.Context, mode string, opts *model.SearchOptions) (*model.SearchOutput, error) {
+	// 在分发到各知识库之前校验，避免参数错误被记为单个知识库的检索失败
+	if opts.Filter != nil {
+		if err := validateDocumentIDs(opts.Filter.DocumentIDs); err != nil {
+			return nil, err
+		}
+	}
+	switch mode {
+	case consts.SearchModeHybrid, "":
+		return s.SearchKnowledgeByHybrid(ctx, opts)
+	case consts.SearchModeSemantic:
+		opts.Fusion = consts.FusionDense
+		return s.SearchKnowledgeByHybrid(ctx, opts)
+	case consts.SearchModeKeyword:
+		if opts.Strategy != "" && opts.Strategy != consts.SearchStrategyStandard {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter, "keyword 模式不支持 %s 检索策略", opts.Strategy)
+		}
+		return s.SearchKnowledgeByKeyword(ctx, opts)
+	case consts.SearchModeLexical:
+		opts.Fusion = consts.FusionLexical
+		return s.SearchKnowledgeByHybrid(ctx, opts)
+	}
+	return nil, gerror.NewCodef(gcode.CodeInvalidParameter, "不支持的搜索模式: %s", mode)
 }
 
 // CreateImportTask 创建导入任务
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"hash/fnv"
+	"sort"
+)
+
+// lexicalTermID 将词条映射为稀疏向量下标
+// 使用 FNV-1a 哈希，不需要维护全局词表；极少量的哈希冲突对检索效果影响可以忽略
+func lexicalTermID(term string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(term))
+	return h.Sum32()
+}
+
+// lexicalTermFreq 统计词频，返回 词条ID -> 词频 与文档长度（词条总数）
+func lexicalTermFreq(text string) (map[uint32]float64, int) {
+	tokens := Tokenizer().CutForSearch(text)
+	tf := make(map[uint32]float64, len(tokens))
+	for _, tok := range tokens {
+		tf[lexicalTermID(tok)]++
+	}
+	return tf, len(tokens)
+}
+
+// lexicalDocCount 文档计入词法索引统计的文档数，没有词条的文档不计入
+func lexicalDocCount(docLen int) int64 {
+	if docLen > 0 {
+		return 1
+	}
+	return 0
+}
+
+// LexicalDocumentVector 生成文档的 text_sparse 向量，值为 BM25 的词频部分：
+//
+//	tf * (k1 + 1) / (tf + k1 * (1 - b + b * dl / avgdl))
+//
+// IDF 部分由 Qdrant 按集合维护（text_sparse 配置了 idf modifier），查询时自动乘上。
+// avgdl 为知识库当前的平均文档长度，为0时（首个文档）按当前文档长度计算。
+func LexicalDocumentVector(text string, avgdl, k1, b float64) (indices []uint32, values []float32, docLen int) {
+	tf, docLen := lexicalTermFreq(text)
+	if avgdl <= 0 {
+		avgdl = float64(docLen)
+	}
+	norm := 1.0
+	if avgdl > 0 {
+		norm = 1 - b + b*float64(docLen)/avgdl
+	}
+	indices, values = sortedSparse(tf, func(freq float64) float64 {
+		return freq * (k1 + 1) / (freq + k1*norm)
+	})
+	return indices, values, docLen
+}
+
+// LexicalQueryVector 生成查询的 text_sparse 向量，每个查询词权重为1（重复出现的词累加）
+func LexicalQueryVector(text string) (indices []uint32, values []float32) {
+	tf, _ := lexicalTermFreq(text)
+	return sortedSparse(tf, func(freq float64) float64 { return freq })
+}
+
+// sortedSparse 按下标排序输出稀疏向量
+func sortedSparse(tf map[uint32]float64, weight func(float64) float64) ([]uint32, []float32) {
+	indices := make([]uint32, 0, len(tf))
+	for id := range tf {
+		indices = append(indices, id)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	values := make([]float32, len(indices))
+	for i, id := range indices {
+		values[i] = float32(weight(tf[id]))
+	}
+	return indices, values
+}
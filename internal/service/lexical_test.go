@@ -0,0 +1,68 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+func TestLexicalDocumentVector(t *testing.T) {
+	useTestTokenizer()
+	tests := []struct {
+		name       string
+		text       string
+		avgdl      float64
+		k1, b      float64
+		wantDocLen int
+		want       map[string]float64 // 词条 -> BM25 词频权重
+	}{
+		{
+			name: "空文本", text: "", avgdl: 10, k1: 1.2, b: 0.75,
+			wantDocLen: 0, want: map[string]float64{},
+		},
+		{
+			name: "首个文档按自身长度计算", text: "报销 报销 比例", avgdl: 0, k1: 1.2, b: 0.75,
+			wantDocLen: 3, want: map[string]float64{"报销": 2 * 2.2 / (2 + 1.2), "比例": 1},
+		},
+		{
+			name: "短于平均长度的文档权重更高", text: "报销 报销 比例", avgdl: 6, k1: 1.2, b: 0.75,
+			wantDocLen: 3, want: map[string]float64{"报销": 2 * 2.2 / (2 + 1.2*0.625), "比例": 2.2 / (1 + 1.2*0.625)},
+		},
+		{
+			name: "b为0时不按长度归一化", text: "报销 报销 比例", avgdl: 6, k1: 1.2, b: 0,
+			wantDocLen: 3, want: map[string]float64{"报销": 2 * 2.2 / (2 + 1.2), "比例": 1},
+		},
+		{
+			name: "搜索引擎模式的子词计入文档长度", text: "基本医疗保险", avgdl: 0, k1: 1.2, b: 0.75,
+			wantDocLen: 3, want: map[string]float64{"医疗": 1, "保险": 1, "基本医疗保险": 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indices, values, docLen := LexicalDocumentVector(tt.text, tt.avgdl, tt.k1, tt.b)
+			if docLen != tt.wantDocLen {
+				t.Errorf("docLen = %d, want %d", docLen, tt.wantDocLen)
+			}
+			if len(indices) != len(values) || len(indices) != len(tt.want) {
+				t.Fatalf("got %d indices and %d values, want %d", len(indices), len(values), len(tt.want))
+			}
+			if !sort.SliceIsSorted(indices, func(i, j int) bool { return indices[i] < indices[j] }) {
+				t.Errorf("indices not sorted: %v", indices)
+			}
+			got := make(map[uint32]float32, len(indices))
+			for i, id := range indices {
+				got[id] = values[i]
+			}
+			for term, weight := range tt.want {
+				value, ok := got[lexicalTermID(term)]
+				if !ok {
+					t.Errorf("missing term %q", term)
+					continue
+				}
+				if math.Abs(float64(value)-weight) > 1e-6 {
+					t.Errorf("weight of %q = %v, want %v", term, value, weight)
+				}
+			}
+		})
+	}
+}
@@ -19,6 +19,13 @@ import (
 // 这里Classify接口兼容原有标签打分和摘要
 type LLMClient interface {
 	Classify(ctx context.Context, content string) (labels []model.LabelScore, summary string, err error)
+
+	// Generate 通用文本生成，用于重排序、改写等需要自由输出的场景
+	Generate(ctx context.Context, prompt string) (string, error)
+
+	// GenerateStream 流式文本生成，每生成一段文本调用一次 onChunk，返回完整文本
+	// onChunk 返回错误时中止生成
+	GenerateStream(ctx context.Context, prompt string, onChunk func(chunk string) error) (string, error)
 }
 
 var (
@@ -104,18 +111,10 @@ func (a *LangchainOllamaLLMAdapter) Classify(ctx context.Context, content string
 		return nil, "", err
 	}
 	prompt := promptTmpl + content
-	llm, err := ollama.New(
-		ollama.WithModel(a.Model),
-		ollama.WithServerURL(a.BaseURL),
-	)
+	llm, err := a.newLLM(ctx)
 	if err != nil {
-		glog.Errorf(ctx, "ollama.New error: %v", err)
 		return nil, "", err
 	}
-	if llm == nil {
-		glog.Errorf(ctx, "ollama.New returned nil LLM")
-		return nil, "", fmt.Errorf("LLM 初始化失败: BaseURL=%s, Model=%s", a.BaseURL, a.Model)
-	}
 	resp, err := llm.Call(ctx, prompt,
 		llms.WithTemperature(0.8),
 		llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
@@ -156,6 +155,65 @@ func (a *LangchainOllamaLLMAdapter) Classify(ctx context.Context, content string
 	return
 }
 
+// Generate 通用文本生成，使用较低的温度以获得稳定输出
+func (a *LangchainOllamaLLMAdapter) Generate(ctx context.Context, prompt string) (string, error) {
+	llm, err := a.newLLM(ctx)
+	if err != nil {
+		return "", err
+	}
+	resp, err := llm.Call(ctx, prompt, llms.WithTemperature(0.1))
+	if err != nil {
+		glog.Errorf(ctx, "llm.Call error: %v", err)
+		return "", err
+	}
+	return resp, nil
+}
+
+// GenerateStream 流式文本生成，用于答案生成
+func (a *LangchainOllamaLLMAdapter) GenerateStream(ctx context.Context, prompt string, onChunk func(chunk string) error) (string, error) {
+	llm, err := a.newLLM(ctx)
+	if err != nil {
+		return "", err
+	}
+	resp, err := llm.Call(ctx, prompt,
+		llms.WithTemperature(0.3),
+		llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+			return onChunk(string(chunk))
+		}),
+	)
+	if err != nil {
+		glog.Errorf(ctx, "llm.Call error: %v", err)
+		return "", err
+	}
+	return resp, nil
+}
+
+// CacheIdentity 缓存标识：模型名称与提示词模板内容的摘要，修改模型或提示词后缓存自动失效
+func (a *LangchainOllamaLLMAdapter) CacheIdentity() (modelName, version string) {
+	promptTmpl, err := LoadPromptTemplate(a.PromptPath)
+	if err == nil {
+		version = contentHash(promptTmpl)[:16]
+	}
+	return "ollama/" + a.Model, version
+}
+
+// newLLM 创建 ollama 客户端
+func (a *LangchainOllamaLLMAdapter) newLLM(ctx context.Context) (*ollama.LLM, error) {
+	llm, err := ollama.New(
+		ollama.WithModel(a.Model),
+		ollama.WithServerURL(a.BaseURL),
+	)
+	if err != nil {
+		glog.Errorf(ctx, "ollama.New error: %v", err)
+		return nil, err
+	}
+	if llm == nil {
+		glog.Errorf(ctx, "ollama.New returned nil LLM")
+		return nil, fmt.Errorf("LLM 初始化失败: BaseURL=%s, Model=%s", a.BaseURL, a.Model)
+	}
+	return llm, nil
+}
+
 // LoadPromptTemplate 读取prompt模板内容
 func LoadPromptTemplate(path string) (string, error) {
 	b, err := os.ReadFile(path)
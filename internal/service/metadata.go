@@ -0,0 +1,209 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"knowledge-system-api/internal/consts"
+	"knowledge-system-api/internal/model"
+)
+
+// metadataKeyPattern 元数据字段名：字母或下划线开头，可包含字母、数字、下划线，最长64个字符
+// 字段名会拼接进 Qdrant payload 路径与 MySQL JSON 路径，只允许安全字符
+var metadataKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,63}$`)
+
+// ValidateMetadataKey 校验元数据字段名是否合法
+func ValidateMetadataKey(key string) error {
+	if !metadataKeyPattern.MatchString(key) {
+		return gerror.NewCodef(gcode.CodeInvalidParameter, "元数据字段名不合法: %s，只允许字母、数字、_，且不能以数字开头", key)
+	}
+	return nil
+}
+
+// IsValidMetadataType 检查元数据字段类型是否合法
+func IsValidMetadataType(typ string) bool {
+	switch typ {
+	case consts.MetadataTypeKeyword, consts.MetadataTypeInteger, consts.MetadataTypeFloat,
+		consts.MetadataTypeBool, consts.MetadataTypeDatetime:
+		return true
+	}
+	return false
+}
+
+// MetadataFieldType 获取字段在知识库中声明的类型，未声明时返回空字符串
+func MetadataFieldType(schema []model.MetadataField, key string) string {
+	for _, f := range schema {
+		if f.Key == key {
+			return f.Type
+		}
+	}
+	return ""
+}
+
+// NormalizeMetadata 按知识库的字段声明转换元数据的值类型，未声明的字段原样保留
+// 声明过的字段允许数组值（例如一条政策同时适用多个地区），数组中每个元素都按声明类型转换
+func NormalizeMetadata(metadata map[string]interface{}, schema []model.MetadataField) (map[string]interface{}, error) {
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+
+	normalized := make(map[string]interface{}, len(metadata))
+	for key, value := range metadata {
+		if err := ValidateMetadataKey(key); err != nil {
+			return nil, err
+		}
+		typ := MetadataFieldType(schema, key)
+		if typ == "" || value == nil {
+			normalized[key] = value
+			continue
+		}
+
+		if values, ok := value.([]interface{}); ok {
+			converted := make([]interface{}, 0, len(values))
+			for _, v := range values {
+				c, err := metadataValue(typ, v)
+				if err != nil {
+					return nil, gerror.WrapCodef(gcode.CodeInvalidParameter, err, "元数据字段 %s", key)
+				}
+				converted = append(converted, c)
+			}
+			normalized[key] = converted
+			continue
+		}
+
+		c, err := metadataValue(typ, value)
+		if err != nil {
+			return nil, gerror.WrapCodef(gcode.CodeInvalidParameter, err, "元数据字段 %s", key)
+		}
+		normalized[key] = c
+	}
+	return normalized, nil
+}
+
+// NormalizeMetadataFilter 校验元数据过滤条件并按字段类型转换条件值，返回转换后的条件与字段类型
+// 字段未声明时按条件值推断类型：字符串为 keyword（范围条件为 datetime），数字为 integer/float，布尔值为 bool
+func NormalizeMetadataFilter(f model.MetadataFilter, schema []model.MetadataField) (*model.MetadataFilter, string, error) {
+	if err := ValidateMetadataKey(f.Key); err != nil {
+		return nil, "", err
+	}
+	if len(f.Match) == 0 && f.Gte == nil && f.Lte == nil {
+		return nil, "", gerror.NewCodef(gcode.CodeInvalidParameter, "元数据条件 %s 至少需要 match、gte、lte 之一", f.Key)
+	}
+
+	typ := MetadataFieldType(schema, f.Key)
+	if typ == "" {
+		typ = inferMetadataType(f)
+	}
+	if typ == consts.MetadataTypeKeyword && (f.Gte != nil || f.Lte != nil) {
+		return nil, "", gerror.NewCodef(gcode.CodeInvalidParameter, "元数据字段 %s 为关键字类型，不支持范围条件", f.Key)
+	}
+	if typ == consts.MetadataTypeBool && (f.Gte != nil || f.Lte != nil) {
+		return nil, "", gerror.NewCodef(gcode.CodeInvalidParameter, "元数据字段 %s 为布尔类型，不支持范围条件", f.Key)
+	}
+
+	out := &model.MetadataFilter{Key: f.Key}
+	var err error
+	for _, v := range f.Match {
+		c, err := metadataValue(typ, v)
+		if err != nil {
+			return nil, "", gerror.WrapCodef(gcode.CodeInvalidParameter, err, "元数据条件 %s", f.Key)
+		}
+		out.Match = append(out.Match, c)
+	}
+	if f.Gte != nil {
+		if out.Gte, err = metadataValue(typ, f.Gte); err != nil {
+			return nil, "", gerror.WrapCodef(gcode.CodeInvalidParameter, err, "元数据条件 %s", f.Key)
+		}
+	}
+	if f.Lte != nil {
+		if out.Lte, err = metadataValue(typ, f.Lte); err != nil {
+			return nil, "", gerror.WrapCodef(gcode.CodeInvalidParameter, err, "元数据条件 %s", f.Key)
+		}
+	}
+	return out, typ, nil
+}
+
+// inferMetadataType 根据条件值推断未声明字段的类型
+func inferMetadataType(f model.MetadataFilter) string {
+	values := append([]interface{}{f.Gte, f.Lte}, f.Match...)
+	typ := ""
+	for _, v := range values {
+		switch x := v.(type) {
+		case bool:
+			return consts.MetadataTypeBool
+		case string:
+			if f.Gte != nil || f.Lte != nil {
+				return consts.MetadataTypeDatetime
+			}
+			return consts.MetadataTypeKeyword
+		case float64:
+			if x != math.Trunc(x) {
+				return consts.MetadataTypeFloat
+			}
+			typ = consts.MetadataTypeInteger
+		case json.Number:
+			if _, err := x.Int64(); err != nil {
+				return consts.MetadataTypeFloat
+			}
+			typ = consts.MetadataTypeInteger
+		case int, int32, int64, uint, uint32, uint64:
+			typ = consts.MetadataTypeInteger
+		case float32:
+			return consts.MetadataTypeFloat
+		}
+	}
+	if typ == "" {
+		return consts.MetadataTypeKeyword
+	}
+	return typ
+}
+
+// metadataValue 将单个值转换为字段类型对应的存储形式
+func metadataValue(typ string, v interface{}) (interface{}, error) {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		return nil, fmt.Errorf("值必须是标量: %v", v)
+	}
+
+	s := strings.TrimSpace(fmt.Sprint(v))
+	switch typ {
+	case consts.MetadataTypeKeyword:
+		return fmt.Sprint(v), nil
+	case consts.MetadataTypeInteger:
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return i, nil
+		}
+		// JSON 数字解码为 float64，整数值也允许
+		if f, err := strconv.ParseFloat(s, 64); err == nil && f == math.Trunc(f) {
+			return int64(f), nil
+		}
+		return nil, fmt.Errorf("不是整数: %v", v)
+	case consts.MetadataTypeFloat:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("不是数字: %v", v)
+		}
+		return f, nil
+	case consts.MetadataTypeBool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("不是布尔值: %v", v)
+		}
+		return b, nil
+	case consts.MetadataTypeDatetime:
+		t, err := gtime.StrToTime(s)
+		if err != nil {
+			return nil, fmt.Errorf("不是日期时间: %v", v)
+		}
+		return payloadTime(t.Time), nil
+	}
+	return nil, fmt.Errorf("不支持的元数据类型: %s", typ)
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/gogf/gf/v2/frame/g"
+
+	"knowledge-system-api/internal/dao"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/model/do"
+	"knowledge-system-api/internal/model/entity"
+)
+
+// 模型结果缓存类型
+const (
+	modelCacheEmbedding = "embedding"
+	modelCacheClassify  = "classify"
+)
+
+// CacheableModel 可缓存结果的模型客户端
+// CacheIdentity 返回模型名称与版本（如提示词内容的摘要），两者都参与缓存键，变化后旧的缓存记录不再命中
+type CacheableModel interface {
+	CacheIdentity() (modelName, version string)
+}
+
+// classifyCacheValue 标签打分结果的缓存内容
+type classifyCacheValue struct {
+	Labels  []model.LabelScore `json:"labels"`
+	Summary string             `json:"summary"`
+}
+
+// modelCacheEnabled 是否启用模型结果缓存（配置 model_cache.enabled）
+func modelCacheEnabled(ctx context.Context) bool {
+	return g.Cfg().MustGet(ctx, "model_cache.enabled", false).Bool()
+}
+
+// cachedVectorize 查询向量化缓存，未命中时调用 embed 并写入缓存
+func cachedVectorize(ctx context.Context, client EmbeddingClient, content string) ([]float32, error) {
+	m, ok := client.(CacheableModel)
+	if !ok || !modelCacheEnabled(ctx) {
+		return client.Embed(ctx, content)
+	}
+
+	modelName, version := m.CacheIdentity()
+	var vector []float32
+	if loadModelCache(ctx, modelCacheEmbedding, modelName, version, content, &vector) && len(vector) > 0 {
+		return vector, nil
+	}
+	vector, err := client.Embed(ctx, content)
+	if err != nil {
+		return nil, err
+	}
+	saveModelCache(ctx, modelCacheEmbedding, modelName, version, content, vector)
+	return vector, nil
+}
+
+// cachedClassify 查询标签打分缓存，未命中时调用大模型并写入缓存
+func cachedClassify(ctx context.Context, client LLMClient, content string) ([]model.LabelScore, string, error) {
+	m, ok := client.(CacheableModel)
+	if !ok || !modelCacheEnabled(ctx) {
+		return client.Classify(ctx, content)
+	}
+
+	modelName, version := m.CacheIdentity()
+	var cached classifyCacheValue
+	if loadModelCache(ctx, modelCacheClassify, modelName, version, content, &cached) && len(cached.Labels) > 0 {
+		return cached.Labels, cached.Summary, nil
+	}
+	labels, summary, err := client.Classify(ctx, content)
+	if err != nil {
+		return nil, "", err
+	}
+	saveModelCache(ctx, modelCacheClassify, modelName, version, content, classifyCacheValue{Labels: labels, Summary: summary})
+	return labels, summary, nil
+}
+
+// loadModelCache 读取缓存记录并解析到 value，未命中或读取失败时返回 false
+func loadModelCache(ctx context.Context, kind, modelName, version, content string, value interface{}) bool {
+	var row entity.ModelCache
+	err := dao.ModelCache.Ctx(ctx).Where(do.ModelCache{
+		Kind:        kind,
+		Model:       modelName,
+		Version:     version,
+		ContentHash: contentHash(content),
+	}).Scan(&row)
+	if err != nil {
+		g.Log().Warningf(ctx, "读取模型结果缓存失败: %v", err)
+		return false
+	}
+	if row.Id == 0 {
+		return false
+	}
+	if err := json.Unmarshal([]byte(row.Value), value); err != nil {
+		g.Log().Warningf(ctx, "解析模型结果缓存失败: %v", err)
+		return false
+	}
+	return true
+}
+
+// saveModelCache 写入缓存记录，并发写入相同内容时忽略重复记录，失败只记录日志
+func saveModelCache(ctx context.Context, kind, modelName, version, content string, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	_, err = dao.ModelCache.Ctx(ctx).Data(do.ModelCache{
+		Kind:        kind,
+		Model:       modelName,
+		Version:     version,
+		ContentHash: contentHash(content),
+		Value:       string(data),
+	}).InsertIgnore()
+	if err != nil {
+		g.Log().Warningf(ctx, "写入模型结果缓存失败: %v", err)
+	}
+}
+
+// contentHash 计算内容的 SHA-256
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
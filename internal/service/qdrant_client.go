@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"knowledge-system-api/internal/consts"
 	"knowledge-system-api/internal/helper"
 	"knowledge-system-api/internal/model"
 	"sync"
@@ -18,6 +19,9 @@ var (
 	qdrantOnce           sync.Once
 	qdrantClientOnce     sync.Once
 	qdrantClient         *qdrant.Client
+
+	// collectionVectors 集合别名 -> 集合中存在的命名向量，集合创建后向量配置不再变化，可以长期缓存
+	collectionVectors sync.Map
 )
 
 // QdrantConfig Qdrant配置
@@ -25,6 +29,8 @@ type QdrantConfig struct {
 	Host      string `yaml:"host" json:"host"`
 	Port      int    `yaml:"port" json:"port"`
 	Dimension uint64 `yaml:"dimension" json:"dimension"`
+	// SummaryVector 是否为新建集合增加 summary_dense 摘要向量，已有集合需重建后才能使用
+	SummaryVector bool `yaml:"summary_vector" json:"summary_vector"`
 }
 
 // LoadQdrantConfig 加载Qdrant配置
@@ -138,14 +144,141 @@ func DeleteQdrantCollection(ctx context.Context, collectionName string) error {
 	return nil
 }
 
+// QdrantCollectionExists 检查集合（或别名）是否存在
+func QdrantCollectionExists(ctx context.Context, collectionName string) (bool, error) {
+	client, err := GetQdrantClient(ctx)
+	if err != nil {
+		return false, fmt.Errorf("获取Qdrant客户端失败: %w", err)
+	}
+	return client.CollectionExists(ctx, collectionName)
+}
+
+// QdrantCreateCollection 创建知识库物理集合，包含 content_dense 密集向量、labels_sparse 标签稀疏向量
+// 与 text_sparse 词法稀疏向量，开启 qdrant.summary_vector 时额外包含 summary_dense 摘要向量
+func QdrantCreateCollection(ctx context.Context, collectionName string) error {
+	client, err := GetQdrantClient(ctx)
+	if err != nil {
+		return fmt.Errorf("获取Qdrant客户端失败: %w", err)
+	}
+
+	denseVectors := map[string]*qdrant.VectorParams{
+		consts.VectorContentDense: {
+			Size:     qdrantConfigInstance.Dimension, // 密集向量维度
+			Distance: qdrant.Distance_Cosine,
+		},
+	}
+	if qdrantConfigInstance.SummaryVector {
+		denseVectors[consts.VectorSummaryDense] = &qdrant.VectorParams{
+			Size:     qdrantConfigInstance.Dimension,
+			Distance: qdrant.Distance_Cosine,
+		}
+	}
+	vectorsConfig := qdrant.NewVectorsConfigMap(denseVectors)
+	sparseVectorsConfig := qdrant.NewSparseVectorsConfig(map[string]*qdrant.SparseVectorParams{
+		consts.VectorLabelsSparse: {},
+		// 词法向量只存储 BM25 的词频部分，IDF 由 Qdrant 按集合统计
+		consts.VectorTextSparse: {Modifier: qdrant.Modifier_Idf.Enum()},
+	})
+
+	err = client.CreateCollection(ctx, &qdrant.CreateCollection{
+		CollectionName:      collectionName,
+		VectorsConfig:       vectorsConfig,
+		SparseVectorsConfig: sparseVectorsConfig,
+	})
+	if err != nil {
+		return fmt.Errorf("创建集合失败: %w", err)
+	}
+	qdrantCreatePayloadIndexes(ctx, client, collectionName)
+	g.Log().Infof(ctx, "成功创建集合 %s，摘要向量: %v", collectionName, qdrantConfigInstance.SummaryVector)
+	return nil
+}
+
+// qdrantVectorNames 获取集合（或别名）中存在的命名向量，包括密集向量与稀疏向量
+func qdrantVectorNames(ctx context.Context, client *qdrant.Client, collectionName string) (map[string]bool, error) {
+	if v, ok := collectionVectors.Load(collectionName); ok {
+		return v.(map[string]bool), nil
+	}
+
+	info, err := client.GetCollectionInfo(ctx, collectionName)
+	if err != nil {
+		return nil, fmt.Errorf("获取集合信息失败: %w", err)
+	}
+
+	names := make(map[string]bool)
+	params := info.GetConfig().GetParams()
+	for name := range params.GetVectorsConfig().GetParamsMap().GetMap() {
+		names[name] = true
+	}
+	for name := range params.GetSparseVectorsConfig().GetMap() {
+		names[name] = true
+	}
+	collectionVectors.Store(collectionName, names)
+	return names, nil
+}
+
+// labelsToSparse 将标签转换为稀疏向量，未在预定义字典中的标签会被忽略
+func labelsToSparse(ctx context.Context, labels []model.LabelScore) (indices []uint32, values []float32) {
+	for _, l := range labels {
+		id, found := helper.Dictionary().GetID(ctx, l.Name)
+		if found {
+			indices = append(indices, id)
+			values = append(values, l.Score)
+		} else {
+			g.Log().Warningf(ctx, "标签 '%s' 在预定义字典中未找到，已忽略。", l.Name)
+		}
+	}
+	return
+}
+
+// QdrantCreateAlias 创建集合别名
+func QdrantCreateAlias(ctx context.Context, aliasName, collectionName string) error {
+	client, err := GetQdrantClient(ctx)
+	if err != nil {
+		return fmt.Errorf("获取Qdrant客户端失败: %w", err)
+	}
+	if err := client.CreateAlias(ctx, aliasName, collectionName); err != nil {
+		return fmt.Errorf("创建集合别名失败: %w", err)
+	}
+	g.Log().Infof(ctx, "成功创建集合别名 %s -> %s", aliasName, collectionName)
+	return nil
+}
+
+// QdrantCountPoints 统计集合（或别名）中的向量数量
+func QdrantCountPoints(ctx context.Context, collectionName string) (uint64, error) {
+	client, err := GetQdrantClient(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("获取Qdrant客户端失败: %w", err)
+	}
+	return client.Count(ctx, &qdrant.CountPoints{
+		CollectionName: collectionName,
+		Exact:          qdrant.PtrOf(true),
+	})
+}
+
+// qdrantExistingPoint 读取已有点的创建时间与正文，点不存在时返回 nil
+func qdrantExistingPoint(ctx context.Context, client *qdrant.Client, collection, id string) (*qdrant.RetrievedPoint, error) {
+	points, err := client.Get(ctx, &qdrant.GetPoints{
+		CollectionName: collection,
+		Ids:            []*qdrant.PointId{qdrant.NewIDUUID(id)},
+		WithPayload:    qdrant.NewWithPayloadInclude(payloadCreatedAt, "content"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("读取已有向量失败: %w", err)
+	}
+	if len(points) == 0 {
+		return nil, nil
+	}
+	return points[0], nil
+}
+
 // QdrantUpsert 将知识条目写入Qdrant向量库
-func QdrantUpsert(ctx context.Context, repoName string, id string, content string, summary string, labels []model.LabelScore) error {
+func QdrantUpsert(ctx context.Context, repoName string, id string, content string, summary string, labels []model.LabelScore, metadata map[string]interface{}) error {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	// 参数检查
 	if repoName == "" {
-		return fmt.Errorf("QdrantUpsert: 集合名称不能为空")
+		return fmt.Errorf("QdrantUpsert: 知识库名称不能为空")
 	}
 
 	if id == "" {
@@ -167,43 +300,33 @@ func QdrantUpsert(ctx context.Context, repoName string, id string, content strin
 		})
 	}
 
-	// 3. 构建payload
-	payload := qdrant.NewValueMap(map[string]any{
-		"content": content,
-		"summary": summary,
-		"labels":  labelPoints,
-	})
+	// 3. 构建payload，时间字段与元数据用于检索过滤
+	now := payloadTime(time.Now())
+	payloadMap := map[string]any{
+		"content":        content,
+		"summary":        summary,
+		payloadLabels:    labelPoints,
+		payloadCreatedAt: now,
+		payloadUpdatedAt: now,
+	}
+	if len(metadata) > 0 {
+		payloadMap[payloadMetadata] = metadata
+	}
+	payload := qdrant.NewValueMap(payloadMap)
 
-	// 4. 确保集合存在
-	collectionExists, err := client.CollectionExists(ctx, repoName)
+	// 4. 解析知识库对应的集合别名，不存在时自动创建集合与别名
+	repo, err := Repo().Ensure(ctx, repoName)
 	if err != nil {
-		g.Log().Warningf(ctx, "检查集合是否存在时出错: %v", err)
+		return fmt.Errorf("QdrantUpsert: %w", err)
 	}
 
-	// 如果集合不存在，创建集合
-	if !collectionExists {
-		// 创建密集向量和稀疏向量配置
-		vectorsConfig := qdrant.NewVectorsConfigMap(map[string]*qdrant.VectorParams{
-			"content_dense": {
-				Size:     qdrantConfigInstance.Dimension, // 密集向量维度
-				Distance: qdrant.Distance_Cosine,
-			},
-		})
-		sparseVectorsConfig := qdrant.NewSparseVectorsConfig(map[string]*qdrant.SparseVectorParams{
-			"labels_sparse": {},
-		})
-
-		err = client.CreateCollection(ctx, &qdrant.CreateCollection{
-			CollectionName:      repoName,
-			VectorsConfig:       vectorsConfig,
-			SparseVectorsConfig: sparseVectorsConfig,
-		})
-		if err != nil {
-			return fmt.Errorf("创建集合失败: %w", err)
-		}
-		g.Log().Infof(ctx, "成功创建集合 %s，包含向量: content_dense, labels_sparse", repoName)
-	} else {
-		g.Log().Debugf(ctx, "集合 %s 已存在，跳过创建", repoName)
+	// 更新已有的点时保留原创建时间，只刷新更新时间
+	existing, err := qdrantExistingPoint(ctx, client, repo.Alias, id)
+	if err != nil {
+		return fmt.Errorf("QdrantUpsert: %w", err)
+	}
+	if createdAt := existing.GetPayload()[payloadCreatedAt].GetStringValue(); createdAt != "" {
+		payload[payloadCreatedAt] = qdrant.NewValueString(createdAt)
 	}
 
 	// 生成密集向量
@@ -214,29 +337,47 @@ func QdrantUpsert(ctx context.Context, repoName string, id string, content strin
 	}
 
 	// 生成稀疏向量
-	var sparseIndices []uint32
-	var sparseValues []float32
+	sparseIndices, sparseValues := labelsToSparse(ctx, labels)
 
-	for _, l := range labels {
-		// 调用GetID方法
-		id, found := helper.Dictionary().GetID(ctx, l.Name)
-		if found {
-			sparseIndices = append(sparseIndices, id)
-			sparseValues = append(sparseValues, l.Score)
-		} else {
-			// 如果标签未在字典中找到，可以选择记录一个警告日志
-			g.Log().Warningf(ctx, "标签 '%s' 在预定义字典中未找到，已忽略。", l.Name)
+	vectorsMap := map[string]*qdrant.Vector{
+		consts.VectorContentDense: qdrant.NewVectorDense(denseVector),
+		consts.VectorLabelsSparse: qdrant.NewVectorSparse(sparseIndices, sparseValues),
+	}
+
+	// 集合包含摘要向量时，同时写入摘要的密集向量
+	vectorNames, err := qdrantVectorNames(ctx, client, repo.Alias)
+	if err != nil {
+		return fmt.Errorf("QdrantUpsert: %w", err)
+	}
+	if vectorNames[consts.VectorSummaryDense] && summary != "" {
+		summaryVector, err := helper.Vectorize(ctx, summary)
+		if err != nil {
+			return fmt.Errorf("向量化摘要失败: %w", err)
 		}
+		vectorsMap[consts.VectorSummaryDense] = qdrant.NewVectorDense(summaryVector)
 	}
 
-	vectorsMap := map[string]*qdrant.Vector{
-		"content_dense": qdrant.NewVectorDense(denseVector),
-		"labels_sparse": qdrant.NewVectorSparse(sparseIndices, sparseValues),
+	// 集合包含词法向量时，按知识库当前的平均文档长度计算 BM25 词频权重
+	var docLen int
+	if vectorNames[consts.VectorTextSparse] {
+		docCount, tokenCount, err := Repo().LexicalStats(ctx, repoName)
+		if err != nil {
+			return fmt.Errorf("获取词法索引统计失败: %w", err)
+		}
+		var avgdl float64
+		if docCount > 0 {
+			avgdl = float64(tokenCount) / float64(docCount)
+		}
+		bm25 := LoadSearchConfig(ctx).BM25
+		var textIndices []uint32
+		var textValues []float32
+		textIndices, textValues, docLen = LexicalDocumentVector(content, avgdl, bm25.K1, bm25.B)
+		vectorsMap[consts.VectorTextSparse] = qdrant.NewVectorSparse(textIndices, textValues)
 	}
 
 	// 5. 上传点
 	_, err = client.Upsert(ctx, &qdrant.UpsertPoints{
-		CollectionName: repoName,
+		CollectionName: repo.Alias,
 		Points: []*qdrant.PointStruct{
 			{
 				Id: qdrant.NewIDUUID(id),
@@ -256,128 +397,21 @@ func QdrantUpsert(ctx context.Context, repoName string, id string, content strin
 	if err != nil {
 		return fmt.Errorf("上传向量到Qdrant失败: %w", err)
 	}
+	InvalidateSearchCache(ctx, repoName)
 
-	return nil
-}
-
-// QdrantSearch 向量搜索
-func QdrantSearch(ctx context.Context, repoName string, content string, labels []model.LabelScore, limit uint64) ([]model.VectorSearchResult, error) {
-	// 参数检查
-	if repoName == "" {
-		return nil, fmt.Errorf("QdrantSearch: 集合名称不能为空")
-	}
-
-	// 获取客户端，如果不存在则初始化
-	client, err := GetQdrantClient(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("QdrantSearch: %w", err)
-	}
-
-	// 创建超时上下文
-	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-
-	// 生成稀疏向量 (用于预查询)
-	var sparseIndices []uint32
-	var sparseValues []float32
-
-	for _, l := range labels {
-		// 调用GetID方法
-		id, found := helper.Dictionary().GetID(ctx, l.Name)
-		if found {
-			sparseIndices = append(sparseIndices, id)
-			sparseValues = append(sparseValues, l.Score)
-		} else {
-			// 如果标签未在字典中找到，可以选择记录一个警告日志
-			g.Log().Warningf(ctx, "标签 '%s' 在预定义字典中未找到，已忽略。", l.Name)
+	// 更新已有的点时扣除旧正文的统计，避免重复导入使文档数与平均文档长度不断增长
+	if vectorNames[consts.VectorTextSparse] {
+		var oldLen int
+		if existing != nil {
+			_, oldLen = lexicalTermFreq(existing.GetPayload()["content"].GetStringValue())
 		}
-	}
-
-	// 日志记录稀疏向量信息
-	if len(sparseIndices) > 0 {
-		g.Log().Debugf(ctx, "搜索使用稀疏向量: 索引数量=%d", len(sparseIndices))
-	} else {
-		g.Log().Warningf(ctx, "未使用稀疏向量过滤，可能影响检索精度")
-	}
-
-	// 生成密集向量 (用于主查询)
-	vector, err := helper.Vectorize(ctx, content)
-
-	if err != nil {
-		return nil, fmt.Errorf("向量化内容失败: %w", err)
-	}
-
-	prefetchLimit := limit * 3 // 预查询的限制，通常设置为主查询的3倍，以确保有足够的候选项
-	labelsSparse := "labels_sparse"
-	prefetchQuery := &qdrant.PrefetchQuery{
-		Using: &labelsSparse, // 使用稀疏向量进行预查询
-		Query: &qdrant.Query{
-			Variant: &qdrant.Query_Nearest{
-				Nearest: &qdrant.VectorInput{
-					Variant: &qdrant.VectorInput_Sparse{
-						Sparse: &qdrant.SparseVector{
-							Values:  sparseValues,
-							Indices: sparseIndices,
-						},
-					},
-				},
-			},
-		}, // 将上面定义的查询逻辑放入
-		Limit: &prefetchLimit, // 直接在 PrefetchQuery 层面设置 Limit
-	}
-
-	// 定义第二阶段的主查询 (基于密集向量)
-	contentDense := "content_dense"
-	mainQuery := &qdrant.Query{
-		Variant: &qdrant.Query_Nearest{
-			Nearest: &qdrant.VectorInput{
-				Variant: &qdrant.VectorInput_Dense{
-					Dense: &qdrant.DenseVector{
-						Data: vector,
-					},
-				},
-			},
-		},
-	}
-
-	queryPointsRequest := &qdrant.QueryPoints{
-		CollectionName: repoName,
-		Prefetch:       []*qdrant.PrefetchQuery{prefetchQuery}, // 放入预查询
-		Query:          mainQuery,                              // 放入主查询
-		Using:          &contentDense,                          // 指定使用的向量名称
-		Limit:          &limit,                                 // 最终限制
-		WithPayload:    qdrant.NewWithPayload(true),            // 返回Payload
-	}
-
-	// 执行搜索
-	results, err := client.Query(timeoutCtx, queryPointsRequest)
-	if err != nil {
-		g.Log().Errorf(ctx, "Qdrant搜索失败: %v", err)
-		return nil, fmt.Errorf("qdrant搜索失败: %w", err)
-	}
-
-	// 处理结果
-	var searchResults []model.VectorSearchResult
-	for _, point := range results {
-		// 获取payload
-		payload := make(map[string]interface{})
-
-		// 复制所有payload字段
-		if point.Payload != nil {
-			for k, v := range point.Payload {
-				payload[k] = v
+		docDelta := lexicalDocCount(docLen) - lexicalDocCount(oldLen)
+		if docDelta != 0 || docLen != oldLen {
+			if err := Repo().AddLexicalStats(ctx, repoName, docDelta, int64(docLen-oldLen)); err != nil {
+				g.Log().Warningf(ctx, "更新词法索引统计失败: %v", err)
 			}
 		}
-
-		searchResults = append(searchResults, model.VectorSearchResult{
-			ID:      point.Id.GetUuid(),
-			Score:   point.Score,
-			Payload: payload,
-		})
-
-		g.Log().Debugf(ctx, "找到结果: ID=%s, Score=%.4f, Payload=%v", point.Id.String(), point.Score, payload)
 	}
 
-	g.Log().Debugf(ctx, "Qdrant搜索完成，找到 %d 条结果", len(searchResults))
-	return searchResults, nil
+	return nil
 }
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/google/uuid"
+	"github.com/qdrant/go-client/qdrant"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"knowledge-system-api/internal/consts"
+	"knowledge-system-api/internal/model"
+)
+
+// payload 中参与过滤的字段
+const (
+	payloadLabels       = "labels"
+	payloadLabelID      = "label_id"
+	payloadLabelScore   = "score"
+	payloadCreatedAt    = "created_at"
+	payloadUpdatedAt    = "updated_at"
+	payloadMetadata     = "metadata"
+	payloadLabelIDPath  = payloadLabels + "[]." + payloadLabelID
+	payloadLabelScoreAt = payloadLabels + "[]." + payloadLabelScore
+)
+
+// qdrantPayloadIndexes 新建集合时创建的 payload 索引，未建索引的字段在大集合上过滤很慢
+var qdrantPayloadIndexes = map[string]qdrant.FieldType{
+	payloadLabelIDPath:  qdrant.FieldType_FieldTypeKeyword,
+	payloadLabelScoreAt: qdrant.FieldType_FieldTypeFloat,
+	payloadCreatedAt:    qdrant.FieldType_FieldTypeDatetime,
+	payloadUpdatedAt:    qdrant.FieldType_FieldTypeDatetime,
+}
+
+// qdrantCreatePayloadIndexes 为集合创建过滤字段的 payload 索引
+func qdrantCreatePayloadIndexes(ctx context.Context, client *qdrant.Client, collectionName string) {
+	for field, fieldType := range qdrantPayloadIndexes {
+		_, err := client.CreateFieldIndex(ctx, &qdrant.CreateFieldIndexCollection{
+			CollectionName: collectionName,
+			FieldName:      field,
+			FieldType:      fieldType.Enum(),
+			Wait:           qdrant.PtrOf(true),
+		})
+		if err != nil {
+			// 索引只影响过滤性能，不影响写入与检索
+			g.Log().Warningf(ctx, "创建payload索引 %s 失败: %v", field, err)
+		}
+	}
+}
+
+// qdrantMetadataFieldTypes 元数据字段类型对应的 payload 索引类型
+var qdrantMetadataFieldTypes = map[string]qdrant.FieldType{
+	consts.MetadataTypeKeyword:  qdrant.FieldType_FieldTypeKeyword,
+	consts.MetadataTypeInteger:  qdrant.FieldType_FieldTypeInteger,
+	consts.MetadataTypeFloat:    qdrant.FieldType_FieldTypeFloat,
+	consts.MetadataTypeBool:     qdrant.FieldType_FieldTypeBool,
+	consts.MetadataTypeDatetime: qdrant.FieldType_FieldTypeDatetime,
+}
+
+// QdrantCreateMetadataIndexes 为知识库声明的元数据字段创建 payload 索引
+func QdrantCreateMetadataIndexes(ctx context.Context, collectionName string, fields []model.MetadataField) error {
+	client, err := GetQdrantClient(ctx)
+	if err != nil {
+		return fmt.Errorf("获取Qdrant客户端失败: %w", err)
+	}
+	for _, f := range fields {
+		_, err := client.CreateFieldIndex(ctx, &qdrant.CreateFieldIndexCollection{
+			CollectionName: collectionName,
+			FieldName:      payloadMetadata + "." + f.Key,
+			FieldType:      qdrantMetadataFieldTypes[f.Type].Enum(),
+			Wait:           qdrant.PtrOf(true),
+		})
+		if err != nil {
+			return fmt.Errorf("创建元数据索引 %s 失败: %w", f.Key, err)
+		}
+	}
+	return nil
+}
+
+// payloadTime 将时间格式化为 payload 中的 RFC3339 字符串，Qdrant 按 datetime 索引比较
+func payloadTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// qdrantSearchFilter 将检索过滤条件转换为 Qdrant payload 过滤器，各条件之间为“且”的关系
+// schema 为知识库的元数据字段声明，用于确定元数据条件的值类型
+func qdrantSearchFilter(f *model.SearchFilter, schema []model.MetadataField) (*qdrant.Filter, error) {
+	if f == nil {
+		return nil, nil
+	}
+
+	var must []*qdrant.Condition
+	// 每个标签条件都要求同一个标签对象同时满足名称与最低分数，必须使用 nested 过滤
+	for _, l := range f.Labels {
+		labelMust := []*qdrant.Condition{qdrant.NewMatchKeyword(payloadLabelID, l.Name)}
+		if l.MinScore > 0 {
+			labelMust = append(labelMust, qdrant.NewRange(payloadLabelScore, &qdrant.Range{
+				Gte: qdrant.PtrOf(float64(l.MinScore)),
+			}))
+		}
+		must = append(must, qdrant.NewNestedFilter(payloadLabels, &qdrant.Filter{Must: labelMust}))
+	}
+	if r := qdrantDatetimeRange(f.CreatedFrom, f.CreatedTo); r != nil {
+		must = append(must, qdrant.NewDatetimeRange(payloadCreatedAt, r))
+	}
+	if r := qdrantDatetimeRange(f.UpdatedFrom, f.UpdatedTo); r != nil {
+		must = append(must, qdrant.NewDatetimeRange(payloadUpdatedAt, r))
+	}
+	if len(f.DocumentIDs) > 0 {
+		if err := validateDocumentIDs(f.DocumentIDs); err != nil {
+			return nil, err
+		}
+		ids := make([]*qdrant.PointId, 0, len(f.DocumentIDs))
+		for _, id := range f.DocumentIDs {
+			ids = append(ids, qdrant.NewIDUUID(id))
+		}
+		must = append(must, qdrant.NewHasID(ids...))
+	}
+	for _, m := range f.Metadata {
+		conditions, err := qdrantMetadataConditions(m, schema)
+		if err != nil {
+			return nil, err
+		}
+		must = append(must, conditions...)
+	}
+
+	if len(must) == 0 {
+		return nil, nil
+	}
+	return &qdrant.Filter{Must: must}, nil
+}
+
+// qdrantMetadataConditions 将单个元数据条件转换为 Qdrant 过滤条件，match 与范围条件同时存在时都需满足
+func qdrantMetadataConditions(f model.MetadataFilter, schema []model.MetadataField) ([]*qdrant.Condition, error) {
+	m, typ, err := NormalizeMetadataFilter(f, schema)
+	if err != nil {
+		return nil, err
+	}
+	field := payloadMetadata + "." + m.Key
+
+	var conditions []*qdrant.Condition
+	if len(m.Match) > 0 {
+		switch typ {
+		case consts.MetadataTypeKeyword:
+			keywords := make([]string, 0, len(m.Match))
+			for _, v := range m.Match {
+				keywords = append(keywords, v.(string))
+			}
+			conditions = append(conditions, qdrant.NewMatchKeywords(field, keywords...))
+		case consts.MetadataTypeInteger:
+			ints := make([]int64, 0, len(m.Match))
+			for _, v := range m.Match {
+				ints = append(ints, v.(int64))
+			}
+			conditions = append(conditions, qdrant.NewMatchInts(field, ints...))
+		default:
+			// 布尔、浮点数与日期时间没有多值匹配，逐个值生成条件后取“或”
+			var should []*qdrant.Condition
+			for _, v := range m.Match {
+				switch typ {
+				case consts.MetadataTypeBool:
+					should = append(should, qdrant.NewMatchBool(field, v.(bool)))
+				case consts.MetadataTypeFloat:
+					should = append(should, qdrant.NewRange(field, &qdrant.Range{
+						Gte: qdrant.PtrOf(v.(float64)),
+						Lte: qdrant.PtrOf(v.(float64)),
+					}))
+				case consts.MetadataTypeDatetime:
+					t := gtime.NewFromStr(v.(string))
+					should = append(should, qdrant.NewDatetimeRange(field, qdrantDatetimeRange(t, t)))
+				}
+			}
+			conditions = append(conditions, qdrant.NewFilterAsCondition(&qdrant.Filter{Should: should}))
+		}
+	}
+
+	if m.Gte == nil && m.Lte == nil {
+		return conditions, nil
+	}
+	if typ == consts.MetadataTypeDatetime {
+		var from, to *gtime.Time
+		if m.Gte != nil {
+			from = gtime.NewFromStr(m.Gte.(string))
+		}
+		if m.Lte != nil {
+			to = gtime.NewFromStr(m.Lte.(string))
+		}
+		return append(conditions, qdrant.NewDatetimeRange(field, qdrantDatetimeRange(from, to))), nil
+	}
+	r := &qdrant.Range{}
+	if m.Gte != nil {
+		r.Gte = qdrant.PtrOf(gconv.Float64(m.Gte))
+	}
+	if m.Lte != nil {
+		r.Lte = qdrant.PtrOf(gconv.Float64(m.Lte))
+	}
+	return append(conditions, qdrant.NewRange(field, r)), nil
+}
+
+// qdrantDatetimeRange 生成闭区间时间范围，两端都为空时返回nil
+func qdrantDatetimeRange(from, to *gtime.Time) *qdrant.DatetimeRange {
+	if from.IsZero() && to.IsZero() {
+		return nil
+	}
+	r := &qdrant.DatetimeRange{}
+	if !from.IsZero() {
+		r.Gte = timestamppb.New(from.Time)
+	}
+	if !to.IsZero() {
+		r.Lte = timestamppb.New(to.Time)
+	}
+	return r
+}
+
+// validateDocumentIDs 校验 document_ids 中的知识ID均为 UUID，Qdrant 点ID只接受 UUID
+func validateDocumentIDs(ids []string) error {
+	for _, id := range ids {
+		if _, err := uuid.Parse(id); err != nil {
+			return gerror.NewCodef(gcode.CodeInvalidParameter, "document_ids 中的知识ID不合法: %s", id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,443 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/qdrant/go-client/qdrant"
+
+	"knowledge-system-api/internal/consts"
+	"knowledge-system-api/internal/helper"
+	"knowledge-system-api/internal/model"
+)
+
+// QdrantSearch 向量搜索，按融合策略组织 Qdrant 查询
+//
+//   - dense: 仅用正文密集向量检索
+//   - sparse_dense: 标签稀疏向量预查询 limit*倍数 个候选，再用正文密集向量重新打分
+//   - rrf/dbsf: 正文、摘要、标签、词法多路并行预查询，由 Qdrant 融合排序
+//   - weighted: 分别检索密集向量与标签稀疏向量，按 alpha/beta 加权求和
+//   - lexical: 仅用正文 BM25 稀疏向量检索
+//
+// 查询没有可用标签（稀疏向量为空）时，依赖稀疏向量的策略回退为 dense。
+func QdrantSearch(ctx context.Context, opts *model.SearchOptions) (*model.VectorSearchOutput, error) {
+	// 参数检查
+	if opts.RepoName == "" {
+		return nil, fmt.Errorf("QdrantSearch: 集合名称不能为空")
+	}
+
+	// 获取客户端，如果不存在则初始化
+	client, err := GetQdrantClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("QdrantSearch: %w", err)
+	}
+
+	// 解析知识库对应的集合别名，知识库不存在时直接返回空结果
+	repo, err := Repo().Get(ctx, opts.RepoName)
+	if err != nil {
+		return nil, fmt.Errorf("QdrantSearch: %w", err)
+	}
+	if repo == nil {
+		g.Log().Debugf(ctx, "知识库 %s 不存在，返回空结果", opts.RepoName)
+		return &model.VectorSearchOutput{}, nil
+	}
+
+	// 创建超时上下文
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cfg := LoadSearchConfig(ctx)
+	fusion := opts.Fusion
+	if fusion == "" {
+		fusion = cfg.Fusion
+	}
+
+	vectorNames, err := qdrantVectorNames(timeoutCtx, client, repo.Alias)
+	if err != nil {
+		return nil, fmt.Errorf("QdrantSearch: %w", err)
+	}
+
+	// 生成词法稀疏向量 (用于 BM25 检索)，早期创建的集合没有词法向量
+	var textIndices []uint32
+	var textValues []float32
+	if vectorNames[consts.VectorTextSparse] {
+		textIndices, textValues = LexicalQueryVector(opts.Query)
+	}
+	if fusion == consts.FusionLexical && len(textIndices) == 0 {
+		g.Log().Infof(ctx, "知识库 %s 没有词法向量或查询分词为空，lexical 回退为 dense", opts.RepoName)
+		fusion = consts.FusionDense
+	}
+
+	// 生成稀疏向量 (用于标签检索)
+	sparseIndices, sparseValues := labelsToSparse(ctx, opts.Labels)
+	if len(sparseIndices) == 0 && fusion != consts.FusionDense && fusion != consts.FusionLexical {
+		g.Log().Warningf(ctx, "查询没有可用的标签，稀疏向量为空")
+		if fusion == consts.FusionSparseDense || fusion == consts.FusionWeighted {
+			g.Log().Infof(ctx, "融合策略 %s 依赖稀疏向量，回退为 dense", fusion)
+			fusion = consts.FusionDense
+		}
+	}
+
+	// 生成密集向量 (用于正文与摘要检索)，分析查询标签时已计算的向量直接复用
+	var timings []model.StageTiming
+	vector := opts.Vector
+	if len(vector) == 0 {
+		start := time.Now()
+		vector, err = helper.Vectorize(ctx, opts.Query)
+		if err != nil {
+			return nil, fmt.Errorf("向量化内容失败: %w", err)
+		}
+		timings = append(timings, model.NewStageTiming("embed", start))
+	}
+
+	// 过滤条件同时作用于每一路预查询与最终查询
+	filter, err := qdrantSearchFilter(opts.Filter, repo.MetadataSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	// 翻页时最终查询跳过 offset 条，预查询需要覆盖到当前页末尾
+	limit, offset := opts.Limit, opts.Offset
+	multiplier := cfg.PrefetchMultiplier
+	if opts.PrefetchMultiplier != nil && *opts.PrefetchMultiplier > 0 {
+		multiplier = *opts.PrefetchMultiplier
+	}
+	prefetchLimit := (offset + limit) * multiplier
+	denseQuery := &qdrant.QueryPoints{
+		CollectionName: repo.Alias,
+		Query:          qdrant.NewQueryDense(vector),
+		Using:          qdrant.PtrOf(consts.VectorContentDense),
+		Limit:          &limit,
+		Offset:         &offset,
+		Filter:         filter,
+		WithPayload:    qdrant.NewWithPayload(true),
+	}
+
+	var points []*qdrant.ScoredPoint
+	queryStart := time.Now()
+	switch fusion {
+	case consts.FusionSparseDense:
+		query := &qdrant.QueryPoints{
+			CollectionName: repo.Alias,
+			Prefetch: []*qdrant.PrefetchQuery{{
+				Using:  qdrant.PtrOf(consts.VectorLabelsSparse),
+				Query:  qdrant.NewQuerySparse(sparseIndices, sparseValues),
+				Limit:  &prefetchLimit,
+				Filter: filter,
+			}},
+			Query:       qdrant.NewQueryDense(vector),
+			Using:       qdrant.PtrOf(consts.VectorContentDense),
+			Limit:       &limit,
+			Offset:      &offset,
+			Filter:      filter,
+			WithPayload: qdrant.NewWithPayload(true),
+		}
+		points, err = client.Query(timeoutCtx, query)
+
+	case consts.FusionLexical:
+		points, err = client.Query(timeoutCtx, &qdrant.QueryPoints{
+			CollectionName: repo.Alias,
+			Query:          qdrant.NewQuerySparse(textIndices, textValues),
+			Using:          qdrant.PtrOf(consts.VectorTextSparse),
+			Limit:          &limit,
+			Offset:         &offset,
+			Filter:         filter,
+			WithPayload:    qdrant.NewWithPayload(true),
+		})
+
+	case consts.FusionRRF, consts.FusionDBSF:
+		prefetches := []*qdrant.PrefetchQuery{{
+			Using:  qdrant.PtrOf(consts.VectorContentDense),
+			Query:  qdrant.NewQueryDense(vector),
+			Limit:  &prefetchLimit,
+			Filter: filter,
+		}}
+		if vectorNames[consts.VectorSummaryDense] {
+			prefetches = append(prefetches, &qdrant.PrefetchQuery{
+				Using:  qdrant.PtrOf(consts.VectorSummaryDense),
+				Query:  qdrant.NewQueryDense(vector),
+				Limit:  &prefetchLimit,
+				Filter: filter,
+			})
+		}
+		if len(sparseIndices) > 0 {
+			prefetches = append(prefetches, &qdrant.PrefetchQuery{
+				Using:  qdrant.PtrOf(consts.VectorLabelsSparse),
+				Query:  qdrant.NewQuerySparse(sparseIndices, sparseValues),
+				Limit:  &prefetchLimit,
+				Filter: filter,
+			})
+		}
+		if len(textIndices) > 0 {
+			prefetches = append(prefetches, &qdrant.PrefetchQuery{
+				Using:  qdrant.PtrOf(consts.VectorTextSparse),
+				Query:  qdrant.NewQuerySparse(textIndices, textValues),
+				Limit:  &prefetchLimit,
+				Filter: filter,
+			})
+		}
+		// 只剩正文一路时无需融合
+		if len(prefetches) == 1 {
+			fusion = consts.FusionDense
+			points, err = client.Query(timeoutCtx, denseQuery)
+			break
+		}
+		method := qdrant.Fusion_RRF
+		if fusion == consts.FusionDBSF {
+			method = qdrant.Fusion_DBSF
+		}
+		points, err = client.Query(timeoutCtx, &qdrant.QueryPoints{
+			CollectionName: repo.Alias,
+			Prefetch:       prefetches,
+			Query:          qdrant.NewQueryFusion(method),
+			Limit:          &limit,
+			Offset:         &offset,
+			Filter:         filter,
+			WithPayload:    qdrant.NewWithPayload(true),
+		})
+
+	case consts.FusionWeighted:
+		alpha, beta := cfg.Hybrid.Alpha, cfg.Hybrid.Beta
+		if opts.Alpha != nil {
+			alpha = *opts.Alpha
+		}
+		if opts.Beta != nil {
+			beta = *opts.Beta
+		}
+		points, err = qdrantWeightedSearch(timeoutCtx, client, repo.Alias, filter, vector, sparseIndices, sparseValues, prefetchLimit, offset, limit, alpha, beta)
+
+	default:
+		fusion = consts.FusionDense
+		points, err = client.Query(timeoutCtx, denseQuery)
+	}
+	if err != nil {
+		g.Log().Errorf(ctx, "Qdrant搜索失败: %v", err)
+		return nil, fmt.Errorf("qdrant搜索失败: %w", err)
+	}
+	timings = append(timings, model.NewStageTiming("query", queryStart))
+
+	// 估计满足过滤条件的候选总数，用于前端展示“更多结果”，统计失败不影响检索结果
+	// 精确计数需要遍历所有满足条件的点，每次检索都执行开销过大，这里使用 Qdrant 基于索引基数的估计值
+	countStart := time.Now()
+	total, err := client.Count(timeoutCtx, &qdrant.CountPoints{
+		CollectionName: repo.Alias,
+		Filter:         filter,
+		Exact:          qdrant.PtrOf(false),
+	})
+	if err != nil {
+		g.Log().Warningf(ctx, "统计知识库 %s 候选数量失败: %v", opts.RepoName, err)
+	}
+	timings = append(timings, model.NewStageTiming("count", countStart))
+
+	// explain 模式下额外查询预查询候选与结果在各路向量上的分数，失败不影响检索结果
+	if opts.Explain != nil {
+		explainStart := time.Now()
+		candidates, err := explainCandidates(timeoutCtx, client, repo.Alias, filter, vector,
+			sparseIndices, sparseValues, textIndices, textValues, prefetchLimit, points)
+		if err != nil {
+			g.Log().Warningf(ctx, "查询知识库 %s 的各路分数失败: %v", opts.RepoName, err)
+		}
+		timings = append(timings, model.NewStageTiming("explain", explainStart))
+		opts.Explain.AddRepo(model.RepoExplain{
+			RepoName:   opts.RepoName,
+			Fusion:     fusion,
+			Candidates: candidates,
+			Timings:    timings,
+		})
+	}
+
+	// 处理结果
+	var searchResults []model.VectorSearchResult
+	for _, point := range points {
+		// 获取payload
+		payload := make(map[string]interface{})
+
+		// 复制所有payload字段
+		if point.Payload != nil {
+			for k, v := range point.Payload {
+				payload[k] = v
+			}
+		}
+
+		searchResults = append(searchResults, model.VectorSearchResult{
+			ID:      point.Id.GetUuid(),
+			Score:   point.Score,
+			Payload: payload,
+		})
+
+		g.Log().Debugf(ctx, "找到结果: ID=%s, Score=%.4f, Payload=%v", point.Id.String(), point.Score, payload)
+	}
+
+	g.Log().Debugf(ctx, "Qdrant搜索完成，融合策略: %s，找到 %d 条结果", fusion, len(searchResults))
+	return &model.VectorSearchOutput{Points: searchResults, Fusion: fusion, Total: total}, nil
+}
+
+// qdrantWeightedSearch 分别检索正文密集向量与标签稀疏向量，按 alpha*dense + beta*sparse 加权求和。
+// 稀疏向量分数是标签分数的点积，量纲与余弦相似度不同，先按候选中的最大值归一化到 [0,1]。
+func qdrantWeightedSearch(ctx context.Context, client *qdrant.Client, collection string, filter *qdrant.Filter, vector []float32,
+	sparseIndices []uint32, sparseValues []float32, candidateLimit, offset, limit uint64, alpha, beta float32) ([]*qdrant.ScoredPoint, error) {
+	batch, err := client.QueryBatch(ctx, &qdrant.QueryBatchPoints{
+		CollectionName: collection,
+		QueryPoints: []*qdrant.QueryPoints{
+			{
+				CollectionName: collection,
+				Query:          qdrant.NewQueryDense(vector),
+				Using:          qdrant.PtrOf(consts.VectorContentDense),
+				Limit:          &candidateLimit,
+				Filter:         filter,
+				WithPayload:    qdrant.NewWithPayload(true),
+			},
+			{
+				CollectionName: collection,
+				Query:          qdrant.NewQuerySparse(sparseIndices, sparseValues),
+				Using:          qdrant.PtrOf(consts.VectorLabelsSparse),
+				Limit:          &candidateLimit,
+				Filter:         filter,
+				WithPayload:    qdrant.NewWithPayload(true),
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(batch) != 2 {
+		return nil, fmt.Errorf("加权检索返回结果数量异常: %d", len(batch))
+	}
+
+	var maxSparse float32
+	for _, p := range batch[1].GetResult() {
+		if p.Score > maxSparse {
+			maxSparse = p.Score
+		}
+	}
+
+	// 合并两路候选，缺失的一路分数按0计算
+	merged := make(map[string]*qdrant.ScoredPoint)
+	scores := make(map[string]float32)
+	for _, p := range batch[0].GetResult() {
+		id := p.Id.GetUuid()
+		merged[id] = p
+		scores[id] += alpha * p.Score
+	}
+	if maxSparse > 0 {
+		for _, p := range batch[1].GetResult() {
+			id := p.Id.GetUuid()
+			if _, ok := merged[id]; !ok {
+				merged[id] = p
+			}
+			scores[id] += beta * p.Score / maxSparse
+		}
+	}
+
+	points := make([]*qdrant.ScoredPoint, 0, len(merged))
+	for id, p := range merged {
+		p.Score = scores[id]
+		points = append(points, p)
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].Score > points[j].Score })
+	if uint64(len(points)) <= offset {
+		return nil, nil
+	}
+	points = points[offset:]
+	if uint64(len(points)) > limit {
+		points = points[:limit]
+	}
+	return points, nil
+}
+
+// explainCandidates 收集标签稀疏向量预查询的候选与检索结果，限定在这些点内分别查询正文密集向量、标签稀疏向量与词法稀疏向量的分数
+func explainCandidates(ctx context.Context, client *qdrant.Client, collection string, filter *qdrant.Filter, vector []float32,
+	sparseIndices []uint32, sparseValues []float32, textIndices []uint32, textValues []float32,
+	prefetchLimit uint64, points []*qdrant.ScoredPoint) ([]model.CandidateExplain, error) {
+	var candidates []model.CandidateExplain
+	index := make(map[string]int)
+	add := func(id string) *model.CandidateExplain {
+		i, ok := index[id]
+		if !ok {
+			i = len(candidates)
+			index[id] = i
+			candidates = append(candidates, model.CandidateExplain{ID: id})
+		}
+		return &candidates[i]
+	}
+
+	if len(sparseIndices) > 0 {
+		prefetched, err := client.Query(ctx, &qdrant.QueryPoints{
+			CollectionName: collection,
+			Query:          qdrant.NewQuerySparse(sparseIndices, sparseValues),
+			Using:          qdrant.PtrOf(consts.VectorLabelsSparse),
+			Limit:          &prefetchLimit,
+			Filter:         filter,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range prefetched {
+			add(p.Id.GetUuid()).Prefetched = true
+		}
+	}
+	for _, p := range points {
+		c := add(p.Id.GetUuid())
+		c.Returned = true
+		c.Score = p.Score
+	}
+	if len(candidates) == 0 {
+		return candidates, nil
+	}
+
+	ids := make([]*qdrant.PointId, len(candidates))
+	for i, c := range candidates {
+		ids[i] = qdrant.NewID(c.ID)
+	}
+	idFilter := &qdrant.Filter{Must: []*qdrant.Condition{qdrant.NewHasID(ids...)}}
+	limit := uint64(len(ids))
+	queries := []*qdrant.QueryPoints{{
+		CollectionName: collection,
+		Query:          qdrant.NewQueryDense(vector),
+		Using:          qdrant.PtrOf(consts.VectorContentDense),
+		Limit:          &limit,
+		Filter:         idFilter,
+	}}
+	setters := []func(c *model.CandidateExplain, score float32){
+		func(c *model.CandidateExplain, score float32) { c.DenseScore = &score },
+	}
+	if len(sparseIndices) > 0 {
+		queries = append(queries, &qdrant.QueryPoints{
+			CollectionName: collection,
+			Query:          qdrant.NewQuerySparse(sparseIndices, sparseValues),
+			Using:          qdrant.PtrOf(consts.VectorLabelsSparse),
+			Limit:          &limit,
+			Filter:         idFilter,
+		})
+		setters = append(setters, func(c *model.CandidateExplain, score float32) { c.SparseScore = &score })
+	}
+	if len(textIndices) > 0 {
+		queries = append(queries, &qdrant.QueryPoints{
+			CollectionName: collection,
+			Query:          qdrant.NewQuerySparse(textIndices, textValues),
+			Using:          qdrant.PtrOf(consts.VectorTextSparse),
+			Limit:          &limit,
+			Filter:         idFilter,
+		})
+		setters = append(setters, func(c *model.CandidateExplain, score float32) { c.LexicalScore = &score })
+	}
+
+	batch, err := client.QueryBatch(ctx, &qdrant.QueryBatchPoints{CollectionName: collection, QueryPoints: queries})
+	if err != nil {
+		return candidates, err
+	}
+	for i, result := range batch {
+		if i >= len(setters) {
+			break
+		}
+		for _, p := range result.GetResult() {
+			if j, ok := index[p.Id.GetUuid()]; ok {
+				setters[i](&candidates[j], p.Score)
+			}
+		}
+	}
+	return candidates, nil
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"knowledge-system-api/internal/dao"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/model/do"
+	"knowledge-system-api/internal/model/entity"
+)
+
+// QualityConfig 知识质量分数配置
+// 质量分数由用户反馈累积，与大模型标签分开保存，按半衰期衰减，检索排序时作为提升系数
+type QualityConfig struct {
+	Like         float64 `yaml:"like" json:"like"`                     // 每个点赞的调整量
+	Dislike      float64 `yaml:"dislike" json:"dislike"`               // 每个点踩的调整量
+	Min          float64 `yaml:"min" json:"min"`                       // 质量分数下限
+	Max          float64 `yaml:"max" json:"max"`                       // 质量分数上限
+	HalfLifeDays float64 `yaml:"half_life_days" json:"half_life_days"` // 半衰期（天），0 表示不衰减
+	BoostWeight  float64 `yaml:"boost_weight" json:"boost_weight"`     // 排序分数提升权重：分数 += |分数| * 权重 * 质量分数，0 表示不提升
+}
+
+// LoadQualityConfig 读取feedback.quality配置，未配置的项使用默认值
+func LoadQualityConfig(ctx context.Context) *QualityConfig {
+	cfg := &QualityConfig{}
+	if err := g.Cfg().MustGet(ctx, "feedback.quality").Scan(cfg); err != nil {
+		g.Log().Warningf(ctx, "加载feedback.quality配置失败，使用默认配置: %v", err)
+	}
+	if cfg.Max <= cfg.Min {
+		cfg.Min, cfg.Max = -1, 1
+	}
+	// 调整量、半衰期与提升权重允许配置为0，只在未配置时使用默认值
+	cfg.Like = g.Cfg().MustGet(ctx, "feedback.quality.like", 0.05).Float64()
+	cfg.Dislike = g.Cfg().MustGet(ctx, "feedback.quality.dislike", -0.08).Float64()
+	cfg.HalfLifeDays = g.Cfg().MustGet(ctx, "feedback.quality.half_life_days", 30).Float64()
+	cfg.BoostWeight = g.Cfg().MustGet(ctx, "feedback.quality.boost_weight", 0.1).Float64()
+	return cfg
+}
+
+// DecayQuality 计算质量分数从 updatedAt 衰减到 now 的值
+func DecayQuality(score float64, updatedAt, now time.Time, halfLifeDays float64) float64 {
+	if halfLifeDays <= 0 || !now.After(updatedAt) {
+		return score
+	}
+	days := now.Sub(updatedAt).Hours() / 24
+	return score * math.Pow(0.5, days/halfLifeDays)
+}
+
+// LoadQualityScores 批量读取知识条目衰减到当前时刻的质量分数，没有调整记录的条目不在结果中
+func LoadQualityScores(ctx context.Context, ids []string) (map[string]float64, error) {
+	scores := make(map[string]float64)
+	if len(ids) == 0 {
+		return scores, nil
+	}
+	var rows []entity.KnowledgeQuality
+	err := dao.KnowledgeQuality.Ctx(ctx).
+		WhereIn(dao.KnowledgeQuality.Columns().KnowledgeId, ids).
+		Scan(&rows)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := LoadQualityConfig(ctx)
+	now := time.Now()
+	for _, row := range rows {
+		if row.UpdatedAt == nil {
+			continue
+		}
+		scores[row.KnowledgeId] = DecayQuality(row.Score, row.UpdatedAt.Time, now, cfg.HalfLifeDays)
+	}
+	return scores, nil
+}
+
+// ApplyQualityBoost 按质量分数提升排序分数（开启重排序时为重排序分数）并重新排序
+// 提升量与分数的绝对值成正比，负分数同样朝质量分数的方向移动；读取失败时保持原顺序
+func ApplyQualityBoost(ctx context.Context, results []model.SearchResult) []model.SearchResult {
+	cfg := LoadQualityConfig(ctx)
+	if cfg.BoostWeight == 0 || len(results) == 0 {
+		return results
+	}
+
+	ids := make([]string, len(results))
+	for i, r := range results {
+		ids[i] = r.ID
+	}
+	scores, err := LoadQualityScores(ctx, ids)
+	if err != nil {
+		g.Log().Warningf(ctx, "读取质量分数失败: %v, 将不按质量分数调整排序", err)
+		return results
+	}
+	if len(scores) == 0 {
+		return results
+	}
+
+	for i := range results {
+		quality, ok := scores[results[i].ID]
+		if !ok {
+			continue
+		}
+		q := float32(quality)
+		boost := float32(cfg.BoostWeight) * q
+		results[i].Quality = &q
+		if results[i].RerankScore != nil {
+			score := *results[i].RerankScore
+			score += float32(math.Abs(float64(score))) * boost
+			results[i].RerankScore = &score
+		} else {
+			results[i].Score += float32(math.Abs(float64(results[i].Score))) * boost
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return rankingScore(results[i]) > rankingScore(results[j])
+	})
+	return results
+}
+
+// rankingScore 排序使用的分数，开启重排序时为重排序分数
+func rankingScore(r model.SearchResult) float32 {
+	if r.RerankScore != nil {
+		return *r.RerankScore
+	}
+	return r.Score
+}
+
+// AdjustQuality 调整知识条目的质量分数并记录审计日志，返回调整日志
+// 先将已有分数衰减到当前时刻，再加上调整量并限制在上下限内；
+// 质量分数影响检索排序，调用方需在事务提交后对条目所属知识库调用 InvalidateSearchCache
+func AdjustQuality(ctx context.Context, knowledgeID, source string, likes, dislikes uint, delta float64) (*model.QualityLog, error) {
+	cfg := LoadQualityConfig(ctx)
+	now := gtime.Now()
+	log := &model.QualityLog{
+		KnowledgeID: knowledgeID,
+		Source:      source,
+		Likes:       likes,
+		Dislikes:    dislikes,
+		Delta:       delta,
+		CreatedAt:   now,
+	}
+
+	err := dao.KnowledgeQuality.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		var current entity.KnowledgeQuality
+		err := dao.KnowledgeQuality.Ctx(ctx).
+			Where(do.KnowledgeQuality{KnowledgeId: knowledgeID}).
+			LockUpdate().
+			Scan(&current)
+		if err != nil {
+			return err
+		}
+		if current.UpdatedAt != nil {
+			log.ScoreBefore = DecayQuality(current.Score, current.UpdatedAt.Time, now.Time, cfg.HalfLifeDays)
+		}
+		log.ScoreAfter = math.Max(cfg.Min, math.Min(cfg.Max, log.ScoreBefore+delta))
+
+		if _, err := dao.KnowledgeQuality.Ctx(ctx).Data(do.KnowledgeQuality{
+			KnowledgeId: knowledgeID,
+			Score:       log.ScoreAfter,
+			UpdatedAt:   now,
+		}).Save(); err != nil {
+			return err
+		}
+		id, err := dao.KnowledgeQualityLog.Ctx(ctx).Data(do.KnowledgeQualityLog{
+			KnowledgeId: knowledgeID,
+			Source:      source,
+			Likes:       likes,
+			Dislikes:    dislikes,
+			ScoreBefore: log.ScoreBefore,
+			Delta:       delta,
+			ScoreAfter:  log.ScoreAfter,
+			CreatedAt:   now,
+		}).InsertAndGetId()
+		log.ID = uint64(id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return log, nil
+}
+
+// ListQualityLogs 分页查询质量分数调整日志，knowledgeID 为空时查询全部，按时间倒序
+func ListQualityLogs(ctx context.Context, knowledgeID string, page, pageSize int) ([]model.QualityLog, int, error) {
+	m := dao.KnowledgeQualityLog.Ctx(ctx)
+	if knowledgeID != "" {
+		m = m.Where(do.KnowledgeQualityLog{KnowledgeId: knowledgeID})
+	}
+	total, err := m.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var rows []entity.KnowledgeQualityLog
+	err = m.Page(page, pageSize).OrderDesc(dao.KnowledgeQualityLog.Columns().Id).Scan(&rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	logs := make([]model.QualityLog, len(rows))
+	for i, row := range rows {
+		logs[i] = model.QualityLog{
+			ID:          row.Id,
+			KnowledgeID: row.KnowledgeId,
+			Source:      row.Source,
+			Likes:       row.Likes,
+			Dislikes:    row.Dislikes,
+			ScoreBefore: row.ScoreBefore,
+			Delta:       row.Delta,
+			ScoreAfter:  row.ScoreAfter,
+			CreatedAt:   row.CreatedAt,
+		}
+	}
+	return logs, total, nil
+}
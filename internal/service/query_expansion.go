@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// QueryExpansionConfig 多查询改写与 HyDE 检索策略配置
+type QueryExpansionConfig struct {
+	Queries              int    `yaml:"queries" json:"queries"`                                 // multi_query 策略由大模型生成的改写查询数量（不含原始查询）
+	RRFK                 int    `yaml:"rrf_k" json:"rrf_k"`                                     // 融合各查询结果时倒数排名融合的 k 值
+	BudgetMs             int    `yaml:"budget_ms" json:"budget_ms"`                             // 调用大模型的最长等待时间（毫秒），超时回退 standard 策略
+	MultiQueryPromptPath string `yaml:"multi_query_prompt_path" json:"multi_query_prompt_path"` // 改写查询的提示词模板，使用 {query} 与 {count} 占位符
+	HyDEPromptPath       string `yaml:"hyde_prompt_path" json:"hyde_prompt_path"`               // 假设性答案的提示词模板，使用 {query} 占位符
+}
+
+// LoadQueryExpansionConfig 读取search.expansion配置，未配置的项使用默认值
+func LoadQueryExpansionConfig(ctx context.Context) *QueryExpansionConfig {
+	cfg := &QueryExpansionConfig{}
+	if err := g.Cfg().MustGet(ctx, "search.expansion").Scan(cfg); err != nil {
+		g.Log().Warningf(ctx, "加载search.expansion配置失败，使用默认配置: %v", err)
+	}
+	if cfg.Queries <= 0 {
+		cfg.Queries = 3
+	}
+	if cfg.RRFK <= 0 {
+		cfg.RRFK = 60
+	}
+	if cfg.BudgetMs <= 0 {
+		cfg.BudgetMs = 5000
+	}
+	if cfg.MultiQueryPromptPath == "" {
+		cfg.MultiQueryPromptPath = "resource/prompts/multi_query"
+	}
+	if cfg.HyDEPromptPath == "" {
+		cfg.HyDEPromptPath = "resource/prompts/hyde"
+	}
+	return cfg
+}
+
+// ExpandQueries 调用大模型生成查询的多个改写，返回去重后不超过 cfg.Queries 条、且不含原始查询的改写结果
+func ExpandQueries(ctx context.Context, cfg *QueryExpansionConfig, query string) ([]string, error) {
+	promptTmpl, err := LoadPromptTemplate(cfg.MultiQueryPromptPath)
+	if err != nil {
+		return nil, fmt.Errorf("加载改写查询Prompt模板失败: %w", err)
+	}
+	prompt := strings.NewReplacer("{query}", query, "{count}", fmt.Sprint(cfg.Queries)).Replace(promptTmpl)
+
+	budgetCtx, cancel := context.WithTimeout(ctx, time.Duration(cfg.BudgetMs)*time.Millisecond)
+	defer cancel()
+	resp, err := GetLLMClient().Generate(budgetCtx, prompt)
+	if err != nil {
+		return nil, err
+	}
+	jsonStr, err := ExtractJSONFromLLMResponse(resp)
+	if err != nil {
+		return nil, fmt.Errorf("提取大模型JSON失败: %w", err)
+	}
+	var parsed struct {
+		Queries []string `json:"queries"`
+	}
+	if err := json.Unmarshal([]byte(jsonStr), &parsed); err != nil {
+		return nil, fmt.Errorf("解析改写查询失败: %w", err)
+	}
+
+	seen := map[string]bool{strings.TrimSpace(query): true}
+	var queries []string
+	for _, q := range parsed.Queries {
+		q = strings.TrimSpace(q)
+		if q == "" || seen[q] {
+			continue
+		}
+		seen[q] = true
+		queries = append(queries, q)
+		if len(queries) == cfg.Queries {
+			break
+		}
+	}
+	if len(queries) == 0 {
+		return nil, fmt.Errorf("改写查询结果为空")
+	}
+	return queries, nil
+}
+
+// HypotheticalAnswer 调用大模型为查询生成一段假设性答案，用于代替查询计算检索向量
+// 答案内容不要求准确，只需在措辞与结构上接近知识库中的正式文本
+func HypotheticalAnswer(ctx context.Context, cfg *QueryExpansionConfig, query string) (string, error) {
+	promptTmpl, err := LoadPromptTemplate(cfg.HyDEPromptPath)
+	if err != nil {
+		return "", fmt.Errorf("加载假设性答案Prompt模板失败: %w", err)
+	}
+	prompt := strings.ReplaceAll(promptTmpl, "{query}", query)
+
+	budgetCtx, cancel := context.WithTimeout(ctx, time.Duration(cfg.BudgetMs)*time.Millisecond)
+	defer cancel()
+	answer, err := GetLLMClient().Generate(budgetCtx, prompt)
+	if err != nil {
+		return "", err
+	}
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return "", fmt.Errorf("假设性答案为空")
+	}
+	return answer, nil
+}
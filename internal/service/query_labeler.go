@@ -0,0 +1,343 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/qdrant/go-client/qdrant"
+
+	"knowledge-system-api/internal/consts"
+	"knowledge-system-api/internal/helper"
+	"knowledge-system-api/internal/model"
+)
+
+// QueryLabelConfig 查询标签分析配置
+type QueryLabelConfig struct {
+	Classifier      string  `yaml:"classifier" json:"classifier"`             // 分析方式：auto/centroid/llm
+	MinConfidence   float32 `yaml:"min_confidence" json:"min_confidence"`     // 低于该余弦相似度的标签不保留，最高相似度也低于该值时回退大模型
+	LLMBudgetMs     int     `yaml:"llm_budget_ms" json:"llm_budget_ms"`       // 调用大模型的最长等待时间（毫秒），超时后使用质心分类结果
+	MinSamples      int     `yaml:"min_samples" json:"min_samples"`           // 标签至少有多少条样本才建立质心
+	MaxSamples      int     `yaml:"max_samples" json:"max_samples"`           // 训练时每个知识库最多读取的样本数
+	RefreshInterval int     `yaml:"refresh_interval" json:"refresh_interval"` // 质心的重新训练间隔（秒）
+}
+
+// LoadQueryLabelConfig 读取search.query_labels配置，未配置的项使用默认值
+func LoadQueryLabelConfig(ctx context.Context) *QueryLabelConfig {
+	cfg := &QueryLabelConfig{}
+	if err := g.Cfg().MustGet(ctx, "search.query_labels").Scan(cfg); err != nil {
+		g.Log().Warningf(ctx, "加载query_labels配置失败，使用默认配置: %v", err)
+	}
+	switch cfg.Classifier {
+	case consts.QueryLabelerAuto, consts.QueryLabelerCentroid, consts.QueryLabelerLLM:
+	default:
+		cfg.Classifier = consts.QueryLabelerAuto
+	}
+	if cfg.MinConfidence == 0 {
+		cfg.MinConfidence = 0.6
+	}
+	if cfg.LLMBudgetMs <= 0 {
+		cfg.LLMBudgetMs = 3000
+	}
+	if cfg.MinSamples <= 0 {
+		cfg.MinSamples = 3
+	}
+	if cfg.MaxSamples <= 0 {
+		cfg.MaxSamples = 5000
+	}
+	if cfg.RefreshInterval <= 0 {
+		cfg.RefreshInterval = 600
+	}
+	return cfg
+}
+
+// labelCentroids 最近质心分类模型：每个标签的质心为带有该标签的知识条目正文向量按标签分数加权的平均值
+type labelCentroids struct {
+	labels    []string
+	centroids [][]float32 // 已归一化为单位向量，与 labels 一一对应
+	samples   int         // 训练使用的样本数
+	builtAt   time.Time
+}
+
+var (
+	centroidModel    atomic.Pointer[labelCentroids]
+	centroidBuilding atomic.Bool
+	centroidMu       sync.Mutex // 保证同一时间只有一个训练过程
+
+	// centroidCtx 后台训练质心使用的上下文，服务关闭时取消
+	centroidCtx, stopCentroids = context.WithCancel(context.Background())
+)
+
+// StopLabelCentroids 取消后台进行中的质心训练，服务关闭后不再发起新的训练
+func StopLabelCentroids() {
+	stopCentroids()
+}
+
+// AnalyzeQueryLabels 分析查询的标签，用于标签稀疏向量检索
+// auto 模式下先用最近质心分类，置信度不足时在 llm_budget_ms 内调用大模型，超时或失败时仍使用质心分类结果；
+// 质心模型尚未训练完成时直接回退大模型。返回结果中带有计算过的查询向量，检索时可复用。
+// threshold 为空时质心分类使用配置 llm.label_threshold；不为空时质心分类与大模型的结果都按该阈值过滤（用于评测不同阈值）
+func AnalyzeQueryLabels(ctx context.Context, query string, threshold *float32) *model.QueryLabels {
+	result := analyzeQueryLabels(ctx, query, threshold)
+	if threshold != nil && result.Source == consts.LabelSourceLLM {
+		result.Labels = FilterLabels(result.Labels, *threshold)
+	}
+	return result
+}
+
+// analyzeQueryLabels 按配置的分析方式分析查询标签
+func analyzeQueryLabels(ctx context.Context, query string, threshold *float32) *model.QueryLabels {
+	cfg := LoadQueryLabelConfig(ctx)
+	if cfg.Classifier == consts.QueryLabelerLLM {
+		return llmQueryLabels(ctx, query, cfg, nil)
+	}
+
+	vector, err := Vectorize(ctx, query)
+	if err != nil {
+		g.Log().Warningf(ctx, "查询向量化失败: %v, 将使用大模型分析标签", err)
+		return llmQueryLabels(ctx, query, cfg, nil)
+	}
+
+	var centroid *model.QueryLabels
+	if m := currentCentroids(ctx, cfg); m != nil {
+		labelThreshold := helper.GetLabelThreshold(ctx)
+		if threshold != nil {
+			labelThreshold = *threshold
+		}
+		labels, confidence := m.predict(vector, cfg.MinConfidence, labelThreshold)
+		centroid = &model.QueryLabels{
+			Labels:     labels,
+			Vector:     vector,
+			Source:     consts.LabelSourceCentroid,
+			Confidence: confidence,
+		}
+		if confidence >= cfg.MinConfidence || cfg.Classifier == consts.QueryLabelerCentroid {
+			g.Log().Debugf(ctx, "最近质心分类得到 %d 个查询标签，置信度 %.3f", len(labels), confidence)
+			return centroid
+		}
+		g.Log().Debugf(ctx, "最近质心分类置信度 %.3f 低于 %.3f，回退大模型", confidence, cfg.MinConfidence)
+	} else if cfg.Classifier == consts.QueryLabelerCentroid {
+		return &model.QueryLabels{Vector: vector, Source: consts.LabelSourceNone}
+	}
+
+	result := llmQueryLabels(ctx, query, cfg, centroid)
+	result.Vector = vector
+	return result
+}
+
+// llmQueryLabels 在延迟预算内调用大模型分析查询标签，失败时返回 fallback（可为 nil）
+func llmQueryLabels(ctx context.Context, query string, cfg *QueryLabelConfig, fallback *model.QueryLabels) *model.QueryLabels {
+	if fallback == nil {
+		fallback = &model.QueryLabels{Source: consts.LabelSourceNone}
+	}
+	if helper.LLMClassify == nil {
+		return fallback
+	}
+
+	budgetCtx, cancel := context.WithTimeout(ctx, time.Duration(cfg.LLMBudgetMs)*time.Millisecond)
+	defer cancel()
+	labels, _, err := helper.LLMClassify(budgetCtx, query)
+	if err != nil {
+		g.Log().Warningf(ctx, "LLM分析失败: %v, 使用%s标签", err, fallback.Source)
+		return fallback
+	}
+	return &model.QueryLabels{Labels: labels, Source: consts.LabelSourceLLM}
+}
+
+// currentCentroids 返回当前的质心模型，模型不存在或已过期时在后台重新训练
+func currentCentroids(ctx context.Context, cfg *QueryLabelConfig) *labelCentroids {
+	m := centroidModel.Load()
+	if m == nil || time.Since(m.builtAt) > time.Duration(cfg.RefreshInterval)*time.Second {
+		if centroidCtx.Err() == nil && centroidBuilding.CompareAndSwap(false, true) {
+			go func() {
+				defer centroidBuilding.Store(false)
+				if _, err := RebuildLabelCentroids(centroidCtx); err != nil {
+					g.Log().Warningf(centroidCtx, "训练查询标签质心失败: %v", err)
+				}
+			}()
+		}
+	}
+	if m == nil || len(m.labels) == 0 {
+		return nil
+	}
+	return m
+}
+
+// RebuildLabelCentroids 从各知识库已打标签的知识条目重新训练查询标签质心，返回训练使用的样本数
+func RebuildLabelCentroids(ctx context.Context) (int, error) {
+	centroidMu.Lock()
+	defer centroidMu.Unlock()
+
+	cfg := LoadQueryLabelConfig(ctx)
+	client, err := GetQdrantClient(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("获取Qdrant客户端失败: %w", err)
+	}
+	repos, err := Repo().List(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sums := make(map[string][]float64)
+	counts := make(map[string]int)
+	samples := 0
+	for _, name := range repos {
+		repo, err := Repo().Get(ctx, name)
+		if err != nil {
+			return 0, err
+		}
+		if repo == nil {
+			continue
+		}
+		n, err := accumulateLabelVectors(ctx, client, repo.Alias, cfg.MaxSamples, sums, counts)
+		if err != nil {
+			return 0, err
+		}
+		samples += n
+	}
+
+	m := &labelCentroids{samples: samples, builtAt: time.Now()}
+	for label, sum := range sums {
+		if counts[label] < cfg.MinSamples {
+			continue
+		}
+		if centroid := unitVector(sum); centroid != nil {
+			m.labels = append(m.labels, label)
+			m.centroids = append(m.centroids, centroid)
+		}
+	}
+	centroidModel.Store(m)
+	g.Log().Infof(ctx, "查询标签质心训练完成: 样本 %d 条，标签 %d 个", samples, len(m.labels))
+	return samples, nil
+}
+
+// accumulateLabelVectors 分页读取集合中的正文向量与标签，按标签分数累加向量，返回读取的样本数
+func accumulateLabelVectors(ctx context.Context, client *qdrant.Client, collection string, maxSamples int,
+	sums map[string][]float64, counts map[string]int) (int, error) {
+	samples := 0
+	var offset *qdrant.PointId
+	for samples < maxSamples {
+		batch := uint32(256)
+		if remaining := maxSamples - samples; remaining < int(batch) {
+			batch = uint32(remaining)
+		}
+		resp, err := client.GetPointsClient().Scroll(ctx, &qdrant.ScrollPoints{
+			CollectionName: collection,
+			Offset:         offset,
+			Limit:          &batch,
+			WithPayload:    qdrant.NewWithPayloadInclude(payloadLabels),
+			WithVectors:    qdrant.NewWithVectorsInclude(consts.VectorContentDense),
+		})
+		if err != nil {
+			return samples, fmt.Errorf("读取集合 %s 失败: %w", collection, err)
+		}
+
+		for _, p := range resp.GetResult() {
+			output := p.GetVectors().GetVectors().GetVectors()[consts.VectorContentDense]
+			vector := output.GetDense().GetData()
+			if len(vector) == 0 {
+				vector = output.GetData()
+			}
+			if len(vector) == 0 {
+				continue
+			}
+			samples++
+			for _, v := range p.GetPayload()[payloadLabels].GetListValue().GetValues() {
+				fields := v.GetStructValue().GetFields()
+				label := fields[payloadLabelID].GetStringValue()
+				score := fields[payloadLabelScore].GetDoubleValue()
+				if score == 0 {
+					score = float64(fields[payloadLabelScore].GetIntegerValue())
+				}
+				if label == "" || score <= 0 {
+					continue
+				}
+				sum := sums[label]
+				if sum == nil {
+					sum = make([]float64, len(vector))
+					sums[label] = sum
+				}
+				if len(sum) != len(vector) {
+					continue
+				}
+				for i, x := range vector {
+					sum[i] += float64(x) * score
+				}
+				counts[label]++
+			}
+		}
+
+		offset = resp.GetNextPageOffset()
+		if offset == nil {
+			break
+		}
+	}
+	return samples, nil
+}
+
+// predict 计算查询向量与各标签质心的余弦相似度，丢弃相似度低于 minSim 的标签，
+// 其余按余弦相似度将 [minSim, 1] 线性映射为 1-5 分，保留不低于阈值的标签；置信度为最高的相似度
+func (m *labelCentroids) predict(vector []float32, minSim, threshold float32) ([]model.LabelScore, float32) {
+	query := unitVector(toFloat64(vector))
+	if query == nil {
+		return nil, 0
+	}
+
+	var labels []model.LabelScore
+	maxSim := float32(-1)
+	for i, c := range m.centroids {
+		if len(c) != len(query) {
+			continue
+		}
+		var sim float32
+		for k := range c {
+			sim += c[k] * query[k]
+		}
+		maxSim = max(maxSim, sim)
+		if sim < minSim {
+			continue
+		}
+		score := float32(5)
+		if minSim < 1 {
+			score = 1 + 4*(min(sim, 1)-minSim)/(1-minSim)
+		}
+		if score >= threshold {
+			labels = append(labels, model.LabelScore{Name: m.labels[i], Score: score})
+		}
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].Score > labels[j].Score
+	})
+	return labels, maxSim
+}
+
+// unitVector 归一化为单位向量，零向量返回 nil
+func unitVector(v []float64) []float32 {
+	var norm float64
+	for _, x := range v {
+		norm += x * x
+	}
+	if norm == 0 {
+		return nil
+	}
+	norm = math.Sqrt(norm)
+	out := make([]float32, len(v))
+	for i, x := range v {
+		out[i] = float32(x / norm)
+	}
+	return out
+}
+
+// toFloat64 转换为 float64 切片
+func toFloat64(v []float32) []float64 {
+	out := make([]float64, len(v))
+	for i, x := range v {
+		out[i] = float64(x)
+	}
+	return out
+}
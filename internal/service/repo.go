@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/util/gvalid"
+
+	"knowledge-system-api/internal/model"
+)
+
+// RepoNameRule 知识库名称校验规则名，可直接用于请求结构体的 v 标签
+const RepoNameRule = "repo-name"
+
+// repoNamePattern 知识库名称：中文、字母、数字开头，可包含 _ - .，最长64个字符
+var repoNamePattern = regexp.MustCompile(`^[\p{Han}A-Za-z0-9][\p{Han}A-Za-z0-9_.\-]{0,63}$`)
+
+func init() {
+	// 注册知识库名称校验规则，空值交给 required 规则处理
+	gvalid.RegisterRule(RepoNameRule, func(ctx context.Context, in gvalid.RuleFuncInput) error {
+		name := in.Value.String()
+		if name == "" {
+			return nil
+		}
+		if err := ValidateRepoName(name); err != nil {
+			if in.Message != "" {
+				return gerror.New(in.Message)
+			}
+			return err
+		}
+		return nil
+	})
+}
+
+// ValidateRepoName 校验知识库名称是否合法
+func ValidateRepoName(name string) error {
+	if !repoNamePattern.MatchString(name) {
+		return gerror.Newf("知识库名称不合法: %s，只允许中文、字母、数字、_、-、.，且长度不超过64", name)
+	}
+	return nil
+}
+
+// IRepo 知识库管理服务接口
+// 知识库名称与 Qdrant 集合解耦：每个知识库对应一个内部ID，通过 Qdrant 别名访问物理集合
+type IRepo interface {
+	// Ensure 获取知识库信息，不存在时创建 Qdrant 集合与别名
+	Ensure(ctx context.Context, name string) (*model.RepoInfo, error)
+
+	// Get 获取知识库信息，不存在时返回 nil
+	Get(ctx context.Context, name string) (*model.RepoInfo, error)
+
+	// List 获取所有已创建集合的知识库名称
+	List(ctx context.Context) ([]string, error)
+
+	// Describe 获取知识库详情，包括向量数量与条目数量
+	Describe(ctx context.Context, name string) (*model.RepoInfo, error)
+
+	// Rename 重命名知识库，Qdrant 集合与别名保持不变
+	Rename(ctx context.Context, name, newName string) error
+
+	// LexicalStats 获取知识库词法索引统计（文档数、词条总数），用于计算 BM25 平均文档长度
+	LexicalStats(ctx context.Context, name string) (docCount, tokenCount uint64, err error)
+
+	// AddLexicalStats 按增量调整知识库词法索引统计，增量可以为负
+	AddLexicalStats(ctx context.Context, name string, docCount, tokenCount int64) error
+
+	// AddMetadataFields 追加元数据字段声明并创建 Qdrant payload 索引，已声明字段的类型不能修改
+	AddMetadataFields(ctx context.Context, name string, fields []model.MetadataField) (*model.RepoInfo, error)
+}
+
+var (
+	localRepo IRepo
+)
+
+// Repo 获取知识库管理服务
+func Repo() IRepo {
+	if localRepo == nil {
+		panic("implement not found for interface IRepo, forgot register?")
+	}
+	return localRepo
+}
+
+// RegisterRepo 注册知识库管理服务
+func RegisterRepo(i IRepo) {
+	localRepo = i
+}
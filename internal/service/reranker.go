@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+
+	"knowledge-system-api/internal/model"
+)
+
+var (
+	rerankerInstance Reranker
+	rerankerOnce     sync.Once
+)
+
+// Reranker 重排序统一接口
+// 输入查询与候选文档，返回与文档一一对应的相关性分数，分数越大越相关
+type Reranker interface {
+	Rerank(ctx context.Context, query string, documents []string) ([]float32, error)
+}
+
+// RerankConfig 重排序配置
+type RerankConfig struct {
+	Enabled   bool   `yaml:"enabled" json:"enabled"`       // 默认是否开启重排序，可被检索请求的 rerank 字段覆盖
+	Backend   string `yaml:"backend" json:"backend"`       // 重排序后端：http（交叉编码器服务）/ llm（大模型listwise排序）
+	TopN      uint64 `yaml:"top_n" json:"top_n"`           // 参与重排序的候选数量，不足 top_k 时按 top_k 计算
+	TimeoutMs int    `yaml:"timeout_ms" json:"timeout_ms"` // 单次重排序的超时时间（毫秒），超时后保留检索顺序，0 表示不限制
+	HTTP      struct {
+		BaseURL string `yaml:"base_url" json:"base_url"`
+		API     string `yaml:"api" json:"api"`     // 接口风格：tei（/rerank）/ cohere（/v1/rerank，兼容 Jina、Xinference 等）
+		Model   string `yaml:"model" json:"model"` // 模型名称，tei 风格可不填
+	} `yaml:"http" json:"http"`
+	LLM struct {
+		PromptPath string `yaml:"prompt_path" json:"prompt_path"`
+		MaxChars   int    `yaml:"max_chars" json:"max_chars"` // 每个候选截取的最大字数，避免超出上下文长度
+	} `yaml:"llm" json:"llm"`
+}
+
+// LoadRerankConfig 读取rerank相关配置，未配置的项使用默认值
+func LoadRerankConfig(ctx context.Context) *RerankConfig {
+	cfg := &RerankConfig{}
+	if err := g.Cfg().MustGet(ctx, "rerank").Scan(cfg); err != nil {
+		g.Log().Warningf(ctx, "加载rerank配置失败，使用默认配置: %v", err)
+	}
+	if cfg.Backend == "" {
+		cfg.Backend = "http"
+	}
+	if cfg.TopN == 0 {
+		cfg.TopN = 20
+	}
+	// 超时允许配置为0，只在未配置时使用默认值
+	cfg.TimeoutMs = g.Cfg().MustGet(ctx, "rerank.timeout_ms", 3000).Int()
+	if cfg.HTTP.BaseURL == "" {
+		cfg.HTTP.BaseURL = "http://localhost:8080"
+	}
+	if cfg.HTTP.API == "" {
+		cfg.HTTP.API = "tei"
+	}
+	if cfg.LLM.PromptPath == "" {
+		cfg.LLM.PromptPath = "resource/prompts/rerank"
+	}
+	if cfg.LLM.MaxChars == 0 {
+		cfg.LLM.MaxChars = 500
+	}
+	return cfg
+}
+
+// GetReranker 工厂方法，根据配置返回对应实现
+func GetReranker() Reranker {
+	rerankerOnce.Do(func() {
+		ctx := context.Background()
+		cfg := LoadRerankConfig(ctx)
+		switch cfg.Backend {
+		case "http":
+			rerankerInstance = NewHTTPReranker(HTTPRerankerConfig{
+				BaseURL: cfg.HTTP.BaseURL,
+				API:     cfg.HTTP.API,
+				Model:   cfg.HTTP.Model,
+			})
+		case "llm":
+			rerankerInstance = &LLMReranker{
+				PromptPath: cfg.LLM.PromptPath,
+				MaxChars:   cfg.LLM.MaxChars,
+			}
+		default:
+			g.Log().Errorf(ctx, "不支持的rerank后端: %s，使用默认http后端", cfg.Backend)
+			rerankerInstance = NewHTTPReranker(HTTPRerankerConfig{
+				BaseURL: cfg.HTTP.BaseURL,
+				API:     cfg.HTTP.API,
+				Model:   cfg.HTTP.Model,
+			})
+		}
+	})
+	return rerankerInstance
+}
+
+// RerankResults 对检索结果重排序，填充 RerankScore 并按其降序排列
+// 参与重排序的文本为摘要与正文的拼接，摘要为空时只使用正文；超过 rerank.timeout_ms 时返回错误并保留原顺序
+func RerankResults(ctx context.Context, query string, results []model.SearchResult) ([]model.SearchResult, error) {
+	if len(results) == 0 {
+		return results, nil
+	}
+	if timeout := LoadRerankConfig(ctx).TimeoutMs; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(timeout)*time.Millisecond)
+		defer cancel()
+	}
+
+	documents := make([]string, len(results))
+	for i, r := range results {
+		documents[i] = r.Content
+		if r.Summary != "" {
+			documents[i] = r.Summary + "\n" + r.Content
+		}
+	}
+
+	scores, err := GetReranker().Rerank(ctx, query, documents)
+	if err != nil {
+		return results, fmt.Errorf("重排序失败: %w", err)
+	}
+	if len(scores) != len(results) {
+		return results, fmt.Errorf("重排序返回分数数量 %d 与候选数量 %d 不一致", len(scores), len(results))
+	}
+
+	for i := range results {
+		score := scores[i]
+		results[i].RerankScore = &score
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return *results[i].RerankScore > *results[j].RerankScore
+	})
+	return results, nil
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+type HTTPRerankerConfig struct {
+	BaseURL string
+	API     string
+	Model   string
+}
+
+// HTTPReranker 调用交叉编码器重排序服务
+//
+//   - tei: HuggingFace text-embeddings-inference，POST /rerank {"query","texts"} -> [{"index","score"}]
+//   - cohere: POST /v1/rerank {"model","query","documents"} -> {"results":[{"index","relevance_score"}]}，
+//     Jina、Xinference、vLLM 等 bge-reranker 服务均兼容该格式
+type HTTPReranker struct {
+	cfg HTTPRerankerConfig
+}
+
+func NewHTTPReranker(cfg HTTPRerankerConfig) *HTTPReranker {
+	return &HTTPReranker{cfg: cfg}
+}
+
+// Rerank 调用重排序服务
+func (r *HTTPReranker) Rerank(ctx context.Context, query string, documents []string) ([]float32, error) {
+	var (
+		url  string
+		body map[string]interface{}
+	)
+	baseURL := strings.TrimRight(r.cfg.BaseURL, "/")
+	if r.cfg.API == "cohere" {
+		url = baseURL + "/v1/rerank"
+		body = map[string]interface{}{
+			"model":     r.cfg.Model,
+			"query":     query,
+			"documents": documents,
+			"top_n":     len(documents),
+		}
+	} else {
+		url = baseURL + "/rerank"
+		body = map[string]interface{}{
+			"query":      query,
+			"texts":      documents,
+			"truncate":   true,
+			"raw_scores": false,
+		}
+	}
+
+	jsonBody, _ := json.Marshal(body)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBytes, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("rerank服务返回错误 %s: %s", resp.Status, string(respBytes))
+	}
+
+	type rankItem struct {
+		Index          int     `json:"index"`
+		Score          float32 `json:"score"`
+		RelevanceScore float32 `json:"relevance_score"`
+	}
+	var items []rankItem
+	if r.cfg.API == "cohere" {
+		var result struct {
+			Results []rankItem `json:"results"`
+		}
+		if err := json.Unmarshal(respBytes, &result); err != nil {
+			return nil, fmt.Errorf("解析rerank响应失败: %w", err)
+		}
+		items = result.Results
+		for i := range items {
+			items[i].Score = items[i].RelevanceScore
+		}
+	} else if err := json.Unmarshal(respBytes, &items); err != nil {
+		return nil, fmt.Errorf("解析rerank响应失败: %w", err)
+	}
+
+	scores := make([]float32, len(documents))
+	for _, item := range items {
+		if item.Index < 0 || item.Index >= len(documents) {
+			return nil, fmt.Errorf("rerank响应中的下标越界: %d", item.Index)
+		}
+		scores[item.Index] = item.Score
+	}
+	return scores, nil
+}
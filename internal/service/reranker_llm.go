@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// LLMReranker 大模型 listwise 重排序
+// 将全部候选编号后一次性交给大模型，要求输出按相关性从高到低排列的编号列表，
+// 排名转换为分数：第 r 名（从0开始）得分 1 - r/n，未出现在列表中的候选得0分
+type LLMReranker struct {
+	PromptPath string
+	MaxChars   int
+}
+
+// Rerank 调用大模型进行 listwise 重排序
+func (r *LLMReranker) Rerank(ctx context.Context, query string, documents []string) ([]float32, error) {
+	promptTmpl, err := LoadPromptTemplate(r.PromptPath)
+	if err != nil {
+		return nil, fmt.Errorf("加载重排序Prompt模板失败: %w", err)
+	}
+
+	var passages strings.Builder
+	for i, doc := range documents {
+		runes := []rune(doc)
+		if r.MaxChars > 0 && len(runes) > r.MaxChars {
+			runes = runes[:r.MaxChars]
+		}
+		fmt.Fprintf(&passages, "[%d] %s\n", i+1, strings.ReplaceAll(string(runes), "\n", " "))
+	}
+	prompt := strings.NewReplacer("{query}", query, "{passages}", passages.String()).Replace(promptTmpl)
+
+	resp, err := GetLLMClient().Generate(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+	jsonStr, err := ExtractJSONFromLLMResponse(resp)
+	if err != nil {
+		return nil, fmt.Errorf("提取大模型JSON失败: %w", err)
+	}
+	var parsed struct {
+		Ranking []int `json:"ranking"`
+	}
+	if err := json.Unmarshal([]byte(jsonStr), &parsed); err != nil {
+		return nil, fmt.Errorf("解析大模型JSON失败: %w", err)
+	}
+
+	n := len(documents)
+	scores := make([]float32, n)
+	seen := make(map[int]bool)
+	rank := 0
+	for _, no := range parsed.Ranking {
+		idx := no - 1
+		if idx < 0 || idx >= n || seen[idx] {
+			g.Log().Debugf(ctx, "忽略重排序结果中的无效编号: %d", no)
+			continue
+		}
+		seen[idx] = true
+		scores[idx] = 1 - float32(rank)/float32(n)
+		rank++
+	}
+	return scores, nil
+}
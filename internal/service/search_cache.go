@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gredis"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gcache"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"knowledge-system-api/internal/model"
+)
+
+// SearchCacheConfig 检索结果缓存配置
+type SearchCacheConfig struct {
+	Enabled  bool           `yaml:"enabled" json:"enabled"`   // 是否启用检索结果缓存
+	Backend  string         `yaml:"backend" json:"backend"`   // 缓存后端：memory（进程内 LRU）/redis
+	Capacity int            `yaml:"capacity" json:"capacity"` // memory 后端最多缓存的检索结果数量
+	TTL      int            `yaml:"ttl" json:"ttl"`           // 缓存有效期（秒）
+	Redis    *gredis.Config `yaml:"redis" json:"redis"`       // redis 后端的连接配置
+}
+
+const (
+	searchCacheBackendMemory = "memory"
+	searchCacheBackendRedis  = "redis"
+
+	searchCacheResultPrefix  = "search:result:"
+	searchCacheVersionPrefix = "search:version:"
+	// searchCacheVersionGlobal 所有检索结果共用的版本，无法确定知识库的写入会更新它
+	searchCacheVersionGlobal = searchCacheVersionPrefix + "@global"
+	// searchCacheVersionAllRepos 未指定知识库（检索全部知识库）的检索结果使用的版本，任一知识库写入都会更新它
+	searchCacheVersionAllRepos = searchCacheVersionPrefix + "@all"
+)
+
+// searchCache 检索结果缓存
+// 失效通过版本号实现：缓存键包含所涉及知识库的当前版本，知识库写入时更新版本，旧结果不再被命中并随 TTL 过期
+type searchCache struct {
+	cfg      *SearchCacheConfig
+	results  *gcache.Cache
+	versions *gcache.Cache // 版本号不受 LRU 淘汰影响，memory 后端单独存放
+	hits     atomic.Uint64
+	misses   atomic.Uint64
+}
+
+var (
+	searchCacheInstance *searchCache
+	searchCacheErr      error // 缓存已启用但创建失败的原因
+	searchCacheOnce     sync.Once
+)
+
+// LoadSearchCacheConfig 读取search.cache配置，未配置的项使用默认值
+func LoadSearchCacheConfig(ctx context.Context) *SearchCacheConfig {
+	cfg := &SearchCacheConfig{}
+	if err := g.Cfg().MustGet(ctx, "search.cache").Scan(cfg); err != nil {
+		g.Log().Warningf(ctx, "加载search.cache配置失败，使用默认配置: %v", err)
+	}
+	if cfg.Backend != searchCacheBackendRedis {
+		cfg.Backend = searchCacheBackendMemory
+	}
+	if cfg.Capacity <= 0 {
+		cfg.Capacity = 1000
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = 300
+	}
+	return cfg
+}
+
+// getSearchCache 获取检索结果缓存单例，未启用或创建失败时返回 nil
+// 创建失败（如 redis 不可用）时禁用缓存而不是回退到进程内缓存，避免多实例之间的失效互不可见
+func getSearchCache(ctx context.Context) *searchCache {
+	searchCacheOnce.Do(func() {
+		cfg := LoadSearchCacheConfig(ctx)
+		if !cfg.Enabled {
+			return
+		}
+		c, err := newSearchCache(ctx, cfg)
+		if err != nil {
+			searchCacheErr = err
+			g.Log().Errorf(ctx, "检索结果缓存已禁用: %v", err)
+			return
+		}
+		g.Log().Infof(ctx, "检索结果缓存已启用: backend=%s, ttl=%ds", cfg.Backend, cfg.TTL)
+		searchCacheInstance = c
+	})
+	return searchCacheInstance
+}
+
+// InitSearchCache 在服务启动时创建检索结果缓存，redis 后端无法连接时返回错误
+func InitSearchCache(ctx context.Context) error {
+	getSearchCache(ctx)
+	return searchCacheErr
+}
+
+// newSearchCache 按配置创建检索结果缓存，redis 后端会先 PING 确认可用
+func newSearchCache(ctx context.Context, cfg *SearchCacheConfig) (*searchCache, error) {
+	c := &searchCache{cfg: cfg}
+	if cfg.Backend == searchCacheBackendMemory {
+		c.results = gcache.New(cfg.Capacity)
+		c.versions = gcache.New()
+		return c, nil
+	}
+	if cfg.Redis == nil {
+		return nil, gerror.New("search.cache.backend 为 redis 时必须配置 search.cache.redis")
+	}
+	redis, err := gredis.New(cfg.Redis)
+	if err != nil {
+		return nil, gerror.Wrap(err, "创建检索缓存Redis客户端失败")
+	}
+	if _, err := redis.Do(ctx, "PING"); err != nil {
+		return nil, gerror.Wrapf(err, "连接检索缓存Redis失败: %s", cfg.Redis.Address)
+	}
+	c.results = gcache.NewWithAdapter(gcache.NewAdapterRedis(redis))
+	c.versions = c.results
+	return c, nil
+}
+
+// searchCacheKey 参与缓存键计算的检索参数
+type searchCacheKey struct {
+	Mode      string              `json:"mode"`
+	Query     string              `json:"query"`
+	RepoName  string              `json:"repo_name"`
+	RepoNames []string            `json:"repo_names"`
+	Limit     uint64              `json:"limit"`
+	Offset    uint64              `json:"offset"`
+	MinScore  *float32            `json:"min_score"`
+	Highlight bool                `json:"highlight"`
+	Fusion    string              `json:"fusion"`
+	Alpha     *float32            `json:"alpha"`
+	Beta      *float32            `json:"beta"`
+	Rerank    *bool               `json:"rerank"`
+	Filter    *model.SearchFilter `json:"filter"`
+	Strategy  string              `json:"strategy"`
+	Threshold *float32            `json:"label_threshold"`
+	Prefetch  *uint64             `json:"prefetch_multiplier"`
+	Versions  []string            `json:"versions"`
+}
+
+// GetSearchCache 查询检索结果缓存，返回缓存的结果与本次检索的缓存键
+// 缓存未启用时返回空缓存键，此时无需写入缓存
+func GetSearchCache(ctx context.Context, mode string, opts *model.SearchOptions) (*model.SearchOutput, string) {
+	c := getSearchCache(ctx)
+	if c == nil {
+		return nil, ""
+	}
+
+	key := c.key(ctx, mode, opts)
+	value, err := c.results.Get(ctx, key)
+	if err != nil {
+		g.Log().Warningf(ctx, "读取检索缓存失败: %v", err)
+		c.misses.Add(1)
+		return nil, key
+	}
+	if value.IsNil() {
+		c.misses.Add(1)
+		return nil, key
+	}
+
+	var output model.SearchOutput
+	if err := json.Unmarshal(value.Bytes(), &output); err != nil {
+		g.Log().Warningf(ctx, "解析检索缓存失败: %v", err)
+		c.misses.Add(1)
+		return nil, key
+	}
+	c.hits.Add(1)
+	return &output, key
+}
+
+// SetSearchCache 写入检索结果缓存，部分知识库失败的结果不缓存
+func SetSearchCache(ctx context.Context, key string, output *model.SearchOutput) {
+	c := getSearchCache(ctx)
+	if c == nil || key == "" || output == nil || len(output.Errors) > 0 {
+		return
+	}
+	data, err := json.Marshal(output)
+	if err != nil {
+		return
+	}
+	if err := c.results.Set(ctx, key, string(data), time.Duration(c.cfg.TTL)*time.Second); err != nil {
+		g.Log().Warningf(ctx, "写入检索缓存失败: %v", err)
+	}
+}
+
+// InvalidateSearchCache 知识库内容变化后使其检索结果缓存失效，不指定知识库时使全部缓存失效
+func InvalidateSearchCache(ctx context.Context, repoNames ...string) {
+	c := getSearchCache(ctx)
+	if c == nil {
+		return
+	}
+
+	keys := []string{searchCacheVersionAllRepos}
+	if len(repoNames) == 0 {
+		keys = []string{searchCacheVersionGlobal}
+	}
+	for _, name := range repoNames {
+		keys = append(keys, searchCacheVersionPrefix+name)
+	}
+	version := gtime.TimestampNanoStr()
+	for _, key := range keys {
+		if err := c.versions.Set(ctx, key, version, 0); err != nil {
+			g.Log().Warningf(ctx, "更新检索缓存版本失败: %v", err)
+		}
+	}
+}
+
+// SearchCacheStats 获取检索结果缓存的命中统计，统计为当前进程自启动以来的数据
+func SearchCacheStats(ctx context.Context) *model.SearchCacheStats {
+	c := getSearchCache(ctx)
+	if c == nil {
+		return &model.SearchCacheStats{}
+	}
+
+	stats := &model.SearchCacheStats{
+		Enabled: true,
+		Backend: c.cfg.Backend,
+		Hits:    c.hits.Load(),
+		Misses:  c.misses.Load(),
+	}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRate = float64(stats.Hits) / float64(total)
+	}
+	// redis 后端的 Size 为整个 DB 的键数量，没有参考意义
+	if c.cfg.Backend == searchCacheBackendMemory {
+		stats.Size = c.results.MustSize(ctx)
+	}
+	return stats
+}
+
+// key 计算缓存键：查询文本规范化（去除首尾空白、合并连续空白、转小写），知识库列表排序，并带上相关知识库的当前版本
+func (c *searchCache) key(ctx context.Context, mode string, opts *model.SearchOptions) string {
+	repoNames := append([]string(nil), opts.RepoNames...)
+	sort.Strings(repoNames)
+
+	versionKeys := []string{searchCacheVersionGlobal}
+	switch {
+	case opts.RepoName != "":
+		versionKeys = append(versionKeys, searchCacheVersionPrefix+opts.RepoName)
+	case len(repoNames) > 0:
+		for _, name := range repoNames {
+			versionKeys = append(versionKeys, searchCacheVersionPrefix+name)
+		}
+	default:
+		versionKeys = append(versionKeys, searchCacheVersionAllRepos)
+	}
+	versions := make([]string, len(versionKeys))
+	for i, key := range versionKeys {
+		value, err := c.versions.Get(ctx, key)
+		if err != nil {
+			g.Log().Warningf(ctx, "读取检索缓存版本失败: %v", err)
+			continue
+		}
+		versions[i] = value.String()
+	}
+
+	data, _ := json.Marshal(searchCacheKey{
+		Mode:      mode,
+		Query:     strings.ToLower(strings.Join(strings.Fields(opts.Query), " ")),
+		RepoName:  opts.RepoName,
+		RepoNames: repoNames,
+		Limit:     opts.Limit,
+		Offset:    opts.Offset,
+		MinScore:  opts.MinScore,
+		Highlight: opts.Highlight,
+		Fusion:    opts.Fusion,
+		Alpha:     opts.Alpha,
+		Beta:      opts.Beta,
+		Rerank:    opts.Rerank,
+		Filter:    opts.Filter,
+		Strategy:  opts.Strategy,
+		Threshold: opts.LabelThreshold,
+		Prefetch:  opts.PrefetchMultiplier,
+		Versions:  versions,
+	})
+	sum := sha1.Sum(data)
+	return searchCacheResultPrefix + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/frame/g"
+
+	"knowledge-system-api/internal/consts"
+)
+
+// SearchConfig 检索相关配置
+type SearchConfig struct {
+	Fusion             string `yaml:"fusion" json:"fusion"`                           // 默认融合策略：dense/sparse_dense/rrf/dbsf/weighted
+	PrefetchMultiplier uint64 `yaml:"prefetch_multiplier" json:"prefetch_multiplier"` // 每路预查询的候选数量 = limit * 该倍数
+	Hybrid             struct {
+		Alpha float32 `yaml:"alpha" json:"alpha"` // weighted 策略中密集向量分数的权重
+		Beta  float32 `yaml:"beta" json:"beta"`   // weighted 策略中标签稀疏向量分数的权重
+	} `yaml:"hybrid" json:"hybrid"`
+	BM25 struct {
+		K1 float64 `yaml:"k1" json:"k1"` // 词频饱和参数
+		B  float64 `yaml:"b" json:"b"`   // 文档长度归一化参数
+	} `yaml:"bm25" json:"bm25"`
+	// Fanout 跨知识库检索：每个知识库是独立的集合，并行检索后合并
+	Fanout struct {
+		Concurrency  int    `yaml:"concurrency" json:"concurrency"`       // 最大并行检索的知识库数量
+		PerRepoLimit uint64 `yaml:"per_repo_limit" json:"per_repo_limit"` // 跨知识库检索时每个知识库召回数量的上限，也是可翻页的最大深度，0 表示不限制
+		Normalize    string `yaml:"normalize" json:"normalize"`           // 合并前的分数归一化方式：minmax/max/none
+	} `yaml:"fanout" json:"fanout"`
+}
+
+// LoadSearchConfig 读取search相关配置，未配置的项使用默认值
+func LoadSearchConfig(ctx context.Context) *SearchConfig {
+	cfg := &SearchConfig{}
+	if err := g.Cfg().MustGet(ctx, "search").Scan(cfg); err != nil {
+		g.Log().Warningf(ctx, "加载search配置失败，使用默认配置: %v", err)
+	}
+	if !IsValidFusion(cfg.Fusion) {
+		cfg.Fusion = consts.FusionRRF
+	}
+	if cfg.PrefetchMultiplier == 0 {
+		cfg.PrefetchMultiplier = 3
+	}
+	if cfg.Hybrid.Alpha == 0 && cfg.Hybrid.Beta == 0 {
+		cfg.Hybrid.Alpha = 0.7
+		cfg.Hybrid.Beta = 0.3
+	}
+	// k1 为0时只看词是否出现，b 为0时不按文档长度归一化，允许配置为0，只在未配置时使用默认值
+	cfg.BM25.K1 = g.Cfg().MustGet(ctx, "search.bm25.k1", 1.2).Float64()
+	cfg.BM25.B = g.Cfg().MustGet(ctx, "search.bm25.b", 0.75).Float64()
+	if cfg.Fanout.Concurrency <= 0 {
+		cfg.Fanout.Concurrency = 4
+	}
+	switch cfg.Fanout.Normalize {
+	case consts.NormalizeMinMax, consts.NormalizeMax, consts.NormalizeNone:
+	default:
+		cfg.Fanout.Normalize = consts.NormalizeMinMax
+	}
+	return cfg
+}
+
+// IsValidFusion 判断融合策略是否受支持
+func IsValidFusion(fusion string) bool {
+	switch fusion {
+	case consts.FusionDense, consts.FusionSparseDense, consts.FusionRRF, consts.FusionDBSF, consts.FusionWeighted, consts.FusionLexical:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+)
+
+// searchCursor 翻页游标内容，记录下一页的起始位置与所属查询
+type searchCursor struct {
+	Offset uint64 `json:"o"`
+	Query  string `json:"q"` // 查询文本的摘要，防止游标被用于其他查询
+}
+
+// EncodeSearchCursor 生成指向下一页的翻页游标
+func EncodeSearchCursor(query string, offset uint64) string {
+	data, _ := json.Marshal(searchCursor{Offset: offset, Query: queryDigest(query)})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeSearchCursor 解析翻页游标，返回下一页的起始位置
+func DecodeSearchCursor(query, cursor string) (uint64, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, errors.New("翻页游标格式错误")
+	}
+	var c searchCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return 0, errors.New("翻页游标格式错误")
+	}
+	if c.Query != queryDigest(query) {
+		return 0, errors.New("翻页游标与查询不匹配")
+	}
+	return c.Offset, nil
+}
+
+// queryDigest 计算查询文本的短摘要
+func queryDigest(query string) string {
+	sum := sha1.Sum([]byte(query))
+	return hex.EncodeToString(sum[:4])
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestSearchCursor(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		cursor     string
+		wantOffset uint64
+		wantErr    bool
+	}{
+		{name: "同一查询", query: "报销比例", cursor: EncodeSearchCursor("报销比例", 20), wantOffset: 20},
+		{name: "起始位置为0", query: "报销比例", cursor: EncodeSearchCursor("报销比例", 0), wantOffset: 0},
+		{name: "空查询", query: "", cursor: EncodeSearchCursor("", 10), wantOffset: 10},
+		{name: "查询不匹配", query: "报销范围", cursor: EncodeSearchCursor("报销比例", 20), wantErr: true},
+		{name: "不是base64", query: "报销比例", cursor: "!!!", wantErr: true},
+		{name: "不是JSON", query: "报销比例", cursor: base64.RawURLEncoding.EncodeToString([]byte("20")), wantErr: true},
+		{name: "空游标", query: "报销比例", cursor: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offset, err := DecodeSearchCursor(tt.query, tt.cursor)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeSearchCursor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if offset != tt.wantOffset {
+				t.Errorf("DecodeSearchCursor() = %d, want %d", offset, tt.wantOffset)
+			}
+		})
+	}
+}
@@ -0,0 +1,315 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gcron"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/util/grand"
+
+	"knowledge-system-api/internal/dao"
+	"knowledge-system-api/internal/model"
+	"knowledge-system-api/internal/model/do"
+	"knowledge-system-api/internal/model/entity"
+)
+
+// searchLogCleanupBatch 清理过期记录时每次删除的数量，避免长时间锁表
+const searchLogCleanupBatch = 5000
+
+// SearchLogConfig 检索记录配置
+type SearchLogConfig struct {
+	Enabled       bool    `yaml:"enabled" json:"enabled"`               // 是否记录检索
+	SampleRate    float64 `yaml:"sample_rate" json:"sample_rate"`       // 抽样比例 (0,1]，失败的检索总是记录
+	RetentionDays int     `yaml:"retention_days" json:"retention_days"` // 保留天数，0 表示不清理
+	CleanupCron   string  `yaml:"cleanup_cron" json:"cleanup_cron"`     // 清理任务的 gcron 表达式（含秒）
+	// ImpressionRetentionDays 展示记录与点击、复制等检索行为的保留天数，0 表示不清理
+	ImpressionRetentionDays int `yaml:"impression_retention_days" json:"impression_retention_days"`
+	// TurnRetentionDays 会话轮次的保留天数，0 表示不清理
+	TurnRetentionDays int `yaml:"turn_retention_days" json:"turn_retention_days"`
+}
+
+// LoadSearchLogConfig 读取search.log配置，未配置的项使用默认值
+func LoadSearchLogConfig(ctx context.Context) *SearchLogConfig {
+	cfg := &SearchLogConfig{Enabled: true}
+	if err := g.Cfg().MustGet(ctx, "search.log").Scan(cfg); err != nil {
+		g.Log().Warningf(ctx, "加载search.log配置失败，使用默认配置: %v", err)
+	}
+	if cfg.SampleRate <= 0 || cfg.SampleRate > 1 {
+		cfg.SampleRate = 1
+	}
+	// 保留天数允许配置为0，只在未配置时使用默认值
+	cfg.RetentionDays = g.Cfg().MustGet(ctx, "search.log.retention_days", 30).Int()
+	cfg.ImpressionRetentionDays = g.Cfg().MustGet(ctx, "search.log.impression_retention_days", 90).Int()
+	cfg.TurnRetentionDays = g.Cfg().MustGet(ctx, "search.log.turn_retention_days", 30).Int()
+	if cfg.CleanupCron == "" {
+		cfg.CleanupCron = "0 30 3 * * *"
+	}
+	return cfg
+}
+
+// NewSearchLogResults 转换检索结果并返回第一条结果的排序分数，没有结果时为 nil
+func NewSearchLogResults(items []model.SearchResult) ([]model.SearchLogResult, *float32) {
+	results := make([]model.SearchLogResult, len(items))
+	for i, item := range items {
+		results[i] = model.SearchLogResult{
+			ID:          item.ID,
+			RepoName:    item.RepoName,
+			Score:       item.Score,
+			RerankScore: item.RerankScore,
+		}
+	}
+	if len(items) == 0 {
+		return results, nil
+	}
+	top := rankingScore(items[0])
+	return results, &top
+}
+
+// RecordSearchLog 按 search.log 配置抽样保存检索记录，失败的检索总是保存
+func RecordSearchLog(ctx context.Context, log *model.SearchLog) error {
+	cfg := LoadSearchLogConfig(ctx)
+	if !cfg.Enabled {
+		return nil
+	}
+	if log.Error == "" && cfg.SampleRate < 1 && !grand.Meet(int(cfg.SampleRate*10000), 10000) {
+		return nil
+	}
+
+	repoNames := log.RepoNames
+	if repoNames == nil {
+		repoNames = []string{}
+	}
+	log.CreatedAt = gtime.Now()
+	data := do.SearchLog{
+		SearchId:    log.SearchID,
+		Source:      log.Source,
+		SessionId:   log.SessionID,
+		Query:       log.Query,
+		SearchQuery: log.SearchQuery,
+		RepoNames:   toJSONString(repoNames),
+		Mode:        log.Mode,
+		Fusion:      log.Fusion,
+		Params:      toJSONString(log.Params),
+		Results:     toJSONString(log.Results),
+		ResultCount: len(log.Results),
+		Total:       log.Total,
+		Cached:      log.Cached,
+		LatencyMs:   log.LatencyMs,
+		Timings:     toJSONString(log.Timings),
+		CreatedAt:   log.CreatedAt,
+	}
+	if log.Filter != nil {
+		data.Filter = toJSONString(log.Filter)
+	}
+	if log.TopScore != nil {
+		data.TopScore = *log.TopScore
+	}
+	if log.Error != "" {
+		data.Error = log.Error
+	}
+	id, err := dao.SearchLog.Ctx(ctx).Data(data).InsertAndGetId()
+	log.ID = uint64(id)
+	return err
+}
+
+// ListSearchLogs 分页查询检索记录，按时间倒序
+func ListSearchLogs(ctx context.Context, opts model.SearchLogListOptions, page, pageSize int) ([]model.SearchLog, int, error) {
+	cols := dao.SearchLog.Columns()
+	m := dao.SearchLog.Ctx(ctx)
+	if opts.RepoName != "" {
+		m = m.Where("JSON_CONTAINS(repo_names, JSON_QUOTE(?))", opts.RepoName)
+	}
+	if opts.SessionID != "" {
+		m = m.Where(cols.SessionId, opts.SessionID)
+	}
+	if opts.Source != "" {
+		m = m.Where(cols.Source, opts.Source)
+	}
+	if opts.Query != "" {
+		m = m.Where("(query LIKE ? OR search_query LIKE ?)", "%"+opts.Query+"%", "%"+opts.Query+"%")
+	}
+	if opts.KnowledgeID != "" {
+		m = m.Where("JSON_CONTAINS(results, JSON_OBJECT('id', ?))", opts.KnowledgeID)
+	}
+	if opts.Failed {
+		m = m.WhereNotNull(cols.Error)
+	}
+	if opts.ZeroResult {
+		m = m.Where(cols.ResultCount, 0).WhereNull(cols.Error)
+	}
+	if opts.StartTime != nil {
+		m = m.WhereGTE(cols.CreatedAt, opts.StartTime)
+	}
+	if opts.EndTime != nil {
+		m = m.WhereLTE(cols.CreatedAt, opts.EndTime)
+	}
+	total, err := m.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var rows []entity.SearchLog
+	if err := m.Page(page, pageSize).OrderDesc(cols.Id).Scan(&rows); err != nil {
+		return nil, 0, err
+	}
+	logs := make([]model.SearchLog, len(rows))
+	for i, row := range rows {
+		logs[i] = toSearchLog(ctx, row)
+	}
+	return logs, total, nil
+}
+
+// GetSearchLog 按检索ID获取检索记录，不存在（未抽中或已清理）时返回 nil
+func GetSearchLog(ctx context.Context, searchID string) (*model.SearchLog, error) {
+	var row *entity.SearchLog
+	if err := dao.SearchLog.Ctx(ctx).Where(do.SearchLog{SearchId: searchID}).Scan(&row); err != nil {
+		return nil, err
+	}
+	if row == nil {
+		return nil, nil
+	}
+	log := toSearchLog(ctx, *row)
+	return &log, nil
+}
+
+// searchCleanupTable 需要按保留天数清理的表
+type searchCleanupTable struct {
+	name  string                           // 日志中的名称
+	model func(context.Context) *gdb.Model // 表的模型
+	days  func(*SearchLogConfig) int       // 保留天数
+}
+
+// searchCleanupTables 检索记录、展示记录、检索行为与会话轮次的清理配置
+var searchCleanupTables = []searchCleanupTable{
+	{
+		name:  "检索记录",
+		model: func(ctx context.Context) *gdb.Model { return dao.SearchLog.Ctx(ctx) },
+		days:  func(cfg *SearchLogConfig) int { return cfg.RetentionDays },
+	},
+	{
+		name:  "展示记录",
+		model: func(ctx context.Context) *gdb.Model { return dao.SearchImpression.Ctx(ctx) },
+		days:  func(cfg *SearchLogConfig) int { return cfg.ImpressionRetentionDays },
+	},
+	{
+		name:  "检索行为",
+		model: func(ctx context.Context) *gdb.Model { return dao.SearchEvent.Ctx(ctx) },
+		days:  func(cfg *SearchLogConfig) int { return cfg.ImpressionRetentionDays },
+	},
+	{
+		name:  "会话轮次",
+		model: func(ctx context.Context) *gdb.Model { return dao.SessionTurn.Ctx(ctx) },
+		days:  func(cfg *SearchLogConfig) int { return cfg.TurnRetentionDays },
+	},
+}
+
+// cleanupBefore 按主键顺序分批删除 before 之前创建的记录，返回删除的数量
+func cleanupBefore(ctx context.Context, model func(context.Context) *gdb.Model, before time.Time) (int64, error) {
+	var deleted int64
+	for {
+		result, err := model(ctx).
+			WhereLT("created_at", gtime.New(before)).
+			OrderAsc("id").
+			Limit(searchLogCleanupBatch).
+			Delete()
+		if err != nil {
+			return deleted, err
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return deleted, err
+		}
+		deleted += n
+		if n < searchLogCleanupBatch {
+			return deleted, nil
+		}
+	}
+}
+
+// CleanupSearchRecords 按 search.log 配置清理过期的检索记录、展示记录、检索行为与会话轮次
+// 保留天数为0的表不清理，某张表清理失败时记录日志并继续清理其他表
+func CleanupSearchRecords(ctx context.Context) {
+	cfg := LoadSearchLogConfig(ctx)
+	for _, table := range searchCleanupTables {
+		days := table.days(cfg)
+		if days <= 0 {
+			continue
+		}
+		deleted, err := cleanupBefore(ctx, table.model, time.Now().AddDate(0, 0, -days))
+		if err != nil {
+			g.Log().Errorf(ctx, "清理过期%s失败，已删除 %d 条: %v", table.name, deleted, err)
+			continue
+		}
+		g.Log().Infof(ctx, "已清理 %d 天前的%s %d 条", days, table.name, deleted)
+	}
+}
+
+// StartSearchLogCleanup 按 search.log 配置注册过期检索记录、展示记录、检索行为与会话轮次的清理任务
+// 所有保留天数都为0时不注册
+func StartSearchLogCleanup(ctx context.Context) error {
+	cfg := LoadSearchLogConfig(ctx)
+	if cfg.RetentionDays <= 0 && cfg.ImpressionRetentionDays <= 0 && cfg.TurnRetentionDays <= 0 {
+		g.Log().Info(ctx, "检索记录清理未启用")
+		return nil
+	}
+	_, err := gcron.AddSingleton(ctx, cfg.CleanupCron, CleanupSearchRecords, "search_log_cleanup")
+	if err != nil {
+		return gerror.Wrapf(err, "注册检索记录清理任务失败: %s", cfg.CleanupCron)
+	}
+	g.Log().Infof(ctx, "检索记录清理已注册: %s，检索记录保留 %d 天，展示记录与检索行为保留 %d 天，会话轮次保留 %d 天",
+		cfg.CleanupCron, cfg.RetentionDays, cfg.ImpressionRetentionDays, cfg.TurnRetentionDays)
+	return nil
+}
+
+// toSearchLog 转换检索记录，JSON 字段解析失败时保留为空
+func toSearchLog(ctx context.Context, row entity.SearchLog) model.SearchLog {
+	log := model.SearchLog{
+		ID:          row.Id,
+		SearchID:    row.SearchId,
+		Source:      row.Source,
+		SessionID:   row.SessionId,
+		Query:       row.Query,
+		SearchQuery: row.SearchQuery,
+		Mode:        row.Mode,
+		Fusion:      row.Fusion,
+		Total:       row.Total,
+		Cached:      row.Cached != 0,
+		LatencyMs:   row.LatencyMs,
+		Error:       row.Error,
+		CreatedAt:   row.CreatedAt,
+	}
+	if row.ResultCount > 0 {
+		top := float32(row.TopScore)
+		log.TopScore = &top
+	}
+	parseSearchLogField(ctx, row.SearchId, "repo_names", row.RepoNames, &log.RepoNames)
+	parseSearchLogField(ctx, row.SearchId, "params", row.Params, &log.Params)
+	parseSearchLogField(ctx, row.SearchId, "filter", row.Filter, &log.Filter)
+	parseSearchLogField(ctx, row.SearchId, "results", row.Results, &log.Results)
+	parseSearchLogField(ctx, row.SearchId, "timings", row.Timings, &log.Timings)
+	return log
+}
+
+// parseSearchLogField 解析检索记录中的 JSON 字段，为空或解析失败时保留零值
+func parseSearchLogField(ctx context.Context, searchID, field, value string, ptr interface{}) {
+	if value == "" {
+		return
+	}
+	if err := json.Unmarshal([]byte(value), ptr); err != nil {
+		g.Log().Warningf(ctx, "解析检索记录 %s 的 %s 失败: %v", searchID, field, err)
+	}
+}
+
+// toJSONString 序列化为 JSON 字符串，失败时返回 null
+func toJSONString(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "null"
+	}
+	return string(data)
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+
+	"knowledge-system-api/internal/model"
+)
+
+// SessionConfig 多轮会话检索配置
+type SessionConfig struct {
+	Rewrite         bool   // 是否改写追问
+	HistoryTurns    int    // 改写时参考的历史轮数
+	RewriteBudgetMs int    // 改写调用大模型的最长等待时间（毫秒），超时使用原始查询
+	PromptPath      string // 改写提示词模板，使用 {history} 与 {query} 占位符
+	Validate        bool   // 是否只接受由 POST /session 签发且未过期的会话ID，默认开启
+	TTLDays         int    // 签发的会话ID有效天数，0 表示不过期
+}
+
+// LoadSessionConfig 读取session配置，未配置的项使用默认值
+func LoadSessionConfig(ctx context.Context) *SessionConfig {
+	return &SessionConfig{
+		Rewrite:         g.Cfg().MustGet(ctx, "session.rewrite", true).Bool(),
+		HistoryTurns:    g.Cfg().MustGet(ctx, "session.history_turns", 3).Int(),
+		RewriteBudgetMs: g.Cfg().MustGet(ctx, "session.rewrite_budget_ms", 3000).Int(),
+		PromptPath:      g.Cfg().MustGet(ctx, "session.rewrite_prompt_path", "resource/prompts/rewrite").String(),
+		Validate:        g.Cfg().MustGet(ctx, "session.validate", true).Bool(),
+		TTLDays:         g.Cfg().MustGet(ctx, "session.ttl_days", 30).Int(),
+	}
+}
+
+// ISession 多轮会话服务接口
+type ISession interface {
+	// Rewrite 结合会话历史将追问改写为独立查询，会话没有历史时返回原始查询
+	Rewrite(ctx context.Context, sessionID, query string) (*model.QueryRewrite, error)
+
+	// AppendTurn 记录一轮检索，返回轮次ID
+	AppendTurn(ctx context.Context, sessionID, query, rewrittenQuery string, knowledgeIDs []string) (uint64, error)
+
+	// RecentTurns 按时间顺序返回会话最近的 limit 轮
+	RecentTurns(ctx context.Context, sessionID string, limit int) ([]model.SessionTurn, error)
+
+	// FindTurn 查找会话中返回过指定知识的最近一轮，会话中没有轮次返回过该知识时返回0
+	FindTurn(ctx context.Context, sessionID, knowledgeID string) (uint64, error)
+
+	// Create 签发新的会话ID
+	Create(ctx context.Context) (*model.UserSession, error)
+
+	// Validate 校验调用方传入的会话ID，未签发或已过期时返回 CodeInvalidParameter 错误，session.validate 为 false 时不校验
+	Validate(ctx context.Context, sessionID string) error
+}
+
+var (
+	localSession ISession
+)
+
+// Session 获取多轮会话服务
+func Session() ISession {
+	if localSession == nil {
+		panic("implement not found for interface ISession, forgot register?")
+	}
+	return localSession
+}
+
+// RegisterSession 注册多轮会话服务
+func RegisterSession(i ISession) {
+	localSession = i
+}
+
+// RewriteQuery 调用大模型将追问改写为不依赖上下文的独立查询
+func RewriteQuery(ctx context.Context, cfg *SessionConfig, history []model.SessionTurn, query string) (string, error) {
+	promptTmpl, err := LoadPromptTemplate(cfg.PromptPath)
+	if err != nil {
+		return "", fmt.Errorf("加载改写Prompt模板失败: %w", err)
+	}
+
+	var b strings.Builder
+	for i, turn := range history {
+		fmt.Fprintf(&b, "第%d轮: %s\n", i+1, turn.RewrittenQuery)
+	}
+	prompt := strings.NewReplacer("{history}", b.String(), "{query}", query).Replace(promptTmpl)
+
+	budgetCtx, cancel := context.WithTimeout(ctx, time.Duration(cfg.RewriteBudgetMs)*time.Millisecond)
+	defer cancel()
+	resp, err := GetLLMClient().Generate(budgetCtx, prompt)
+	if err != nil {
+		return "", err
+	}
+	jsonStr, err := ExtractJSONFromLLMResponse(resp)
+	if err != nil {
+		return "", fmt.Errorf("提取大模型JSON失败: %w", err)
+	}
+	var parsed struct {
+		Query string `json:"query"`
+	}
+	if err := json.Unmarshal([]byte(jsonStr), &parsed); err != nil {
+		return "", fmt.Errorf("解析改写结果失败: %w", err)
+	}
+	if strings.TrimSpace(parsed.Query) == "" {
+		return "", fmt.Errorf("改写结果为空")
+	}
+	return strings.TrimSpace(parsed.Query), nil
+}
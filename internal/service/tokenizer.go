@@ -0,0 +1,275 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+var (
+	tokenizerInstance *ChineseTokenizer
+	tokenizerOnce     sync.Once
+)
+
+// minDictWords 词典词条数低于该值时视为示例词典，启动时提示替换为完整词典
+const minDictWords = 10000
+
+// defaultStopWords 内置停用词，分词结果中会被剔除
+var defaultStopWords = []string{
+	"的", "了", "是", "在", "和", "与", "及", "或", "也", "都", "就", "而", "被", "把", "对", "从", "向", "为", "以",
+	"我", "你", "他", "她", "它", "我们", "你们", "他们", "这", "那", "这个", "那个", "什么", "吗", "呢", "吧", "啊",
+	"a", "an", "the", "of", "to", "and", "or", "in", "on", "for", "is", "are",
+}
+
+// TokenizerConfig 分词器配置
+type TokenizerConfig struct {
+	DictPath     string `yaml:"dict_path" json:"dict_path"`           // 主词典路径，格式与 jieba dict.txt 相同：词 词频 [词性]
+	UserDictPath string `yaml:"user_dict_path" json:"user_dict_path"` // 用户词典路径，可选，格式同上
+}
+
+// ChineseTokenizer 基于词典的中文分词器
+//
+// 算法与 jieba 的精确模式一致：根据前缀词典构建有向无环图，动态规划求最大概率路径。
+// 未登录词（连续的、不在词典中的单字）不使用 HMM，而是切分为二元组（bigram），
+// 保证索引与查询使用相同的切分方式即可相互匹配。
+type ChineseTokenizer struct {
+	freq      map[string]float64 // 词 -> 词频，前缀以0词频存在
+	total     float64            // 总词频
+	words     int                // 词条数量，不含前缀
+	maxLen    int                // 最长词的字数
+	stopWords map[string]bool
+}
+
+// Tokenizer 获取分词器单例
+func Tokenizer() *ChineseTokenizer {
+	tokenizerOnce.Do(func() {
+		ctx := context.Background()
+		var cfg TokenizerConfig
+		if err := g.Cfg().MustGet(ctx, "tokenizer").Scan(&cfg); err != nil {
+			g.Log().Warningf(ctx, "加载tokenizer配置失败，使用默认配置: %v", err)
+		}
+		if cfg.DictPath == "" {
+			cfg.DictPath = "resource/dict/dict.txt"
+		}
+
+		t := NewChineseTokenizer()
+		for _, path := range []string{cfg.DictPath, cfg.UserDictPath} {
+			if path == "" {
+				continue
+			}
+			if err := t.LoadDict(path); err != nil {
+				g.Log().Errorf(ctx, "加载分词词典 %s 失败: %v", path, err)
+			}
+		}
+		g.Log().Infof(ctx, "分词器初始化完成，词条数量: %d", t.words)
+		if t.words < minDictWords {
+			g.Log().Warningf(ctx, "分词词典只有 %d 个词条，请通过 tokenizer.dict_path 配置完整的 jieba 词典，否则词法检索效果较差", t.words)
+		}
+		tokenizerInstance = t
+	})
+	return tokenizerInstance
+}
+
+// NewChineseTokenizer 创建空词典的分词器
+func NewChineseTokenizer() *ChineseTokenizer {
+	t := &ChineseTokenizer{
+		freq:      make(map[string]float64),
+		stopWords: make(map[string]bool),
+	}
+	for _, w := range defaultStopWords {
+		t.stopWords[w] = true
+	}
+	return t
+}
+
+// LoadDict 加载 jieba 格式的词典文件
+func (t *ChineseTokenizer) LoadDict(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		freq := 1.0
+		if len(fields) > 1 {
+			if v, err := strconv.ParseFloat(fields[1], 64); err == nil {
+				freq = v
+			}
+		}
+		t.AddWord(fields[0], freq)
+	}
+	return scanner.Err()
+}
+
+// AddWord 向词典中添加词条
+func (t *ChineseTokenizer) AddWord(word string, freq float64) {
+	runes := []rune(word)
+	if len(runes) == 0 {
+		return
+	}
+	if old, ok := t.freq[word]; ok {
+		t.total -= old
+	}
+	if t.freq[word] == 0 {
+		t.words++
+	}
+	t.freq[word] = freq
+	t.total += freq
+	if len(runes) > t.maxLen {
+		t.maxLen = len(runes)
+	}
+	// 记录前缀，构建DAG时用于提前终止
+	for i := 1; i < len(runes); i++ {
+		prefix := string(runes[:i])
+		if _, ok := t.freq[prefix]; !ok {
+			t.freq[prefix] = 0
+		}
+	}
+}
+
+// Cut 对文本分词，返回去除停用词与标点后的小写词条
+func (t *ChineseTokenizer) Cut(text string) []string {
+	var tokens []string
+	var han, other []rune
+
+	flushHan := func() {
+		if len(han) > 0 {
+			tokens = append(tokens, t.cutHan(han)...)
+			han = han[:0]
+		}
+	}
+	flushOther := func() {
+		if len(other) > 0 {
+			tokens = append(tokens, strings.ToLower(string(other)))
+			other = other[:0]
+		}
+	}
+
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			flushOther()
+			han = append(han, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushHan()
+			other = append(other, r)
+		default:
+			flushHan()
+			flushOther()
+		}
+	}
+	flushHan()
+	flushOther()
+
+	result := tokens[:0]
+	for _, tok := range tokens {
+		if !t.stopWords[tok] {
+			result = append(result, tok)
+		}
+	}
+	return result
+}
+
+// CutForSearch 搜索引擎模式分词，与 jieba 的 cut_for_search 一致：
+// 在精确分词结果的基础上，对长词再输出其中包含的二字、三字词典词，提高召回
+func (t *ChineseTokenizer) CutForSearch(text string) []string {
+	var result []string
+	for _, word := range t.Cut(text) {
+		runes := []rune(word)
+		for _, size := range []int{2, 3} {
+			if len(runes) <= size {
+				continue
+			}
+			for i := 0; i+size <= len(runes); i++ {
+				sub := string(runes[i : i+size])
+				if t.freq[sub] > 0 {
+					result = append(result, sub)
+				}
+			}
+		}
+		result = append(result, word)
+	}
+	return result
+}
+
+// cutHan 对连续的汉字片段分词
+func (t *ChineseTokenizer) cutHan(s []rune) []string {
+	n := len(s)
+	if n == 0 {
+		return nil
+	}
+
+	// 构建DAG：dag[i] 为所有以 i 开头的词的结束位置
+	dag := make([][]int, n)
+	for i := 0; i < n; i++ {
+		for j := i; j < n && j-i < t.maxLen; j++ {
+			freq, ok := t.freq[string(s[i:j+1])]
+			if !ok {
+				break
+			}
+			if freq > 0 {
+				dag[i] = append(dag[i], j)
+			}
+		}
+		if len(dag[i]) == 0 {
+			dag[i] = []int{i}
+		}
+	}
+
+	// 从后向前动态规划，求最大概率路径
+	logTotal := math.Log(math.Max(t.total, 1))
+	route := make([]float64, n+1)
+	next := make([]int, n)
+	for i := n - 1; i >= 0; i-- {
+		route[i] = math.Inf(-1)
+		for _, j := range dag[i] {
+			freq := t.freq[string(s[i:j+1])]
+			score := math.Log(math.Max(freq, 1)) - logTotal + route[j+1]
+			if score > route[i] {
+				route[i] = score
+				next[i] = j
+			}
+		}
+	}
+
+	// 按路径输出，连续的未登录单字（停用词除外）合并后切分为二元组
+	var words []string
+	var buf []rune
+	flush := func() {
+		switch len(buf) {
+		case 0:
+		case 1:
+			words = append(words, string(buf))
+		default:
+			for k := 0; k+1 < len(buf); k++ {
+				words = append(words, string(buf[k:k+2]))
+			}
+		}
+		buf = buf[:0]
+	}
+	for i := 0; i < n; {
+		j := next[i]
+		if j == i && t.freq[string(s[i])] == 0 && !t.stopWords[string(s[i])] {
+			buf = append(buf, s[i])
+		} else {
+			flush()
+			words = append(words, string(s[i:j+1]))
+		}
+		i = j + 1
+	}
+	flush()
+	return words
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"slices"
+	"testing"
+)
+
+// newTestTokenizer 创建只包含少量词条的分词器，测试结果不依赖词典文件
+func newTestTokenizer() *ChineseTokenizer {
+	t := NewChineseTokenizer()
+	for word, freq := range map[string]float64{
+		"基本医疗保险": 1500,
+		"医疗保险":   3000,
+		"医疗":     2000,
+		"保险":     2000,
+		"报销":     1500,
+		"比例":     1000,
+	} {
+		t.AddWord(word, freq)
+	}
+	return t
+}
+
+// useTestTokenizer 让 Tokenizer() 返回测试分词器，需在首次调用 Tokenizer() 之前执行
+func useTestTokenizer() {
+	tokenizerOnce.Do(func() {
+		tokenizerInstance = newTestTokenizer()
+	})
+}
+
+func TestChineseTokenizerCut(t *testing.T) {
+	tokenizer := newTestTokenizer()
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "空文本", text: "", want: nil},
+		{name: "词典中的长词", text: "基本医疗保险", want: []string{"基本医疗保险"}},
+		{name: "最大概率路径", text: "医疗保险报销比例", want: []string{"医疗保险", "报销", "比例"}},
+		{name: "未登录词切分为二元组", text: "甲乙丙", want: []string{"甲乙", "乙丙"}},
+		{name: "未登录单字", text: "甲", want: []string{"甲"}},
+		{name: "去除停用词", text: "的比例", want: []string{"比例"}},
+		{name: "字母数字转小写并按标点切分", text: "医保报销 API，GPT4", want: []string{"医保", "报销", "api", "gpt4"}},
+		{name: "只有停用词", text: "的 the", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tokenizer.Cut(tt.text); !slices.Equal(got, tt.want) {
+				t.Errorf("Cut(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChineseTokenizerCutForSearch(t *testing.T) {
+	tokenizer := newTestTokenizer()
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "长词输出其中的词典词", text: "基本医疗保险", want: []string{"医疗", "保险", "基本医疗保险"}},
+		{name: "两字词不再拆分", text: "报销比例", want: []string{"报销", "比例"}},
+		{name: "未登录词不输出子词", text: "甲乙丙", want: []string{"甲乙", "乙丙"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tokenizer.CutForSearch(tt.text); !slices.Equal(got, tt.want) {
+				t.Errorf("CutForSearch(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
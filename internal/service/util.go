@@ -3,6 +3,8 @@ package service
 import (
 	"fmt"
 	"regexp"
+	"strings"
+	"unicode"
 	// "regexp"
 )
 
@@ -41,3 +43,30 @@ func ExtractJSONFromLLMResponse(resp string) (string, error) {
 	}
 	return "", fmt.Errorf("未找到有效的JSON内容")
 }
+
+// NormalizeQuery 归一化查询用于去重：全角转半角、转小写、去除标点符号、合并空白
+// 中文之间的空白没有意义，直接去除
+func NormalizeQuery(query string) string {
+	var b strings.Builder
+	var last rune
+	space := false
+	for _, r := range query {
+		switch {
+		case r == '　':
+			r = ' '
+		case r >= '！' && r <= '～':
+			r -= 0xfee0
+		}
+		if unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r) {
+			space = last != 0
+			continue
+		}
+		if space && !unicode.Is(unicode.Han, last) && !unicode.Is(unicode.Han, r) {
+			b.WriteByte(' ')
+		}
+		space = false
+		last = unicode.ToLower(r)
+		b.WriteRune(last)
+	}
+	return b.String()
+}
@@ -1,12 +1,15 @@
 -- =================================================================
 -- 知识库系统数据库完整脚本 (最终优化版)
--- 包含: knowledge, import_task, import_task_item, task_queue 四张表
+-- 包含: knowledge, knowledge_repo, import_task, import_task_item, task_queue, feedback, knowledge_quality, knowledge_quality_log,
+--       feedback_watermark, feedback_run, search_log, search_impression, search_event, query_feedback, user_session,
+--       session_turn, model_cache, eval_set, eval_query 共十九张表
 -- 核心优化:
 -- 1. `import_task` 表中的 `items` 字段被拆分为独立的 `import_task_item` 表，实现结构规范化。
 -- 2. 所有表结构一次性定义，避免后期 ALTER TABLE 操作。
 -- 3. 状态字段 (`status`) 均使用 ENUM 类型进行优化。
 -- 4. 索引经过设计，避免冗余并提高核心查询场景的性能。
 -- 5. 自动更新时间戳字段 (`updated_at`) 均已配置。
+-- 6. 由初始版本升级的数据库先执行 upgrade.sql 为已有表补充字段与索引，再执行本脚本创建新增的表。
 -- =================================================================
 
 -- 步骤 1: 创建数据库 (如果不存在)
@@ -22,15 +25,35 @@ CREATE TABLE IF NOT EXISTS `knowledge` (
   `content` text NOT NULL COMMENT '知识内容',
   `labels` json DEFAULT NULL COMMENT '标签分数数组',
   `summary` text DEFAULT NULL COMMENT '内容摘要',
+  `metadata` json DEFAULT NULL COMMENT '用户自定义元数据',
   `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
   `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
   PRIMARY KEY (`id`),
   KEY `idx_repo_name` (`repo_name`),
-  FULLTEXT KEY `idx_content` (`content`) COMMENT '内容全文索引',
-  FULLTEXT KEY `idx_summary` (`summary`) COMMENT '摘要全文索引'
+  FULLTEXT KEY `idx_content` (`content`) WITH PARSER ngram COMMENT '内容全文索引，ngram 分词以支持中文关键词检索',
+  FULLTEXT KEY `idx_summary` (`summary`) WITH PARSER ngram COMMENT '摘要全文索引，ngram 分词以支持中文关键词检索'
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='知识条目表';
 
 
+-- 步骤 3.1: 创建知识库映射表
+-- 知识库名称只是展示用的名字，Qdrant 中通过别名 `alias` 指向实际的物理集合 `collection`，
+-- 重命名知识库时只需修改本表与按名称关联的表（knowledge、eval_set、检索记录、展示记录与查询反馈），无需迁移向量数据。
+CREATE TABLE IF NOT EXISTS `knowledge_repo` (
+  `id` varchar(32) NOT NULL COMMENT '知识库内部ID',
+  `name` varchar(64) NOT NULL COMMENT '知识库名称',
+  `alias` varchar(64) NOT NULL COMMENT 'Qdrant集合别名',
+  `collection` varchar(255) NOT NULL COMMENT 'Qdrant物理集合名称',
+  `doc_count` bigint UNSIGNED NOT NULL DEFAULT 0 COMMENT '已建立词法索引的文档数',
+  `token_count` bigint UNSIGNED NOT NULL DEFAULT 0 COMMENT '已建立词法索引的词条总数',
+  `metadata_schema` json DEFAULT NULL COMMENT '元数据字段声明',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_name` (`name`),
+  UNIQUE KEY `uk_alias` (`alias`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='知识库与Qdrant集合映射表';
+
+
 -- 步骤 4: 创建导入任务表 (优化版 - 移除 items 字段)
 CREATE TABLE IF NOT EXISTS `import_task` (
   `id` varchar(36) NOT NULL COMMENT '任务ID',
@@ -80,17 +103,218 @@ CREATE TABLE IF NOT EXISTS `task_queue` (
 -- 创建反馈表
 CREATE TABLE IF NOT EXISTS `feedback` (
   `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
-  `session_id` varchar(64) NOT NULL COMMENT '用户会话ID',
+  `session_id` varchar(64) NOT NULL DEFAULT '' COMMENT '用户会话ID，匿名反馈时为空',
   `user_query` text NOT NULL COMMENT '用户查询内容',
   `retrieved_knowledge_id` varchar(36) NOT NULL COMMENT '被检索到的知识ID',
   `action` ENUM('like', 'dislike') NOT NULL COMMENT '反馈操作类型',
+  `turn_id` BIGINT UNSIGNED DEFAULT NULL COMMENT '关联的会话轮次ID',
+  `search_id` varchar(36) DEFAULT NULL COMMENT '产生该反馈的检索ID',
+  `anonymous` tinyint(1) NOT NULL DEFAULT 0 COMMENT '是否匿名反馈（没有会话ID）',
   `timestamp` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '反馈时间',
   PRIMARY KEY (`id`),
   KEY `idx_knowledge_id` (`retrieved_knowledge_id`),
+  KEY `idx_session_id` (`session_id`),
+  KEY `idx_search_id` (`search_id`),
   KEY `idx_timestamp` (`timestamp`),
   CONSTRAINT `fk_feedback_knowledge` FOREIGN KEY (`retrieved_knowledge_id`) REFERENCES `knowledge` (`id`) ON DELETE CASCADE ON UPDATE CASCADE
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户反馈数据表';
 
+-- 创建知识质量分数表
+-- 由用户反馈等信号累积的质量分数，与大模型标签分开保存，检索排序时按半衰期衰减后作为提升系数
+CREATE TABLE IF NOT EXISTS `knowledge_quality` (
+  `knowledge_id` varchar(36) NOT NULL COMMENT '知识ID',
+  `score` double NOT NULL DEFAULT 0 COMMENT '最近一次调整后的质量分数，读取时按距 updated_at 的时间衰减',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '最近一次调整时间',
+  PRIMARY KEY (`knowledge_id`),
+  CONSTRAINT `fk_knowledge_quality_knowledge` FOREIGN KEY (`knowledge_id`) REFERENCES `knowledge` (`id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='知识质量分数表';
+
+-- 创建知识质量调整日志表
+-- 每次调整质量分数都记录一条，用于审计；知识删除后日志保留
+CREATE TABLE IF NOT EXISTS `knowledge_quality_log` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `knowledge_id` varchar(36) NOT NULL COMMENT '知识ID',
+  `source` varchar(32) NOT NULL COMMENT '调整来源，如 feedback',
+  `likes` INT UNSIGNED NOT NULL DEFAULT 0 COMMENT '本次计入的点赞数',
+  `dislikes` INT UNSIGNED NOT NULL DEFAULT 0 COMMENT '本次计入的点踩数',
+  `score_before` double NOT NULL COMMENT '调整前的质量分数（已衰减到调整时刻）',
+  `delta` double NOT NULL COMMENT '调整量',
+  `score_after` double NOT NULL COMMENT '调整后的质量分数（已限制在上下限内）',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '调整时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_knowledge_id` (`knowledge_id`, `id`),
+  KEY `idx_created_at` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='知识质量调整日志表';
+
+-- 创建反馈水位线表
+-- 记录各反馈处理任务已处理到的最大反馈ID，每条反馈只被处理一次
+CREATE TABLE IF NOT EXISTS `feedback_watermark` (
+  `name` varchar(32) NOT NULL COMMENT '处理任务名称',
+  `feedback_id` BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT '已处理的最大反馈ID',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  PRIMARY KEY (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='反馈水位线表';
+
+-- 创建反馈处理记录表
+CREATE TABLE IF NOT EXISTS `feedback_run` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `trigger_type` ENUM('cron', 'manual') NOT NULL COMMENT '触发方式',
+  `status` ENUM('succeeded', 'failed') NOT NULL COMMENT '处理结果',
+  `from_feedback_id` BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT '处理前的水位线（不含）',
+  `to_feedback_id` BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT '处理后的水位线（含），失败时与处理前相同',
+  `feedbacks` INT UNSIGNED NOT NULL DEFAULT 0 COMMENT '处理的反馈数量',
+  `adjusted` INT UNSIGNED NOT NULL DEFAULT 0 COMMENT '调整质量分数的知识数量',
+  `error` text DEFAULT NULL COMMENT '失败原因',
+  `started_at` datetime NOT NULL COMMENT '开始时间',
+  `finished_at` datetime NOT NULL COMMENT '结束时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_started_at` (`started_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='反馈处理记录表';
+
+-- 创建检索记录表
+-- 每次检索与答案生成的请求参数、结果与耗时，按 search.log 配置抽样记录并定期清理
+CREATE TABLE IF NOT EXISTS `search_log` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `search_id` varchar(36) NOT NULL COMMENT '检索ID，与展示记录、检索行为及反馈中的 search_id 对应',
+  `source` ENUM('search', 'answer') NOT NULL COMMENT '检索来源',
+  `session_id` varchar(64) NOT NULL DEFAULT '' COMMENT '用户会话ID，未指定时为空',
+  `query` text NOT NULL COMMENT '用户原始查询',
+  `search_query` text NOT NULL COMMENT '实际用于检索的查询，多轮会话中为改写后的查询',
+  `repo_names` json NOT NULL COMMENT '检索的知识库，空数组表示全部知识库',
+  `mode` varchar(16) NOT NULL DEFAULT '' COMMENT '检索模式',
+  `fusion` varchar(64) NOT NULL DEFAULT '' COMMENT '实际使用的融合策略',
+  `params` json DEFAULT NULL COMMENT '检索参数',
+  `filter` json DEFAULT NULL COMMENT '过滤条件',
+  `results` json DEFAULT NULL COMMENT '返回的知识ID与分数',
+  `result_count` INT UNSIGNED NOT NULL DEFAULT 0 COMMENT '返回的结果数量',
+  `total` BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT '满足过滤条件的候选总数',
+  `top_score` double DEFAULT NULL COMMENT '第一条结果的排序分数，没有结果时为 NULL',
+  `cached` TINYINT(1) NOT NULL DEFAULT 0 COMMENT '是否命中检索缓存',
+  `latency_ms` double NOT NULL DEFAULT 0 COMMENT '总耗时（毫秒）',
+  `timings` json DEFAULT NULL COMMENT '各阶段耗时',
+  `error` text DEFAULT NULL COMMENT '失败原因',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '检索时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_search_id` (`search_id`),
+  KEY `idx_session_id` (`session_id`, `id`),
+  KEY `idx_created_at` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='检索记录表';
+
+-- 创建检索展示记录表
+-- 每次检索或答案生成返回的知识条目各记录一行，与点击/复制行为一起用于计算条目的点击率
+CREATE TABLE IF NOT EXISTS `search_impression` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `search_id` varchar(36) NOT NULL COMMENT '检索ID，同一次检索返回的条目相同',
+  `session_id` varchar(64) NOT NULL DEFAULT '' COMMENT '用户会话ID，未指定时为空',
+  `source` ENUM('search', 'answer') NOT NULL COMMENT '展示来源',
+  `query` text NOT NULL COMMENT '实际用于检索的查询',
+  `knowledge_id` varchar(36) NOT NULL COMMENT '展示的知识ID',
+  `repo_name` varchar(64) NOT NULL COMMENT '知识库名称',
+  `position` INT UNSIGNED NOT NULL COMMENT '展示位置，从1开始，含翻页偏移',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '展示时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_search_id` (`search_id`, `knowledge_id`),
+  KEY `idx_knowledge_id` (`knowledge_id`, `created_at`),
+  KEY `idx_session_id` (`session_id`),
+  KEY `idx_created_at` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='检索展示记录表';
+
+-- 创建检索行为表
+-- 用户对检索结果的点击、复制等隐式反馈，必须对应一条展示记录
+CREATE TABLE IF NOT EXISTS `search_event` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `search_id` varchar(36) NOT NULL COMMENT '检索ID',
+  `session_id` varchar(64) NOT NULL DEFAULT '' COMMENT '用户会话ID',
+  `knowledge_id` varchar(36) NOT NULL COMMENT '知识ID',
+  `event` ENUM('click', 'copy') NOT NULL COMMENT '行为类型',
+  `position` INT UNSIGNED NOT NULL COMMENT '知识在该次检索中的展示位置',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '行为时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_search_id` (`search_id`, `knowledge_id`, `event`),
+  KEY `idx_created_at` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='检索行为表';
+
+-- 创建查询反馈表
+-- 针对整次检索的反馈，如“没有相关结果”，用于发现知识库中缺失的内容
+CREATE TABLE IF NOT EXISTS `query_feedback` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `search_id` varchar(36) NOT NULL DEFAULT '' COMMENT '关联的检索ID，未关联时为空',
+  `session_id` varchar(64) NOT NULL DEFAULT '' COMMENT '用户会话ID，匿名反馈时为空',
+  `query` text NOT NULL COMMENT '用户查询',
+  `repo_name` varchar(64) NOT NULL DEFAULT '' COMMENT '知识库名称，跨知识库或未知时为空',
+  `rating` ENUM('no_relevant', 'partial', 'helpful') NOT NULL COMMENT '整体评价',
+  `comment` text DEFAULT NULL COMMENT '补充说明',
+  `anonymous` tinyint(1) NOT NULL DEFAULT 0 COMMENT '是否匿名反馈（没有会话ID）',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '反馈时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_session_id` (`session_id`),
+  KEY `idx_repo_name` (`repo_name`, `created_at`),
+  KEY `idx_created_at` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='查询反馈表';
+
+-- 创建用户会话表
+-- 由 POST /session 签发的会话ID，开启 session.validate 时检索、答案生成与反馈只接受这里签发且未过期的会话ID
+CREATE TABLE IF NOT EXISTS `user_session` (
+  `id` varchar(36) NOT NULL COMMENT '会话ID，服务端生成UUID',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '签发时间',
+  `expires_at` datetime DEFAULT NULL COMMENT '过期时间，为空表示不过期',
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户会话表';
+
+-- 创建会话轮次表
+-- 记录多轮检索中每一轮的原始查询与改写后的独立查询，用于改写后续追问并关联反馈
+CREATE TABLE IF NOT EXISTS `session_turn` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `session_id` varchar(64) NOT NULL COMMENT '用户会话ID',
+  `query` text NOT NULL COMMENT '用户原始查询',
+  `rewritten_query` text NOT NULL COMMENT '改写后的独立查询',
+  `knowledge_ids` json DEFAULT NULL COMMENT '本轮返回的知识ID列表',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_session_id` (`session_id`, `id`),
+  KEY `idx_created_at` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='会话轮次表';
+
+-- 创建模型结果缓存表
+-- 缓存向量化与标签打分的结果，键包含模型名称与提示词版本，模型或提示词变化后旧记录不再命中
+CREATE TABLE IF NOT EXISTS `model_cache` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `kind` varchar(16) NOT NULL COMMENT '缓存类型：embedding/classify',
+  `model` varchar(128) NOT NULL COMMENT '模型名称',
+  `version` varchar(64) NOT NULL DEFAULT '' COMMENT '提示词版本，向量化为空',
+  `content_hash` char(64) NOT NULL COMMENT '输入内容的SHA-256',
+  `value` mediumtext NOT NULL COMMENT '缓存结果JSON',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_lookup` (`kind`, `model`, `version`, `content_hash`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='模型结果缓存表';
+
+-- 创建检索评测集表
+-- 每个评测集属于一个知识库，包含若干条带相关性标注的查询，用于离线评测检索配置
+CREATE TABLE IF NOT EXISTS `eval_set` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `repo_name` varchar(64) NOT NULL COMMENT '知识库名称',
+  `name` varchar(64) NOT NULL COMMENT '评测集名称',
+  `description` varchar(255) NOT NULL DEFAULT '' COMMENT '评测集说明',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_repo_name` (`repo_name`, `name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='检索评测集表';
+
+-- 创建评测查询表
+-- judgments 为相关知识及其相关度等级，如 [{"id":"...","grade":3}]，等级越高越相关
+CREATE TABLE IF NOT EXISTS `eval_query` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `set_id` BIGINT UNSIGNED NOT NULL COMMENT '所属评测集ID',
+  `query` text NOT NULL COMMENT '查询内容',
+  `judgments` json NOT NULL COMMENT '相关知识ID与相关度等级',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_set_id` (`set_id`),
+  CONSTRAINT `fk_eval_query_set` FOREIGN KEY (`set_id`) REFERENCES `eval_set` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='评测查询表';
+
 
 -- 步骤 7: 创建用户并授权 (可选，根据实际情况修改)
 -- CREATE USER 'knowledge_user'@'%' IDENTIFIED BY 'knowledge_password';
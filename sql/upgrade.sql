@@ -0,0 +1,39 @@
+-- =================================================================
+-- 知识库系统数据库升级脚本
+-- 适用于由初始版本（knowledge, import_task, import_task_item, task_queue, feedback 五张表）升级的数据库，
+-- 为已有表补充后续版本新增的字段与索引；新增的表由 init.sql 中的 CREATE TABLE IF NOT EXISTS 创建。
+-- 升级步骤:
+-- 1. 执行本脚本（只需执行一次，MySQL 不支持 ADD COLUMN IF NOT EXISTS，重复执行会因字段已存在而报错）。
+-- 2. 再执行 init.sql 创建新增的表，已存在的表不受影响。
+-- =================================================================
+
+USE `knowledge_system`;
+
+-- 步骤 1: 知识表
+-- 新增用户自定义元数据；全文索引改用 ngram 分词以支持中文关键词检索，重建索引耗时与数据量成正比
+ALTER TABLE `knowledge`
+  ADD COLUMN `metadata` json DEFAULT NULL COMMENT '用户自定义元数据' AFTER `summary`,
+  DROP INDEX `idx_content`,
+  DROP INDEX `idx_summary`;
+ALTER TABLE `knowledge`
+  ADD FULLTEXT KEY `idx_content` (`content`) WITH PARSER ngram COMMENT '内容全文索引，ngram 分词以支持中文关键词检索';
+ALTER TABLE `knowledge`
+  ADD FULLTEXT KEY `idx_summary` (`summary`) WITH PARSER ngram COMMENT '摘要全文索引，ngram 分词以支持中文关键词检索';
+
+-- 步骤 2: 反馈表
+-- 新增会话轮次、检索ID与匿名标记；session_id 允许为空以记录匿名反馈
+ALTER TABLE `feedback`
+  MODIFY COLUMN `session_id` varchar(64) NOT NULL DEFAULT '' COMMENT '用户会话ID，匿名反馈时为空',
+  ADD COLUMN `turn_id` BIGINT UNSIGNED DEFAULT NULL COMMENT '关联的会话轮次ID' AFTER `action`,
+  ADD COLUMN `search_id` varchar(36) DEFAULT NULL COMMENT '产生该反馈的检索ID' AFTER `turn_id`,
+  ADD COLUMN `anonymous` tinyint(1) NOT NULL DEFAULT 0 COMMENT '是否匿名反馈（没有会话ID）' AFTER `search_id`,
+  ADD KEY `idx_session_id` (`session_id`),
+  ADD KEY `idx_search_id` (`search_id`);
+
+-- 初始版本未传会话ID的反馈使用固定的测试会话ID，升级后视为匿名反馈
+UPDATE `feedback`
+SET `session_id` = '', `anonymous` = 1
+WHERE `session_id` = '90c91010-d24b-4056-9c46-89aafe0ed4cb';
+
+-- 步骤 3: 执行 init.sql 创建新增的表
+-- mysql -u root -p < sql/init.sql